@@ -0,0 +1,45 @@
+// Package broker holds the domain.Broker adapters the outbox relay publishes
+// order events with.
+package broker
+
+import (
+	"fmt"
+	"net/url"
+	"order/domain"
+	"strings"
+)
+
+// Open returns the broker of rawURL, one of
+//
+//	nats://[user:password@]host:4222
+//	kafka+http://host:8082/topic (or kafka+https), through the Kafka REST proxy
+//	file:///path/to/events.jsonl
+//	memory
+func Open(rawURL string) (domain.Broker, error) {
+	if rawURL == "memory" {
+		return NewMemoryBroker(), nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("broker url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "nats":
+		return NewNATSBroker(u), nil
+	case "kafka+http", "kafka+https":
+		topic := strings.Trim(u.Path, "/")
+		if topic == "" || strings.Contains(topic, "/") {
+			return nil, fmt.Errorf("broker url %s: the path must be the topic", rawURL)
+		}
+
+		proxy := url.URL{Scheme: strings.TrimPrefix(u.Scheme, "kafka+"), User: u.User, Host: u.Host}
+
+		return NewKafkaBroker(proxy.String(), topic, nil), nil
+	case "file":
+		return NewFileBroker(u.Path)
+	}
+
+	return nil, fmt.Errorf("broker url %s: unsupported scheme %q", rawURL, u.Scheme)
+}
@@ -0,0 +1,46 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"order/domain"
+	"os"
+	"sync"
+)
+
+// FileBroker appends events to a file, one JSON object per line.
+type FileBroker struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileBroker(path string) (*FileBroker, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileBroker{file: file}, nil
+}
+
+// Publish syncs the file before returning, so that a published event
+// survives a crash.
+func (f *FileBroker) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return f.file.Sync()
+}
+
+func (f *FileBroker) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,111 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"order/domain"
+	"time"
+)
+
+// KafkaBroker produces events to a Kafka topic through the Confluent REST
+// proxy (API v2). Events are keyed by order ID, so the events of an order
+// land in one partition, in order.
+type KafkaBroker struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewKafkaBroker produces to topic through the REST proxy at proxyURL. A nil
+// client uses one timing out after 10 seconds.
+func NewKafkaBroker(proxyURL string, topic string, client *http.Client) *KafkaBroker {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &KafkaBroker{
+		endpoint: proxyURL + "/topics/" + url.PathEscape(topic),
+		client:   client,
+	}
+}
+
+type kafkaRecord struct {
+	Key   string             `json:"key"`
+	Value domain.OutboxEvent `json:"value"`
+}
+
+type kafkaOffsets struct {
+	Offsets []struct {
+		Partition int64  `json:"partition"`
+		Offset    int64  `json:"offset"`
+		ErrorCode int64  `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// Publish returns domain.ErrEventRejected when the proxy refuses the record
+// itself, and a plain error when the failure may be temporary.
+func (k *KafkaBroker) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	body, err := json.Marshal(map[string][]kafkaRecord{
+		"records": {{Key: event.OrderId, Value: event}},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("kafka rest proxy: %s: %s", resp.Status, bytes.TrimSpace(data))
+		// only a record too large or invalid fails every time; credentials,
+		// topics and endpoints are configuration, which gets fixed
+		if resp.StatusCode == http.StatusRequestEntityTooLarge || resp.StatusCode == http.StatusUnprocessableEntity {
+			err = fmt.Errorf("%w: %v", domain.ErrEventRejected, err)
+		}
+
+		return err
+	}
+
+	var offsets kafkaOffsets
+	if err := json.Unmarshal(data, &offsets); err != nil {
+		return fmt.Errorf("kafka rest proxy: %w", err)
+	}
+	for _, offset := range offsets.Offsets {
+		switch offset.ErrorCode {
+		case 0:
+		case 1:
+			// error code 1 is a non-retriable error
+			return fmt.Errorf("%w: kafka: %s", domain.ErrEventRejected, offset.Error)
+		default:
+			return fmt.Errorf("kafka: %s", offset.Error)
+		}
+	}
+
+	return nil
+}
+
+func (k *KafkaBroker) Close() error {
+	k.client.CloseIdleConnections()
+
+	return nil
+}
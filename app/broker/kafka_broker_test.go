@@ -0,0 +1,54 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"order/domain"
+	"testing"
+)
+
+func TestKafkaBrokerRejections(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		failed   bool
+		rejected bool
+	}{
+		{"produced", http.StatusOK, `{"offsets":[{"partition":0,"offset":1}]}`, false, false},
+		{"record too large", http.StatusRequestEntityTooLarge, `{"error_code":413}`, true, true},
+		{"invalid record", http.StatusUnprocessableEntity, `{"error_code":42201}`, true, true},
+		{"non-retriable offset error", http.StatusOK, `{"offsets":[{"error_code":1,"error":"record too large"}]}`, true, true},
+		{"unauthorized", http.StatusUnauthorized, `{"error_code":40101}`, true, false},
+		{"forbidden", http.StatusForbidden, `{"error_code":40301}`, true, false},
+		{"missing topic", http.StatusNotFound, `{"error_code":40401}`, true, false},
+		{"throttled", http.StatusTooManyRequests, ``, true, false},
+		{"unavailable", http.StatusServiceUnavailable, ``, true, false},
+		{"retriable offset error", http.StatusOK, `{"offsets":[{"error_code":2,"error":"leader not available"}]}`, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/topics/orders" {
+					t.Errorf("produced to %s; want /topics/orders", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer proxy.Close()
+
+			b := NewKafkaBroker(proxy.URL, "orders", proxy.Client())
+			err := b.Publish(context.Background(), domain.OutboxEvent{EventId: "e1", OrderId: "o1"})
+
+			if rejected := errors.Is(err, domain.ErrEventRejected); rejected != tt.rejected {
+				t.Errorf("Publish = %v; want rejected %v", err, tt.rejected)
+			}
+			if failed := err != nil; failed != tt.failed {
+				t.Errorf("Publish = %v; want failed %v", err, tt.failed)
+			}
+		})
+	}
+}
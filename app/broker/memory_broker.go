@@ -0,0 +1,44 @@
+package broker
+
+import (
+	"context"
+	"order/domain"
+	"sync"
+)
+
+// MemoryBroker hands events to in-process subscribers, for local runs and
+// tests.
+type MemoryBroker struct {
+	mu          sync.RWMutex
+	subscribers []func(ctx context.Context, event domain.OutboxEvent) error
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{}
+}
+
+// Subscribe calls handler with every event published from now on. An error
+// from handler fails the publication, which is retried.
+func (m *MemoryBroker) Subscribe(handler func(ctx context.Context, event domain.OutboxEvent) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.subscribers = append(m.subscribers, handler)
+}
+
+func (m *MemoryBroker) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, handler := range m.subscribers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryBroker) Close() error {
+	return nil
+}
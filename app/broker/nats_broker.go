@@ -0,0 +1,183 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"order/domain"
+	"strings"
+	"sync"
+	"time"
+)
+
+// natsTimeout bounds a publication when the context has no deadline.
+const natsTimeout = 10 * time.Second
+
+// NATSBroker publishes each event on the subject named after its type, e.g.
+// "order.settled". It speaks the NATS text protocol and waits for the server
+// to answer a PING after each event, so a returned Publish was received.
+// With JetStream, the event ID is sent as Nats-Msg-Id so that events
+// published twice are dropped.
+type NATSBroker struct {
+	mu   sync.Mutex
+	url  *url.URL
+	conn net.Conn
+	r    *bufio.Reader
+	// headers and maxPayload are announced by the server.
+	headers    bool
+	maxPayload int64
+}
+
+// NewNATSBroker returns a broker publishing to the server at u. It connects
+// on the first event and reconnects after errors.
+func NewNATSBroker(u *url.URL) *NATSBroker {
+	return &NATSBroker{url: u}
+}
+
+func (n *NATSBroker) connect(deadline time.Time) (err error) {
+	host := n.url.Host
+	if n.url.Port() == "" {
+		host = net.JoinHostPort(n.url.Hostname(), "4222")
+	}
+
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.Dial("tcp", host)
+	if err != nil {
+		return
+	}
+	conn.SetDeadline(deadline)
+	n.conn, n.r = conn, bufio.NewReader(conn)
+
+	line, err := n.r.ReadString('\n')
+	if err != nil {
+		return
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("nats: unexpected greeting %q", line)
+	}
+	info := strings.TrimSpace(strings.TrimPrefix(line, "INFO "))
+
+	var server struct {
+		Headers    bool  `json:"headers"`
+		MaxPayload int64 `json:"max_payload"`
+	}
+	if err = json.Unmarshal([]byte(info), &server); err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+	n.headers, n.maxPayload = server.Headers, server.MaxPayload
+
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"headers":  n.headers,
+		"name":     "order",
+		"lang":     "go",
+		"version":  "1",
+	}
+	if user := n.url.User; user != nil {
+		if password, ok := user.Password(); ok {
+			options["user"], options["pass"] = user.Username(), password
+		} else {
+			options["auth_token"] = user.Username()
+		}
+	}
+	data, err := json.Marshal(options)
+	if err != nil {
+		return
+	}
+
+	if _, err = fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", data); err != nil {
+		return
+	}
+
+	return n.pong()
+}
+
+// pong reads until the server answers the last PING.
+func (n *NATSBroker) pong() error {
+	for {
+		line, err := n.r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := n.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (n *NATSBroker) Publish(ctx context.Context, event domain.OutboxEvent) (err error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(natsTimeout)
+	}
+
+	// a broken connection is dropped, to be made again by the next event
+	defer func() {
+		if err != nil && n.conn != nil {
+			n.conn.Close()
+			n.conn = nil
+		}
+	}()
+
+	if n.conn == nil {
+		if err = n.connect(deadline); err != nil {
+			return
+		}
+	}
+	n.conn.SetDeadline(deadline)
+
+	if n.maxPayload > 0 && int64(len(payload)) > n.maxPayload {
+		return fmt.Errorf("%w: nats: %d bytes exceed the max payload of %d", domain.ErrEventRejected, len(payload), n.maxPayload)
+	}
+
+	var frame strings.Builder
+	if n.headers {
+		header := "NATS/1.0\r\nNats-Msg-Id: " + event.EventId + "\r\n\r\n"
+		fmt.Fprintf(&frame, "HPUB %s %d %d\r\n%s", event.Type, len(header), len(header)+len(payload), header)
+	} else {
+		fmt.Fprintf(&frame, "PUB %s %d\r\n", event.Type, len(payload))
+	}
+	frame.Write(payload)
+	frame.WriteString("\r\nPING\r\n")
+
+	if _, err = n.conn.Write([]byte(frame.String())); err != nil {
+		return
+	}
+
+	return n.pong()
+}
+
+func (n *NATSBroker) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		return nil
+	}
+
+	err := n.conn.Close()
+	n.conn = nil
+
+	return err
+}
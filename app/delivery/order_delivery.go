@@ -9,13 +9,23 @@ import (
 )
 
 type OrderDelivery struct {
-	usecase domain.OrderUsecase
+	usecase      domain.OrderUsecase
+	coupons      domain.CouponUsecase
+	entitlements domain.EntitlementUsecase
+	webhooks     domain.WebhookUsecase
+	invoices     domain.InvoiceUsecase
+	ledger       domain.LedgerUsecase
 	pb.UnimplementedOrderServiceServer
 }
 
-func NewOrderDelivery(usecase domain.OrderUsecase) *OrderDelivery {
+func NewOrderDelivery(usecase domain.OrderUsecase, coupons domain.CouponUsecase, entitlements domain.EntitlementUsecase, webhooks domain.WebhookUsecase, invoices domain.InvoiceUsecase, ledger domain.LedgerUsecase) *OrderDelivery {
 	return &OrderDelivery{
-		usecase: usecase,
+		usecase:      usecase,
+		coupons:      coupons,
+		entitlements: entitlements,
+		webhooks:     webhooks,
+		invoices:     invoices,
+		ledger:       ledger,
 	}
 }
 
@@ -23,19 +33,38 @@ func NewOrderDelivery(usecase domain.OrderUsecase) *OrderDelivery {
 // func (pd *OrderDelivery) Delete(ctx context.Context, req *pb.) (res *pb., err error) {}
 
 func (o *OrderDelivery) SumIncome(ctx context.Context, req *pb.OrderSumIncomeRequest) (res *pb.OrderSumResponse, err error) {
-	total, err := o.usecase.SumIncome(ctx, req)
+	totals, err := o.usecase.SumIncome(ctx, req)
 	if err == mongo.ErrNoDocuments {
 		res = &pb.OrderSumResponse{IsEmpty: true}
 		err = nil
 
 		return
 	}
+	if err != nil {
+		return
+	}
 
-	res = &pb.OrderSumResponse{Payload: &pb.OrderSumPayload{Total: total}}
+	payload := &pb.OrderSumPayload{}
+	for _, total := range totals {
+		payload.Totals = append(payload.Totals, &pb.Money{Currency: total.Currency, Amount: total.Amount})
+	}
+
+	// a single total is only meaningful when every order shares a currency
+	if len(totals) == 1 {
+		payload.Total = totals[0].Amount
+	}
+
+	res = &pb.OrderSumResponse{Payload: payload}
 
 	return
 }
 
+func (o *OrderDelivery) Expire(ctx context.Context, req *pb.OrderExpireRequest) (res *pb.OrderExpireResponse, err error) {
+	affected, err := o.usecase.Expire(ctx, req)
+
+	return &pb.OrderExpireResponse{Affected: affected}, err
+}
+
 func (o *OrderDelivery) Cancel(ctx context.Context, req *pb.OrderCancelRequest) (res *pb.OperationResponse, err error) {
 	res, err = o.usecase.Cancel(ctx, req)
 	return
@@ -59,9 +88,152 @@ func (o *OrderDelivery) ChangeStatus(ctx context.Context, req *pb.OrderChangeSta
 	return &pb.OperationResponse{IsAffected: affected}, err
 }
 
-func (o *OrderDelivery) Create(ctx context.Context, req *pb.OrderCreateRequest) (res *pb.Empty, err error) {
-	err = o.usecase.Save(ctx, req)
-	res = &pb.Empty{}
+func (o *OrderDelivery) Create(ctx context.Context, req *pb.OrderCreateRequest) (res *pb.Order, err error) {
+	res, err = o.usecase.Save(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) CreateCoupon(ctx context.Context, req *pb.Coupon) (res *pb.OperationResponse, err error) {
+	err = o.coupons.Create(ctx, req)
+
+	return &pb.OperationResponse{IsAffected: err == nil}, err
+}
+
+func (o *OrderDelivery) FindCoupon(ctx context.Context, req *pb.CouponFindOneRequest) (res *pb.CouponFindOneResponse, err error) {
+	res, err = o.coupons.FindOne(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) TaxReport(ctx context.Context, req *pb.OrderTaxReportRequest) (res *pb.OrderTaxReportResponse, err error) {
+	res, err = o.usecase.TaxReport(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) Refund(ctx context.Context, req *pb.OrderRefundRequest) (res *pb.OrderRefundResponse, err error) {
+	res, err = o.usecase.Refund(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) CheckEntitlement(ctx context.Context, req *pb.EntitlementCheckRequest) (res *pb.EntitlementCheckResponse, err error) {
+	res, err = o.entitlements.Check(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) ListEntitlements(ctx context.Context, req *pb.EntitlementListRequest) (res *pb.EntitlementListResponse, err error) {
+	res, err = o.entitlements.List(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) Renew(ctx context.Context, req *pb.OrderRenewRequest) (res *pb.OrderRenewResponse, err error) {
+	res, err = o.usecase.Renew(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) SetAutoRenew(ctx context.Context, req *pb.EntitlementAutoRenewRequest) (res *pb.OperationResponse, err error) {
+	res, err = o.entitlements.SetAutoRenew(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) QuotePlanChange(ctx context.Context, req *pb.PlanChangeRequest) (res *pb.PlanChangeQuote, err error) {
+	res, err = o.usecase.QuotePlanChange(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) ChangePlan(ctx context.Context, req *pb.PlanChangeRequest) (res *pb.PlanChangeResponse, err error) {
+	res, err = o.usecase.ChangePlan(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) Quote(ctx context.Context, req *pb.OrderCreateRequest) (res *pb.OrderQuote, err error) {
+	res, err = o.usecase.Quote(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) CreateWebhook(ctx context.Context, req *pb.WebhookSubscription) (res *pb.WebhookSubscription, err error) {
+	res, err = o.webhooks.Create(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) UpdateWebhook(ctx context.Context, req *pb.WebhookUpdateRequest) (res *pb.WebhookSubscription, err error) {
+	res, err = o.webhooks.Update(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) DeleteWebhook(ctx context.Context, req *pb.WebhookDeleteRequest) (res *pb.OperationResponse, err error) {
+	res, err = o.webhooks.Delete(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) ListWebhooks(ctx context.Context, req *pb.WebhookListRequest) (res *pb.WebhookListResponse, err error) {
+	res, err = o.webhooks.List(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) ListWebhookDeliveries(ctx context.Context, req *pb.WebhookDeliveryListRequest) (res *pb.WebhookDeliveryListResponse, err error) {
+	res, err = o.webhooks.ListDeliveries(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) ReplayWebhookDelivery(ctx context.Context, req *pb.WebhookReplayRequest) (res *pb.WebhookDelivery, err error) {
+	res, err = o.webhooks.Replay(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) DispatchWebhooks(ctx context.Context, req *pb.WebhookDispatchRequest) (res *pb.WebhookDispatchResponse, err error) {
+	res, err = o.webhooks.Dispatch(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) GetInvoice(ctx context.Context, req *pb.InvoiceRequest) (res *pb.Invoice, err error) {
+	res, err = o.invoices.Get(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) GetPaymentQR(ctx context.Context, req *pb.PaymentQRRequest) (res *pb.PaymentQR, err error) {
+	res, err = o.usecase.PaymentQR(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) RetryPayment(ctx context.Context, req *pb.RetryPaymentRequest) (res *pb.Order, err error) {
+	res, err = o.usecase.RetryPayment(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) Reconcile(ctx context.Context, req *pb.ReconcileRequest) (res *pb.ReconciliationReport, err error) {
+	res, err = o.usecase.Reconcile(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) GetLedgerBalances(ctx context.Context, req *pb.LedgerBalanceRequest) (res *pb.LedgerBalanceResponse, err error) {
+	res, err = o.ledger.Balances(ctx, req)
+
+	return
+}
+
+func (o *OrderDelivery) CheckLedger(ctx context.Context, req *pb.LedgerCheckRequest) (res *pb.LedgerCheckResponse, err error) {
+	res, err = o.ledger.Check(ctx, req)
 
 	return
 }
@@ -0,0 +1,349 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"order/pb"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Gateway serves the OrderService over HTTP/JSON by translating every
+// request into a call on the gRPC handler.
+type Gateway struct {
+	server    pb.OrderServiceServer
+	routes    []*route
+	openapi   []byte
+	marshal   protojson.MarshalOptions
+	unmarshal protojson.UnmarshalOptions
+}
+
+// NewGateway creates a new Gateway that dispatches to the given OrderService handler.
+func NewGateway(server pb.OrderServiceServer) *Gateway {
+	g := &Gateway{
+		server:    server,
+		marshal:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+		unmarshal: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}
+
+	service := pb.File_pb_order_proto.Services().ByName(protoreflect.Name(pb.OrderService_ServiceDesc.ServiceName))
+	for _, binding := range bindings {
+		method := service.Methods().ByName(protoreflect.Name(binding.rpc))
+		if method == nil {
+			panic(fmt.Sprintf("gateway: route %s %s refers to unknown method %s", binding.verb, binding.path, binding.rpc))
+		}
+
+		g.routes = append(g.routes, newRoute(binding, method))
+	}
+
+	// every method must be reachable over HTTP
+	for _, desc := range pb.OrderService_ServiceDesc.Methods {
+		if g.find(desc.MethodName) == nil {
+			panic(fmt.Sprintf("gateway: method %s has no HTTP route", desc.MethodName))
+		}
+	}
+
+	g.openapi = g.buildOpenAPI()
+
+	return g
+}
+
+func (g *Gateway) find(rpc string) *route {
+	for _, r := range g.routes {
+		if r.rpc == rpc {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// OpenAPI returns the generated OpenAPI document describing the routes.
+func (g *Gateway) OpenAPI() []byte {
+	return g.openapi
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == "/v1/openapi.json" {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(g.openapi)
+
+		return
+	}
+
+	pathMatched := false
+	for _, rt := range g.routes {
+		params, ok := rt.match(r.URL.Path)
+		if !ok {
+			continue
+		}
+
+		pathMatched = true
+		if rt.verb != r.Method {
+			continue
+		}
+
+		g.serve(w, r, rt, params)
+
+		return
+	}
+
+	if pathMatched {
+		g.writeError(w, status.Errorf(codes.Unimplemented, "method %s not allowed for %s", r.Method, r.URL.Path), http.StatusMethodNotAllowed)
+		return
+	}
+
+	g.writeError(w, status.Errorf(codes.NotFound, "no route for %s %s", r.Method, r.URL.Path), 0)
+}
+
+func (g *Gateway) serve(w http.ResponseWriter, r *http.Request, rt *route, params map[string]string) {
+	in := rt.input.New().Interface()
+
+	if rt.body {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			g.writeError(w, status.Errorf(codes.InvalidArgument, "read body: %v", err), 0)
+			return
+		}
+
+		if len(strings.TrimSpace(string(data))) > 0 {
+			if err := g.unmarshal.Unmarshal(data, in); err != nil {
+				g.writeError(w, status.Errorf(codes.InvalidArgument, "invalid body: %v", err), 0)
+				return
+			}
+		}
+	} else {
+		for key, values := range r.URL.Query() {
+			if len(values) == 0 {
+				continue
+			}
+
+			if err := setField(in.ProtoReflect(), key, values[len(values)-1]); err != nil {
+				g.writeError(w, status.Errorf(codes.InvalidArgument, "query parameter %q: %v", key, err), 0)
+				return
+			}
+		}
+	}
+
+	// path parameters always win over the body and query string
+	for key, value := range params {
+		if err := setField(in.ProtoReflect(), key, value); err != nil {
+			g.writeError(w, status.Errorf(codes.InvalidArgument, "path parameter %q: %v", key, err), 0)
+			return
+		}
+	}
+
+	dec := func(v interface{}) error {
+		proto.Merge(v.(proto.Message), in)
+		return nil
+	}
+
+	out, err := rt.handler(g.server, r.Context(), dec, nil)
+	if err != nil {
+		g.writeError(w, err, 0)
+		return
+	}
+
+	data, err := g.marshal.Marshal(out.(proto.Message))
+	if err != nil {
+		g.writeError(w, status.Errorf(codes.Internal, "marshal response: %v", err), 0)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// errorBody is the JSON document returned for every failed request.
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    int    `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// writeError renders err as JSON, deriving the HTTP status from the gRPC
+// status code unless httpStatus overrides it.
+func (g *Gateway) writeError(w http.ResponseWriter, err error, httpStatus int) {
+	st := status.Convert(err)
+	if httpStatus == 0 {
+		httpStatus = HTTPStatusFromCode(st.Code())
+	}
+
+	body, _ := json.Marshal(errorBody{Error: errorDetail{
+		Code:    httpStatus,
+		Status:  codeName(st.Code()),
+		Message: st.Message(),
+	}})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	w.Write(body)
+}
+
+// HTTPStatusFromCode maps a gRPC status code to the closest HTTP status.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// codeName returns the canonical upper snake case name of a status code,
+// e.g. NOT_FOUND.
+func codeName(code codes.Code) string {
+	if code == codes.OK {
+		return "OK"
+	}
+
+	name := code.String()
+	var b strings.Builder
+	for i, c := range name {
+		if i > 0 && c >= 'A' && c <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(c)
+	}
+
+	return strings.ToUpper(b.String())
+}
+
+// setField assigns a string value to the (possibly dotted) field path of msg,
+// accepting both the proto and the JSON name of every path element.
+func setField(msg protoreflect.Message, path string, value string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := findField(msg.Descriptor(), name)
+		if fd == nil {
+			return fmt.Errorf("unknown field %q", name)
+		}
+
+		if i < len(names)-1 {
+			if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+				return fmt.Errorf("field %q is not a message", name)
+			}
+
+			msg = msg.Mutable(fd).Message()
+			continue
+		}
+
+		v, err := parseScalar(fd, value)
+		if err != nil {
+			return err
+		}
+
+		if fd.IsList() {
+			msg.Mutable(fd).List().Append(v)
+		} else {
+			msg.Set(fd, v)
+		}
+	}
+
+	return nil
+}
+
+func findField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if fd := md.Fields().ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+
+	return md.Fields().ByJSONName(name)
+}
+
+func parseScalar(fd protoreflect.FieldDescriptor, value string) (v protoreflect.Value, err error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(value)), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(value)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(value, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(value, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(value, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.DoubleKind:
+		n, err := strconv.ParseFloat(value, 64)
+		return protoreflect.ValueOfFloat64(n), err
+	case protoreflect.FloatKind:
+		n, err := strconv.ParseFloat(value, 32)
+		return protoreflect.ValueOfFloat32(float32(n)), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(value)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), err
+	}
+
+	return v, fmt.Errorf("unsupported field type %s", fd.Kind())
+}
+
+// handlerFunc matches the signature of the generated gRPC method handlers.
+type handlerFunc func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error)
+
+func methodHandler(rpc string) handlerFunc {
+	for _, desc := range pb.OrderService_ServiceDesc.Methods {
+		if desc.MethodName == rpc {
+			return handlerFunc(desc.Handler)
+		}
+	}
+
+	return nil
+}
+
+func messageType(md protoreflect.MessageDescriptor) protoreflect.MessageType {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName())
+	if err != nil {
+		panic(fmt.Sprintf("gateway: %v", err))
+	}
+
+	return mt
+}
@@ -0,0 +1,194 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type object = map[string]interface{}
+
+// buildOpenAPI generates an OpenAPI 3 document from the route table and the
+// protobuf descriptors, so it never drifts from the served API.
+func (g *Gateway) buildOpenAPI() []byte {
+	schemas := object{
+		"Error": object{
+			"type": "object",
+			"properties": object{
+				"error": object{
+					"type": "object",
+					"properties": object{
+						"code":    object{"type": "integer"},
+						"status":  object{"type": "string"},
+						"message": object{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+
+	paths := object{}
+	for _, r := range g.routes {
+		addSchema(schemas, r.input.Descriptor())
+		addSchema(schemas, r.output.Descriptor())
+
+		var params []object
+		bound := map[string]bool{}
+		for _, name := range r.variables() {
+			bound[name] = true
+			params = append(params, object{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   fieldSchema(findField(r.input.Descriptor(), name)),
+			})
+		}
+
+		if !r.body {
+			fields := r.input.Descriptor().Fields()
+			for i := 0; i < fields.Len(); i++ {
+				fd := fields.Get(i)
+				if bound[string(fd.Name())] || fd.Kind() == protoreflect.MessageKind {
+					continue
+				}
+
+				params = append(params, object{
+					"name":   string(fd.Name()),
+					"in":     "query",
+					"schema": fieldSchema(fd),
+				})
+			}
+		}
+
+		op := object{
+			"operationId": "OrderService_" + r.rpc,
+			"tags":        []string{"OrderService"},
+			"responses": object{
+				"200": object{
+					"description": "A successful response.",
+					"content":     jsonContent(r.output.Descriptor()),
+				},
+				"default": object{
+					"description": "An error derived from the gRPC status.",
+					"content": object{
+						"application/json": object{"schema": object{"$ref": "#/components/schemas/Error"}},
+					},
+				},
+			},
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+		if r.body {
+			op["requestBody"] = object{"content": jsonContent(r.input.Descriptor())}
+		}
+
+		item, _ := paths[r.path].(object)
+		if item == nil {
+			item = object{}
+			paths[r.path] = item
+		}
+		item[strings.ToLower(r.verb)] = op
+	}
+
+	paths["/v1/openapi.json"] = object{
+		strings.ToLower(http.MethodGet): object{
+			"operationId": "OpenAPI",
+			"responses":   object{"200": object{"description": "This document."}},
+		},
+	}
+
+	doc := object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":   "OrderService",
+			"version": "v1",
+		},
+		"paths":      paths,
+		"components": object{"schemas": schemas},
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
+func jsonContent(md protoreflect.MessageDescriptor) object {
+	return object{
+		"application/json": object{"schema": object{"$ref": "#/components/schemas/" + string(md.Name())}},
+	}
+}
+
+// addSchema registers md and every message it references in schemas.
+func addSchema(schemas object, md protoreflect.MessageDescriptor) {
+	name := string(md.Name())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+
+	properties := object{}
+	schemas[name] = object{"type": "object", "properties": properties}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		properties[string(fd.Name())] = fieldSchema(fd)
+
+		if fd.IsMap() {
+			if v := fd.MapValue(); v.Kind() == protoreflect.MessageKind {
+				addSchema(schemas, v.Message())
+			}
+		} else if fd.Kind() == protoreflect.MessageKind {
+			addSchema(schemas, fd.Message())
+		}
+	}
+}
+
+func fieldSchema(fd protoreflect.FieldDescriptor) object {
+	if fd.IsMap() {
+		return object{"type": "object", "additionalProperties": kindSchema(fd.MapValue())}
+	}
+
+	if fd.IsList() {
+		return object{"type": "array", "items": kindSchema(fd)}
+	}
+
+	return kindSchema(fd)
+}
+
+func kindSchema(fd protoreflect.FieldDescriptor) object {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return object{"type": "string"}
+	case protoreflect.BytesKind:
+		return object{"type": "string", "format": "byte"}
+	case protoreflect.BoolKind:
+		return object{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return object{"type": "integer", "format": "int32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson encodes 64-bit integers as strings
+		return object{"type": "string", "format": "int64"}
+	case protoreflect.DoubleKind:
+		return object{"type": "number", "format": "double"}
+	case protoreflect.FloatKind:
+		return object{"type": "number", "format": "float"}
+	case protoreflect.EnumKind:
+		var values []string
+		for i := 0; i < fd.Enum().Values().Len(); i++ {
+			values = append(values, string(fd.Enum().Values().Get(i).Name()))
+		}
+		return object{"type": "string", "enum": values}
+	case protoreflect.MessageKind:
+		return object{"$ref": "#/components/schemas/" + string(fd.Message().Name())}
+	}
+
+	return object{}
+}
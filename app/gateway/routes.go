@@ -0,0 +1,136 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// binding maps an OrderService method to an HTTP verb and path template.
+// Path variables are written as {field} and may be followed by a :verb
+// suffix, e.g. /v1/orders/{order_id}:cancel.
+type binding struct {
+	verb string
+	path string
+	rpc  string
+	// body reports whether the request message is read from the JSON body
+	// instead of the query string.
+	body bool
+}
+
+var bindings = []binding{
+	{verb: http.MethodPost, path: "/v1/orders", rpc: "Create", body: true},
+	{verb: http.MethodGet, path: "/v1/orders", rpc: "FindAll"},
+	{verb: http.MethodPost, path: "/v1/orders:quote", rpc: "Quote", body: true},
+	{verb: http.MethodGet, path: "/v1/orders:sumIncome", rpc: "SumIncome"},
+	{verb: http.MethodPost, path: "/v1/orders:expire", rpc: "Expire", body: true},
+	{verb: http.MethodPost, path: "/v1/orders:renew", rpc: "Renew", body: true},
+	{verb: http.MethodGet, path: "/v1/orders:taxReport", rpc: "TaxReport"},
+	{verb: http.MethodPost, path: "/v1/orders:reconcile", rpc: "Reconcile", body: true},
+	{verb: http.MethodGet, path: "/v1/orders/{order_id}", rpc: "FindOne"},
+	{verb: http.MethodPost, path: "/v1/orders/{order_id}:changeStatus", rpc: "ChangeStatus", body: true},
+	{verb: http.MethodPost, path: "/v1/orders/{order_id}:cancel", rpc: "Cancel", body: true},
+	{verb: http.MethodPost, path: "/v1/orders/{order_id}:refund", rpc: "Refund", body: true},
+	{verb: http.MethodPost, path: "/v1/orders/{order_id}:retryPayment", rpc: "RetryPayment", body: true},
+	{verb: http.MethodPost, path: "/v1/coupons", rpc: "CreateCoupon", body: true},
+	{verb: http.MethodGet, path: "/v1/coupons/{code}", rpc: "FindCoupon"},
+	{verb: http.MethodGet, path: "/v1/customers/{customer_id}/entitlements", rpc: "ListEntitlements"},
+	{verb: http.MethodGet, path: "/v1/customers/{customer_id}/entitlements/{product_id}", rpc: "CheckEntitlement"},
+	{verb: http.MethodPost, path: "/v1/customers/{customer_id}/entitlements/{product_id}:setAutoRenew", rpc: "SetAutoRenew", body: true},
+	{verb: http.MethodPost, path: "/v1/customers/{customer_id}/plans:quote", rpc: "QuotePlanChange", body: true},
+	{verb: http.MethodPost, path: "/v1/customers/{customer_id}/plans:change", rpc: "ChangePlan", body: true},
+	{verb: http.MethodPost, path: "/v1/merchants/{merchant_id}/webhooks", rpc: "CreateWebhook", body: true},
+	{verb: http.MethodGet, path: "/v1/merchants/{merchant_id}/webhooks", rpc: "ListWebhooks"},
+	{verb: http.MethodPut, path: "/v1/merchants/{merchant_id}/webhooks/{subscription_id}", rpc: "UpdateWebhook", body: true},
+	{verb: http.MethodDelete, path: "/v1/merchants/{merchant_id}/webhooks/{subscription_id}", rpc: "DeleteWebhook"},
+	{verb: http.MethodGet, path: "/v1/merchants/{merchant_id}/webhookDeliveries", rpc: "ListWebhookDeliveries"},
+	{verb: http.MethodPost, path: "/v1/webhookDeliveries/{delivery_id}:replay", rpc: "ReplayWebhookDelivery", body: true},
+	{verb: http.MethodPost, path: "/v1/webhooks:dispatch", rpc: "DispatchWebhooks", body: true},
+	{verb: http.MethodGet, path: "/v1/orders/{order_id}/invoice", rpc: "GetInvoice"},
+	{verb: http.MethodGet, path: "/v1/orders/{order_id}/qr", rpc: "GetPaymentQR"},
+	{verb: http.MethodGet, path: "/v1/ledger/balances", rpc: "GetLedgerBalances"},
+	{verb: http.MethodGet, path: "/v1/ledger:check", rpc: "CheckLedger"},
+}
+
+type segment struct {
+	literal  string
+	variable string
+	suffix   string
+}
+
+type route struct {
+	binding
+	segments []segment
+	input    protoreflect.MessageType
+	output   protoreflect.MessageType
+	method   protoreflect.MethodDescriptor
+	handler  handlerFunc
+}
+
+func newRoute(b binding, method protoreflect.MethodDescriptor) *route {
+	r := &route{
+		binding: b,
+		input:   messageType(method.Input()),
+		output:  messageType(method.Output()),
+		method:  method,
+		handler: methodHandler(b.rpc),
+	}
+
+	for _, part := range strings.Split(strings.Trim(b.path, "/"), "/") {
+		if !strings.HasPrefix(part, "{") {
+			r.segments = append(r.segments, segment{literal: part})
+			continue
+		}
+
+		end := strings.Index(part, "}")
+		r.segments = append(r.segments, segment{variable: part[1:end], suffix: part[end+1:]})
+	}
+
+	return r
+}
+
+// match reports whether path matches the route and returns the bound path
+// variables.
+func (r *route) match(path string) (params map[string]string, ok bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != len(r.segments) {
+		return nil, false
+	}
+
+	params = map[string]string{}
+	for i, seg := range r.segments {
+		part := parts[i]
+		if seg.variable == "" {
+			if part != seg.literal {
+				return nil, false
+			}
+
+			continue
+		}
+
+		if !strings.HasSuffix(part, seg.suffix) {
+			return nil, false
+		}
+
+		value := strings.TrimSuffix(part, seg.suffix)
+		if value == "" || (seg.suffix == "" && strings.Contains(value, ":")) {
+			return nil, false
+		}
+
+		params[seg.variable] = value
+	}
+
+	return params, true
+}
+
+// variables returns the names of the path variables in template order.
+func (r *route) variables() (names []string) {
+	for _, seg := range r.segments {
+		if seg.variable != "" {
+			names = append(names, seg.variable)
+		}
+	}
+
+	return
+}
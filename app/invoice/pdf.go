@@ -0,0 +1,214 @@
+package invoice
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// A4 page size and margin, in points.
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	margin     = 50.0
+)
+
+// helveticaWidths and helveticaBoldWidths are the widths, in thousandths of
+// the font size, of the printable ASCII characters of the standard fonts,
+// from their Adobe font metrics. Other characters are measured as a digit.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// winAnsi maps the characters of WinAnsiEncoding outside Latin-1.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// encodeText encodes s in WinAnsiEncoding, replacing the characters it
+// cannot represent with "?".
+func encodeText(s string) []byte {
+	encoded := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			encoded = append(encoded, byte(r))
+		case winAnsi[r] != 0:
+			encoded = append(encoded, winAnsi[r])
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+
+	return encoded
+}
+
+// textWidth returns the width of s in points.
+func textWidth(s string, size float64, bold bool) float64 {
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+
+	total := 0
+	for _, c := range encodeText(s) {
+		if c >= 0x20 && c < 0x7f {
+			total += widths[c-0x20]
+		} else {
+			total += 556
+		}
+	}
+
+	return float64(total) * size / 1000
+}
+
+// fitText shortens s with an ellipsis until it is at most width wide.
+func fitText(s string, width float64, size float64, bold bool) string {
+	if textWidth(s, size, bold) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 && textWidth(string(runes)+"...", size, bold) > width {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + "..."
+}
+
+// number formats f for a content stream, to the hundredth of a point.
+func number(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+// pdfDocument builds a PDF of A4 pages written with the standard Helvetica
+// fonts, which PDF readers provide, so that no font is embedded. Positions
+// are in points from the bottom left corner of the page.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+}
+
+func newPDFDocument() *pdfDocument {
+	d := &pdfDocument{}
+	d.addPage()
+
+	return d
+}
+
+func (d *pdfDocument) addPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+}
+
+// color sets the color of the text and shapes drawn next, in RGB from 0 to
+// 1.
+func (d *pdfDocument) color(r, g, b float64) {
+	fmt.Fprintf(d.page, "%s %s %s rg %[1]s %[2]s %[3]s RG\n", number(r), number(g), number(b))
+}
+
+func (d *pdfDocument) text(x, y float64, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(d.page, "BT /%s %s Tf %s %s Td (", font, number(size), number(x), number(y))
+	for _, c := range encodeText(s) {
+		if c == '(' || c == ')' || c == '\\' {
+			d.page.WriteByte('\\')
+		}
+		d.page.WriteByte(c)
+	}
+	d.page.WriteString(") Tj ET\n")
+}
+
+// textRight draws s ending at right.
+func (d *pdfDocument) textRight(right, y float64, size float64, bold bool, s string) {
+	d.text(right-textWidth(s, size, bold), y, size, bold, s)
+}
+
+func (d *pdfDocument) line(x1, y1, x2, y2 float64, width float64) {
+	fmt.Fprintf(d.page, "%s w %s %s m %s %s l S\n", number(width), number(x1), number(y1), number(x2), number(y2))
+}
+
+func (d *pdfDocument) fill(x, y, w, h float64) {
+	fmt.Fprintf(d.page, "%s %s %s %s re f\n", number(x), number(y), number(w), number(h))
+}
+
+// bytes returns the document, its page contents compressed. The output only
+// depends on what was drawn, title and created.
+func (d *pdfDocument) bytes(title string, created time.Time) ([]byte, error) {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	const firstPage = 6
+	kids := ""
+	for i := range d.pages {
+		kids += fmt.Sprintf("%d 0 R ", firstPage+2*i)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids, len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	var info bytes.Buffer
+	info.WriteString("<< /Title (")
+	for _, c := range encodeText(title) {
+		if c == '(' || c == ')' || c == '\\' {
+			info.WriteByte('\\')
+		}
+		info.WriteByte(c)
+	}
+	fmt.Fprintf(&info, ") /Producer (order) /CreationDate (D:%s) >>", created.UTC().Format("20060102150405Z"))
+	object(info.String())
+
+	for i, page := range d.pages {
+		var content bytes.Buffer
+		zw := zlib.NewWriter(&content)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			number(pageWidth), number(pageHeight), firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes(), nil
+}
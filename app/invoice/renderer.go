@@ -0,0 +1,307 @@
+// Package invoice renders the invoices of settled orders as HTML and PDF, in
+// pure Go.
+package invoice
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"order/domain"
+	"order/pb"
+	"order/variable"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed invoice.html
+var invoiceHTML string
+
+var htmlTemplate = template.Must(template.New("invoice.html").Parse(invoiceHTML))
+
+// Renderer renders invoices branded with the seller, showing times in its
+// location.
+type Renderer struct {
+	seller   domain.InvoiceSeller
+	location *time.Location
+}
+
+// NewRenderer returns a Renderer of the invoices of seller, dated in
+// location, UTC when nil.
+func NewRenderer(seller domain.InvoiceSeller, location *time.Location) *Renderer {
+	if location == nil {
+		location = time.UTC
+	}
+
+	return &Renderer{seller: seller, location: location}
+}
+
+// invoiceRow is a line of the invoice summary, e.g. a tax or the total.
+type invoiceRow struct {
+	Label  string
+	Amount string
+	Total  bool
+}
+
+type invoiceLine struct {
+	Description string
+	Quantity    string
+	UnitPrice   string
+	Amount      string
+}
+
+// invoiceView is what both documents show, formatted.
+type invoiceView struct {
+	Number  string
+	Issued  string
+	Settled string
+	OrderId string
+	Seller  domain.InvoiceSeller
+	// SellerLines are the address lines of the seller, then its tax ID and
+	// email.
+	SellerLines []string
+	Buyer       *pb.OrderBuyer
+	Lines       []invoiceLine
+	Summary     []invoiceRow
+	Payment     string
+}
+
+// maskAccount hides all but the last four characters of account.
+func maskAccount(account string) string {
+	if len(account) <= 4 {
+		return account
+	}
+
+	return strings.Repeat("*", len(account)-4) + account[len(account)-4:]
+}
+
+func formatRate(rate int64) string {
+	return strconv.FormatFloat(float64(rate)/100, 'f', -1, 64) + "%"
+}
+
+// paymentMethod describes how the order was paid, e.g. "Bank transfer, BCA
+// virtual account ********1234".
+func paymentMethod(payment *pb.OrderPayment) string {
+	method := strings.ReplaceAll(payment.GetPaymentType(), "_", " ")
+	if method == variable.PaymentTypeQRIS {
+		method = "QRIS"
+	} else if method != "" {
+		method = strings.ToUpper(method[:1]) + method[1:]
+	}
+
+	var details []string
+	if bank := payment.GetBank(); bank != "" {
+		details = append(details, strings.ToUpper(bank))
+	}
+	if va := payment.GetVaNumber(); va != "" {
+		details = append(details, "virtual account "+maskAccount(va))
+	}
+
+	switch {
+	case method == "":
+		return strings.Join(details, " ")
+	case len(details) == 0:
+		return method
+	}
+
+	return method + ", " + strings.Join(details, " ")
+}
+
+func (r *Renderer) view(data domain.InvoiceData) invoiceView {
+	order := data.Order
+	money := func(amount int64) string {
+		return domain.NewMoney(order.GetCurrency(), amount).String()
+	}
+	date := func(unix int64) string {
+		return time.Unix(unix, 0).In(r.location).Format("2 January 2006")
+	}
+
+	view := invoiceView{
+		Number:  data.Number,
+		Issued:  date(data.IssuedAt),
+		Settled: date(order.GetSettlementTime()),
+		OrderId: order.GetOrderId(),
+		Seller:  r.seller,
+		Buyer:   order.GetBuyer(),
+		Payment: paymentMethod(order.GetPayment()),
+	}
+
+	for _, line := range strings.Split(r.seller.Address, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			view.SellerLines = append(view.SellerLines, line)
+		}
+	}
+	if r.seller.TaxId != "" {
+		view.SellerLines = append(view.SellerLines, "Tax ID: "+r.seller.TaxId)
+	}
+	if r.seller.Email != "" {
+		view.SellerLines = append(view.SellerLines, r.seller.Email)
+	}
+
+	items := order.GetItems()
+	if len(items) == 0 && order.GetProduct() != nil {
+		items = []*pb.OrderItem{{Product: order.GetProduct(), Quantity: 1, UnitPrice: order.GetProduct().GetPrice(), LineTotal: order.GetProduct().GetPrice()}}
+	}
+	for _, item := range items {
+		view.Lines = append(view.Lines, invoiceLine{
+			Description: item.GetProduct().GetName(),
+			Quantity:    strconv.FormatInt(item.GetQuantity(), 10),
+			UnitPrice:   money(item.GetUnitPrice()),
+			Amount:      money(item.GetLineTotal()),
+		})
+	}
+
+	view.Summary = append(view.Summary, invoiceRow{Label: "Subtotal", Amount: money(order.GetSubtotal())})
+	for _, discount := range order.GetDiscounts() {
+		label := "Discount " + discount.GetCode()
+		if discount.GetDescription() != "" {
+			label += " (" + discount.GetDescription() + ")"
+		}
+		view.Summary = append(view.Summary, invoiceRow{Label: label, Amount: money(-discount.GetAmount())})
+	}
+	for _, tax := range order.GetTaxes() {
+		label := tax.GetName() + " " + formatRate(tax.GetRate())
+		if order.GetTaxInclusive() {
+			label += ", included"
+		}
+		view.Summary = append(view.Summary, invoiceRow{Label: label, Amount: money(tax.GetAmount())})
+	}
+	view.Summary = append(view.Summary, invoiceRow{Label: "Total paid", Amount: money(order.GetTotal()), Total: true})
+	if surplus := order.GetOverpayment(); surplus.GetAmount() > 0 {
+		label := "Overpayment, credited to the customer"
+		if surplus.GetDisposition() == variable.OverpaymentRefundDue {
+			label = "Overpayment, to be refunded"
+		}
+		view.Summary = append(view.Summary, invoiceRow{Label: label, Amount: money(surplus.GetAmount())})
+	}
+
+	return view
+}
+
+func (r *Renderer) Render(data domain.InvoiceData) (html []byte, pdf []byte, err error) {
+	view := r.view(data)
+
+	var buf bytes.Buffer
+	if err = htmlTemplate.Execute(&buf, view); err != nil {
+		return
+	}
+
+	pdf, err = renderPDF(view, time.Unix(data.IssuedAt, 0))
+	if err != nil {
+		return
+	}
+
+	return buf.Bytes(), pdf, nil
+}
+
+// brand is the accent color of invoices, in RGB from 0 to 1.
+var brand = [3]float64{0.13, 0.33, 0.62}
+
+// Columns of the line items table, as the right edge of each numeric
+// column.
+const (
+	quantityRight = 350.0
+	unitRight     = 450.0
+	amountRight   = pageWidth - margin
+)
+
+func renderPDF(view invoiceView, issued time.Time) ([]byte, error) {
+	d := newPDFDocument()
+	y := pageHeight - margin - 20
+
+	d.color(brand[0], brand[1], brand[2])
+	d.text(margin, y, 20, true, fitText(view.Seller.Name, 300, 20, true))
+	d.textRight(amountRight, y, 20, true, "INVOICE")
+	d.color(0, 0, 0)
+
+	left := y - 20
+	for _, line := range view.SellerLines {
+		d.text(margin, left, 9, false, fitText(line, 280, 9, false))
+		left -= 12
+	}
+
+	right := y - 20
+	for _, field := range [][2]string{
+		{"Invoice number", view.Number},
+		{"Issued", view.Issued},
+		{"Order", view.OrderId},
+		{"Paid", view.Settled},
+	} {
+		d.text(360, right, 9, true, field[0])
+		d.textRight(amountRight, right, 9, false, field[1])
+		right -= 12
+	}
+
+	y = left
+	if right < y {
+		y = right
+	}
+	y -= 20
+
+	d.text(margin, y, 10, true, "Billed to")
+	y -= 14
+	for _, line := range []string{view.Buyer.GetName(), view.Buyer.GetEmail(), "Customer " + view.Buyer.GetCustomerId()} {
+		if strings.TrimSpace(line) == "" || line == "Customer " {
+			continue
+		}
+		d.text(margin, y, 9, false, fitText(line, 300, 9, false))
+		y -= 12
+	}
+	y -= 16
+
+	header := func() {
+		d.color(brand[0], brand[1], brand[2])
+		d.fill(margin, y-6, amountRight-margin, 20)
+		d.color(1, 1, 1)
+		d.text(margin+6, y, 9, true, "Description")
+		d.textRight(quantityRight, y, 9, true, "Qty")
+		d.textRight(unitRight, y, 9, true, "Unit price")
+		d.textRight(amountRight-6, y, 9, true, "Amount")
+		d.color(0, 0, 0)
+		y -= 22
+	}
+	header()
+
+	for _, line := range view.Lines {
+		if y < margin+60 {
+			d.addPage()
+			y = pageHeight - margin
+			header()
+		}
+
+		d.text(margin+6, y, 9, false, fitText(line.Description, quantityRight-margin-40, 9, false))
+		d.textRight(quantityRight, y, 9, false, line.Quantity)
+		d.textRight(unitRight, y, 9, false, line.UnitPrice)
+		d.textRight(amountRight-6, y, 9, false, line.Amount)
+		y -= 16
+	}
+
+	d.color(0.6, 0.6, 0.6)
+	d.line(margin, y+8, amountRight, y+8, 0.5)
+	d.color(0, 0, 0)
+	y -= 8
+
+	for _, row := range view.Summary {
+		if y < margin+40 {
+			d.addPage()
+			y = pageHeight - margin
+		}
+
+		d.textRight(unitRight, y, 9, row.Total, row.Label)
+		d.textRight(amountRight-6, y, 9, row.Total, row.Amount)
+		y -= 14
+	}
+
+	if y < margin+40 {
+		d.addPage()
+		y = pageHeight - margin
+	}
+	y -= 16
+	d.text(margin, y, 9, true, "Payment")
+	d.text(margin+60, y, 9, false, fitText(view.Payment+", received "+view.Settled, amountRight-margin-60, 9, false))
+
+	d.color(0.4, 0.4, 0.4)
+	d.text(margin, margin, 8, false, "Thank you for your purchase. This invoice was issued electronically and is valid without a signature.")
+
+	return d.bytes("Invoice "+view.Number, issued)
+}
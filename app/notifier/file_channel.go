@@ -0,0 +1,55 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"order/domain"
+	"os"
+	"sync"
+)
+
+// FileChannel writes notifications to a file, one JSON object per line,
+// for local development.
+type FileChannel struct {
+	mu sync.Mutex
+	w  io.Writer
+	// close closes the file, nil for stdout.
+	close func() error
+}
+
+func NewFileChannel(path string) (*FileChannel, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileChannel{w: file, close: file.Close}, nil
+}
+
+// NewStdoutChannel returns a FileChannel writing to the standard output.
+func NewStdoutChannel() *FileChannel {
+	return &FileChannel{w: os.Stdout}
+}
+
+func (f *FileChannel) Send(ctx context.Context, message domain.NotificationMessage) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err = f.w.Write(append(line, '\n'))
+
+	return err
+}
+
+func (f *FileChannel) Close() error {
+	if f.close == nil {
+		return nil
+	}
+
+	return f.close()
+}
@@ -0,0 +1,57 @@
+// Package notifier holds the domain.NotificationChannel adapters buyers are
+// notified through, and the templates notifications are rendered with.
+package notifier
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/url"
+	"order/domain"
+	"strings"
+)
+
+// Open returns the notification channel of rawURL, one of
+//
+//	smtp://[user:password@]host:587?from=orders@example.com
+//	sms+https://[token@]host/path (or sms+http), see HTTPSMSProvider
+//	file:///path/to/notifications.jsonl
+//	stdout
+func Open(rawURL string) (domain.NotificationChannel, error) {
+	if rawURL == "stdout" {
+		return NewStdoutChannel(), nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("notification url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "smtp":
+		from := u.Query().Get("from")
+		if from == "" {
+			return nil, fmt.Errorf("notification url %s: the from parameter is required", u.Redacted())
+		}
+
+		addr := u.Host
+		if u.Port() == "" {
+			addr = net.JoinHostPort(u.Hostname(), "587")
+		}
+
+		var auth smtp.Auth
+		if password, ok := u.User.Password(); ok {
+			auth = smtp.PlainAuth("", u.User.Username(), password, u.Hostname())
+		}
+
+		return NewSMTPChannel(addr, auth, from), nil
+	case "sms+http", "sms+https":
+		endpoint := url.URL{Scheme: strings.TrimPrefix(u.Scheme, "sms+"), Host: u.Host, Path: u.Path, RawQuery: u.RawQuery}
+
+		return NewSMSChannel(NewHTTPSMSProvider(endpoint.String(), u.User.Username(), nil)), nil
+	case "file":
+		return NewFileChannel(u.Path)
+	}
+
+	return nil, fmt.Errorf("notification url %s: unsupported scheme %q", u.Redacted(), u.Scheme)
+}
@@ -0,0 +1,86 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"order/domain"
+	"time"
+)
+
+// SMSChannel texts the Text of notifications through an SMS provider.
+type SMSChannel struct {
+	provider domain.SMSProvider
+}
+
+func NewSMSChannel(provider domain.SMSProvider) *SMSChannel {
+	return &SMSChannel{provider: provider}
+}
+
+func (s *SMSChannel) Send(ctx context.Context, message domain.NotificationMessage) error {
+	return s.provider.SendSMS(ctx, message.To, message.Text)
+}
+
+func (s *SMSChannel) Close() error {
+	return nil
+}
+
+// HTTPSMSProvider posts each text message as a JSON object with "to" and
+// "text" fields to an HTTP endpoint, the shape of most SMS gateway APIs and
+// of the small adapters written for the others. Any 2xx response means the
+// message was accepted.
+type HTTPSMSProvider struct {
+	endpoint string
+	token    string
+	client   *http.Client
+}
+
+// NewHTTPSMSProvider posts to endpoint, with token as a bearer token unless
+// it is empty. A nil client uses one timing out after 10 seconds.
+func NewHTTPSMSProvider(endpoint string, token string, client *http.Client) *HTTPSMSProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &HTTPSMSProvider{
+		endpoint: endpoint,
+		token:    token,
+		client:   client,
+	}
+}
+
+func (h *HTTPSMSProvider) SendSMS(ctx context.Context, to string, text string) error {
+	body, err := json.Marshal(map[string]string{"to": to, "text": text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("sms provider: %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+
+	return nil
+}
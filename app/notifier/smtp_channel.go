@@ -0,0 +1,117 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"order/domain"
+	"time"
+)
+
+// SMTPChannel emails notifications through an SMTP server, upgrading the
+// connection with STARTTLS when the server offers it.
+type SMTPChannel struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPChannel sends from the address from through the server at addr,
+// authenticating with auth unless it is nil.
+func NewSMTPChannel(addr string, auth smtp.Auth, from string) *SMTPChannel {
+	return &SMTPChannel{
+		addr: addr,
+		auth: auth,
+		from: from,
+	}
+}
+
+// Send ignores ctx, as net/smtp does not take one.
+func (s *SMTPChannel) Send(ctx context.Context, message domain.NotificationMessage) error {
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return fmt.Errorf("smtp from: %w", err)
+	}
+
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("smtp to: %w", err)
+	}
+
+	msg, err := s.build(from, to, message)
+	if err != nil {
+		return err
+	}
+
+	return smtp.SendMail(s.addr, s.auth, from.Address, []string{to.Address}, msg)
+}
+
+func (s *SMTPChannel) Close() error {
+	return nil
+}
+
+// build returns the MIME message of message, a multipart/alternative one
+// when it has an HTML version.
+func (s *SMTPChannel) build(from *mail.Address, to *mail.Address, message domain.NotificationMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+
+	if message.HTML == "" {
+		fmt.Fprintf(&buf, "Content-Type: text/plain; charset=utf-8\r\n")
+		fmt.Fprintf(&buf, "Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		if err := writeQuotedPrintable(&buf, message.Text); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=utf-8", message.Text},
+		{"text/html; charset=utf-8", message.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+
+	return qp.Close()
+}
@@ -0,0 +1,196 @@
+package notifier
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"order/domain"
+	"order/variable"
+	"os"
+	"path"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// defaultTemplates are the templates used when no template directory is
+// configured, in English and Indonesian.
+//
+//go:embed templates
+var defaultTemplates embed.FS
+
+// Template files of each locale directory. email.txt defines the subject
+// "<name>.subject" and plain text "<name>" of each email, email.html its
+// HTML version "<name>", and sms.txt the text "<name>" of each SMS. A
+// notification without a template for a channel is not sent through it.
+const (
+	emailTextFile = "email.txt"
+	emailHTMLFile = "email.html"
+	smsFile       = "sms.txt"
+)
+
+type localeTemplates struct {
+	emailText *texttemplate.Template
+	emailHTML *htmltemplate.Template
+	sms       *texttemplate.Template
+}
+
+// Templates renders notifications from a directory holding one directory of
+// templates per locale, named after the locale, e.g. "en" or "id".
+type Templates struct {
+	locales       map[string]*localeTemplates
+	defaultLocale string
+}
+
+// LoadTemplates loads the templates of dir, or the built-in ones when dir is
+// empty. Notifications in locales without templates are written in
+// defaultLocale, and their times shown in location.
+func LoadTemplates(dir string, defaultLocale string, location *time.Location) (*Templates, error) {
+	var fsys fs.FS = os.DirFS(dir)
+	if dir == "" {
+		fsys, _ = fs.Sub(defaultTemplates, "templates")
+	}
+
+	funcs := map[string]interface{}{
+		"money": func(currency string, amount int64) string {
+			return domain.NewMoney(currency, amount).String()
+		},
+		"date": func(unix int64) string {
+			return time.Unix(unix, 0).In(location).Format("02/01/2006 15:04 MST")
+		},
+		"upper": strings.ToUpper,
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("notification templates: %w", err)
+	}
+
+	t := &Templates{locales: map[string]*localeTemplates{}, defaultLocale: normalizeLocale(defaultLocale)}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		locale := &localeTemplates{}
+		name := entry.Name()
+		if locale.emailText, err = parseText(fsys, path.Join(name, emailTextFile), funcs); err != nil {
+			return nil, err
+		}
+		if locale.sms, err = parseText(fsys, path.Join(name, smsFile), funcs); err != nil {
+			return nil, err
+		}
+
+		html, err := fs.ReadFile(fsys, path.Join(name, emailHTMLFile))
+		if err == nil {
+			locale.emailHTML, err = htmltemplate.New(emailHTMLFile).Funcs(funcs).Parse(string(html))
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("notification templates: %s: %w", name, err)
+		}
+
+		t.locales[normalizeLocale(name)] = locale
+	}
+
+	if _, ok := t.locales[t.defaultLocale]; !ok {
+		return nil, fmt.Errorf("notification templates: no templates for the default locale %q", defaultLocale)
+	}
+
+	return t, nil
+}
+
+// parseText parses the text template file, nil when there is none.
+func parseText(fsys fs.FS, file string, funcs map[string]interface{}) (*texttemplate.Template, error) {
+	text, err := fs.ReadFile(fsys, file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("notification templates: %w", err)
+	}
+
+	parsed, err := texttemplate.New(path.Base(file)).Funcs(funcs).Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("notification templates: %s: %w", file, err)
+	}
+
+	return parsed, nil
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// candidates returns the locales tried for locale, from the most specific
+// one to the default: "id-ID" tries "id-id", then "id", then the default.
+func (t *Templates) candidates(locale string) []string {
+	locale = normalizeLocale(locale)
+
+	var candidates []string
+	if locale != "" {
+		candidates = append(candidates, locale)
+		if language, _, found := strings.Cut(locale, "-"); found {
+			candidates = append(candidates, language)
+		}
+	}
+
+	return append(candidates, t.defaultLocale)
+}
+
+func (t *Templates) Render(template string, channel string, locale string, data domain.NotificationData) (message domain.NotificationMessage, found bool, err error) {
+	message.Channel = channel
+
+	for _, candidate := range t.candidates(locale) {
+		templates, ok := t.locales[candidate]
+		if !ok {
+			continue
+		}
+
+		data.Locale = candidate
+		switch channel {
+		case variable.NotificationChannelEmail:
+			if templates.emailText == nil || templates.emailText.Lookup(template) == nil {
+				continue
+			}
+
+			if message.Subject, err = executeText(templates.emailText, template+".subject", data); err != nil {
+				return
+			}
+			if message.Text, err = executeText(templates.emailText, template, data); err != nil {
+				return
+			}
+
+			if templates.emailHTML != nil && templates.emailHTML.Lookup(template) != nil {
+				var buf bytes.Buffer
+				if err = templates.emailHTML.ExecuteTemplate(&buf, template, data); err != nil {
+					return
+				}
+				message.HTML = buf.String()
+			}
+
+			return message, true, nil
+		case variable.NotificationChannelSMS:
+			if templates.sms == nil || templates.sms.Lookup(template) == nil {
+				continue
+			}
+
+			message.Text, err = executeText(templates.sms, template, data)
+
+			return message, err == nil, err
+		}
+	}
+
+	return
+}
+
+func executeText(templates *texttemplate.Template, name string, data domain.NotificationData) (string, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
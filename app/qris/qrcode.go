@@ -0,0 +1,513 @@
+package qris
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// The QR code encoder below supports what QR payment payloads need: byte
+// mode data at error correction level M, in the smallest of the 40 versions
+// it fits in, masked with the pattern of lowest penalty (ISO/IEC 18004).
+
+// errTooLong is returned for data beyond the capacity of version 40.
+var errTooLong = errors.New("data too long for a QR code")
+
+// eccCodewordsPerBlock and eccBlocks are the error correction codewords of
+// each block and the number of blocks of every version at level M, indexed
+// by version.
+var eccCodewordsPerBlock = [41]int{
+	0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26,
+	26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28,
+}
+
+var eccBlocks = [41]int{
+	0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14,
+	16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49,
+}
+
+// eccLevelM is the format information value of error correction level M.
+const eccLevelM = 0
+
+// rawCodewords returns the number of codewords of version, data and error
+// correction together.
+func rawCodewords(version int) int {
+	modules := (16*version+128)*version + 64
+	if version >= 2 {
+		alignments := version/7 + 2
+		modules -= (25*alignments-10)*alignments - 55
+		if version >= 7 {
+			modules -= 36
+		}
+	}
+
+	return modules / 8
+}
+
+func dataCodewords(version int) int {
+	return rawCodewords(version) - eccCodewordsPerBlock[version]*eccBlocks[version]
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x, y byte) byte {
+	var z byte
+	for i := 7; i >= 0; i-- {
+		carry := z >> 7
+		z = z<<1 ^ carry*0x1d
+		z ^= (y >> uint(i) & 1) * x
+	}
+
+	return z
+}
+
+// rsDivisor returns the coefficients of the Reed-Solomon generator
+// polynomial of degree, highest first, the leading 1 omitted.
+func rsDivisor(degree int) []byte {
+	divisor := make([]byte, degree)
+	divisor[degree-1] = 1
+
+	var root byte = 1
+	for i := 0; i < degree; i++ {
+		for j := range divisor {
+			divisor[j] = gfMultiply(divisor[j], root)
+			if j+1 < degree {
+				divisor[j] ^= divisor[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+
+	return divisor
+}
+
+// rsRemainder returns the error correction codewords of data.
+func rsRemainder(data, divisor []byte) []byte {
+	remainder := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ remainder[0]
+		copy(remainder, remainder[1:])
+		remainder[len(remainder)-1] = 0
+		for i, coefficient := range divisor {
+			remainder[i] ^= gfMultiply(coefficient, factor)
+		}
+	}
+
+	return remainder
+}
+
+// qrCode is a QR code symbol being drawn, true modules being dark.
+type qrCode struct {
+	version  int
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+// encodeQR returns the QR code of data.
+func encodeQR(data []byte) (*qrCode, error) {
+	version := 1
+	for ; ; version++ {
+		if version > 40 {
+			return nil, errTooLong
+		}
+
+		countBits := 8
+		if version >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= 8*dataCodewords(version) {
+			break
+		}
+	}
+
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+
+	var bits bitBuffer
+	bits.append(0x4, 4) // byte mode
+	bits.append(len(data), countBits)
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	capacity := 8 * dataCodewords(version)
+	terminator := capacity - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xec; len(bits) < capacity; pad ^= 0xec ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	codewords := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			codewords[i>>3] |= 1 << uint(7-i&7)
+		}
+	}
+
+	q := newQRCode(version)
+	q.drawFunctionPatterns()
+	q.drawCodewords(q.addECC(codewords))
+
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if penalty := q.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		q.applyMask(mask)
+	}
+	q.applyMask(best)
+	q.drawFormatBits(best)
+
+	return q, nil
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value int, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, value>>uint(i)&1 == 1)
+	}
+}
+
+func newQRCode(version int) *qrCode {
+	size := version*4 + 17
+	q := &qrCode{version: version, size: size}
+	q.modules = make([][]bool, size)
+	q.function = make([][]bool, size)
+	for y := range q.modules {
+		q.modules[y] = make([]bool, size)
+		q.function[y] = make([]bool, size)
+	}
+
+	return q
+}
+
+func (q *qrCode) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+// alignmentPositions returns the centers of the alignment patterns on
+// either axis.
+func (q *qrCode) alignmentPositions() []int {
+	if q.version == 1 {
+		return nil
+	}
+
+	count := q.version/7 + 2
+	step := (q.version*4 + count*2 + 1) / (count*2 - 2) * 2
+	if q.version == 32 {
+		step = 26
+	}
+
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, pos := count-1, q.size-7; i >= 1; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+
+	return positions
+}
+
+func (q *qrCode) drawFunctionPatterns() {
+	for i := 0; i < q.size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	for _, corner := range [][2]int{{3, 3}, {q.size - 4, 3}, {3, q.size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := corner[0]+dx, corner[1]+dy
+				if x < 0 || x >= q.size || y < 0 || y >= q.size {
+					continue
+				}
+				distance := max(abs(dx), abs(dy))
+				q.setFunction(x, y, distance != 2 && distance != 4)
+			}
+		}
+	}
+
+	positions := q.alignmentPositions()
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// the corners of the finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// reserve the format bits, drawn once the mask is chosen
+	q.drawFormatBits(0)
+
+	if q.version >= 7 {
+		rem := q.version
+		for i := 0; i < 12; i++ {
+			rem = rem<<1 ^ (rem>>11)*0x1f25
+		}
+		bits := q.version<<12 | rem
+		for i := 0; i < 18; i++ {
+			dark := bits>>uint(i)&1 == 1
+			a, b := q.size-11+i%3, i/3
+			q.setFunction(a, b, dark)
+			q.setFunction(b, a, dark)
+		}
+	}
+}
+
+func (q *qrCode) drawFormatBits(mask int) {
+	data := eccLevelM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>uint(i)&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(i))
+	}
+	q.setFunction(8, q.size-8, true)
+}
+
+// addECC splits data into blocks, appends their error correction codewords
+// and interleaves the blocks.
+func (q *qrCode) addECC(data []byte) []byte {
+	blocks := eccBlocks[q.version]
+	eccLen := eccCodewordsPerBlock[q.version]
+	raw := rawCodewords(q.version)
+	shortBlocks := blocks - raw%blocks
+	shortLen := raw / blocks
+
+	divisor := rsDivisor(eccLen)
+	var all [][]byte
+	for i, k := 0, 0; i < blocks; i++ {
+		length := shortLen - eccLen
+		if i >= shortBlocks {
+			length++
+		}
+		block := append([]byte(nil), data[k:k+length]...)
+		k += length
+
+		ecc := rsRemainder(block, divisor)
+		if i < shortBlocks {
+			// short blocks skip the last data column of the long ones
+			block = append(block, 0)
+		}
+		all = append(all, append(block, ecc...))
+	}
+
+	result := make([]byte, 0, raw)
+	for i := 0; i < len(all[0]); i++ {
+		for j, block := range all {
+			if i != shortLen-eccLen || j >= shortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+
+	return result
+}
+
+// drawCodewords places the codewords in the zigzag of two module wide
+// columns, from the bottom right corner.
+func (q *qrCode) drawCodewords(codewords []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert
+				}
+				if !q.function[y][x] && i < len(codewords)*8 {
+					q.modules[y][x] = codewords[i>>3]>>uint(7-i&7)&1 == 1
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask flips the data modules selected by mask, undoing a previous
+// call with the same mask.
+func (q *qrCode) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			var flip bool
+			switch mask {
+			case 0:
+				flip = (x+y)%2 == 0
+			case 1:
+				flip = y%2 == 0
+			case 2:
+				flip = x%3 == 0
+			case 3:
+				flip = (x+y)%3 == 0
+			case 4:
+				flip = (x/3+y/2)%2 == 0
+			case 5:
+				flip = x*y%2+x*y%3 == 0
+			case 6:
+				flip = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				flip = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if flip && !q.function[y][x] {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// finderLike is the pattern of a finder pattern followed by four light
+// modules, penalized in either direction.
+var finderLike = []bool{true, false, true, true, true, false, true, false, false, false, false}
+
+// penalty scores how hard the symbol is to read, by the four rules of the
+// standard.
+func (q *qrCode) penalty() int {
+	penalty := 0
+	at := func(x, y int, transpose bool) bool {
+		if transpose {
+			x, y = y, x
+		}
+		if x < 0 || x >= q.size || y < 0 || y >= q.size {
+			return false
+		}
+		return q.modules[y][x]
+	}
+
+	for _, transpose := range []bool{false, true} {
+		for y := 0; y < q.size; y++ {
+			run := 0
+			for x := 0; x < q.size; x++ {
+				if x > 0 && at(x, y, transpose) == at(x-1, y, transpose) {
+					run++
+				} else {
+					if run >= 5 {
+						penalty += run - 2
+					}
+					run = 1
+				}
+			}
+			if run >= 5 {
+				penalty += run - 2
+			}
+
+			for x := -len(finderLike); x < q.size; x++ {
+				forward, backward := true, true
+				for i, dark := range finderLike {
+					forward = forward && at(x+i, y, transpose) == dark
+					backward = backward && at(x+len(finderLike)-1-i, y, transpose) == dark
+				}
+				if forward {
+					penalty += 40
+				}
+				if backward {
+					penalty += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x+1 < q.size && y+1 < q.size {
+				c := q.modules[y][x]
+				if c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+					penalty += 3
+				}
+			}
+		}
+	}
+
+	// every 5% the dark modules stray from half of the symbol
+	total := q.size * q.size
+	penalty += ((abs(dark*20-total*10)+total-1)/total - 1) * 10
+
+	return penalty
+}
+
+// png renders the symbol with its quiet zone of four modules, each module
+// scale pixels wide.
+func (q *qrCode) png(scale int) ([]byte, error) {
+	const quiet = 4
+	width := (q.size + 2*quiet) * scale
+
+	img := image.NewGray(image.Rect(0, 0, width, width))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if !q.modules[y][x] {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetGray((x+quiet)*scale+dx, (y+quiet)*scale+dy, color.Gray{})
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,306 @@
+// Package qris builds QRIS payloads, the EMVCo merchant-presented QR codes
+// Indonesian wallets and banks pay, parses them back, and renders them as
+// PNG images, in pure Go.
+package qris
+
+import (
+	"errors"
+	"fmt"
+	"order/domain"
+	"order/variable"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// IDs of the data objects of a payload.
+const (
+	idFormat          = "00"
+	idInitiation      = "01"
+	idAcquirerAccount = "26"
+	idQRISAccount     = "51"
+	idMCC             = "52"
+	idCurrency        = "53"
+	idAmount          = "54"
+	idCountry         = "58"
+	idName            = "59"
+	idCity            = "60"
+	idPostalCode      = "61"
+	idAdditionalData  = "62"
+	idCRC             = "63"
+)
+
+// IDs of the data objects of the merchant account templates, and of the
+// additional data template.
+const (
+	idGloballyUnique = "00"
+	idMerchantPAN    = "01"
+	idMerchantId     = "02"
+	idCriteria       = "03"
+	idReferenceLabel = "05"
+)
+
+// qrisDomain identifies the QRIS merchant account template.
+const qrisDomain = "ID.CO.QRIS.WWW"
+
+// Points of initiation: a static payload is paid any number of times, a
+// dynamic one once.
+const (
+	InitiationStatic  = "11"
+	InitiationDynamic = "12"
+)
+
+// currencyCodes are the ISO 4217 numeric codes of the supported currencies.
+var currencyCodes = map[string]string{
+	"IDR": "360",
+	"SGD": "702",
+	"MYR": "458",
+	"USD": "840",
+	"EUR": "978",
+	"JPY": "392",
+}
+
+var criteria = map[string]bool{"UMI": true, "UKE": true, "UME": true, "UBE": true, "URE": true}
+
+var (
+	digits = regexp.MustCompile(`^[0-9]+$`)
+	amount = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+)
+
+// Generator issues the payloads of a merchant.
+type Generator struct {
+	merchant domain.QRISMerchant
+}
+
+// NewGenerator checks merchant against the limits of the payload fields and
+// returns its generator.
+func NewGenerator(merchant domain.QRISMerchant) (domain.PaymentQR, error) {
+	if merchant.Country == "" {
+		merchant.Country = "ID"
+	}
+
+	switch {
+	case merchant.NMID == "" && merchant.MerchantPAN == "":
+		return nil, errors.New("qris: the merchant needs an NMID or a merchant PAN")
+	case merchant.MerchantPAN != "" && merchant.AcquirerDomain == "":
+		return nil, errors.New("qris: a merchant PAN needs the domain of its acquirer")
+	case merchant.Criteria != "" && !criteria[merchant.Criteria]:
+		return nil, fmt.Errorf("qris: unknown merchant criteria %q", merchant.Criteria)
+	case len(merchant.MCC) != 4 || !digits.MatchString(merchant.MCC):
+		return nil, fmt.Errorf("qris: merchant category code %q must be 4 digits", merchant.MCC)
+	case merchant.Name == "" || len(merchant.Name) > 25:
+		return nil, errors.New("qris: the merchant name must be 1 to 25 characters")
+	case merchant.City == "" || len(merchant.City) > 15:
+		return nil, errors.New("qris: the merchant city must be 1 to 15 characters")
+	case len(merchant.PostalCode) > 10:
+		return nil, errors.New("qris: the postal code is at most 10 characters")
+	case len(merchant.Country) != 2:
+		return nil, fmt.Errorf("qris: country %q must be an ISO 3166-1 alpha-2 code", merchant.Country)
+	}
+
+	return &Generator{merchant: merchant}, nil
+}
+
+// formatAmount formats amount, in the minor units of currency, in major
+// units, e.g. "150000" rupiah or "12.50" dollars.
+func formatAmount(amount int64, currency string) string {
+	exp := variable.CurrencyExponents[currency]
+	if exp == 0 {
+		return strconv.FormatInt(amount, 10)
+	}
+
+	unit := int64(1)
+	for i := 0; i < exp; i++ {
+		unit *= 10
+	}
+
+	return fmt.Sprintf("%d.%0*d", amount/unit, exp, amount%unit)
+}
+
+// Payload returns the dynamic payload charging amount under reference, the
+// reference label the payer's app reports back with the payment.
+func (g *Generator) Payload(amount int64, currency string, reference string) (payload string, err error) {
+	code, ok := currencyCodes[currency]
+	if !ok {
+		return "", fmt.Errorf("qris: unsupported currency %q", currency)
+	}
+	if amount <= 0 {
+		return "", fmt.Errorf("qris: amount %d must be positive", amount)
+	}
+	formatted := formatAmount(amount, currency)
+	if len(formatted) > 13 {
+		return "", fmt.Errorf("qris: amount %s is too large", formatted)
+	}
+	if len(reference) > 25 {
+		return "", fmt.Errorf("qris: reference %q is longer than 25 characters", reference)
+	}
+
+	m := g.merchant
+	fields := []field{
+		{ID: idFormat, Value: "01"},
+		{ID: idInitiation, Value: InitiationDynamic},
+	}
+	if m.MerchantPAN != "" {
+		fields = append(fields, field{ID: idAcquirerAccount, Value: encodeFields([]field{
+			{ID: idGloballyUnique, Value: m.AcquirerDomain},
+			{ID: idMerchantPAN, Value: m.MerchantPAN},
+			{ID: idMerchantId, Value: m.MerchantId},
+			{ID: idCriteria, Value: m.Criteria},
+		})})
+	}
+	if m.NMID != "" {
+		fields = append(fields, field{ID: idQRISAccount, Value: encodeFields([]field{
+			{ID: idGloballyUnique, Value: qrisDomain},
+			{ID: idMerchantId, Value: m.NMID},
+			{ID: idCriteria, Value: m.Criteria},
+		})})
+	}
+	fields = append(fields,
+		field{ID: idMCC, Value: m.MCC},
+		field{ID: idCurrency, Value: code},
+		field{ID: idAmount, Value: formatted},
+		field{ID: idCountry, Value: m.Country},
+		field{ID: idName, Value: m.Name},
+		field{ID: idCity, Value: m.City},
+		field{ID: idPostalCode, Value: m.PostalCode},
+		field{ID: idAdditionalData, Value: encodeFields([]field{{ID: idReferenceLabel, Value: reference}})},
+	)
+
+	payload = encodeFields(fields) + idCRC + "04"
+	payload += fmt.Sprintf("%04X", crc16(payload))
+
+	return payload, nil
+}
+
+// Render returns the QR code of payload as a PNG image about size pixels
+// wide, at least one pixel per module.
+func (g *Generator) Render(payload string, size int) (image []byte, err error) {
+	code, err := encodeQR([]byte(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	scale := size / (code.size + 8)
+	if scale < 1 {
+		scale = 1
+	}
+
+	return code.png(scale)
+}
+
+// Payload is a parsed payload.
+type Payload struct {
+	// Initiation is InitiationStatic or InitiationDynamic.
+	Initiation string
+	// Accounts are the merchant account templates by ID, "02" to "51",
+	// the subfields of templates mapped by their ID.
+	Accounts map[string]map[string]string
+	MCC      string
+	// Currency is the ISO 4217 numeric code of the currency.
+	Currency string
+	// Amount is in major units, empty when the payer enters it.
+	Amount     string
+	Country    string
+	Name       string
+	City       string
+	PostalCode string
+	// Reference is the reference label of the additional data.
+	Reference string
+}
+
+// Parse validates payload, its checksum, the syntax of its data objects and
+// the presence of the mandatory ones, and returns its content.
+func Parse(payload string) (*Payload, error) {
+	if len(payload) < 8 || payload[len(payload)-8:len(payload)-4] != idCRC+"04" {
+		return nil, errors.New("qris: the payload does not end with its CRC")
+	}
+	if want := fmt.Sprintf("%04X", crc16(payload[:len(payload)-4])); !strings.EqualFold(payload[len(payload)-4:], want) {
+		return nil, fmt.Errorf("qris: CRC is %s, want %s", payload[len(payload)-4:], want)
+	}
+
+	fields, err := decodeFields(payload)
+	if err != nil {
+		return nil, fmt.Errorf("qris: %w", err)
+	}
+	if fields[0].ID != idFormat || fields[0].Value != "01" {
+		return nil, errors.New(`qris: the payload must start with format indicator "01"`)
+	}
+
+	p := &Payload{Accounts: map[string]map[string]string{}}
+	seen := map[string]bool{}
+	for _, f := range fields {
+		if seen[f.ID] {
+			return nil, fmt.Errorf("qris: %s appears twice", f.ID)
+		}
+		seen[f.ID] = true
+
+		switch id, _ := strconv.Atoi(f.ID); {
+		case f.ID == idInitiation:
+			if f.Value != InitiationStatic && f.Value != InitiationDynamic {
+				return nil, fmt.Errorf("qris: unknown point of initiation %q", f.Value)
+			}
+			p.Initiation = f.Value
+		case id >= 2 && id <= 51:
+			account := map[string]string{}
+			if id >= 26 {
+				subfields, err := decodeFields(f.Value)
+				if err != nil {
+					return nil, fmt.Errorf("qris: merchant account %s: %w", f.ID, err)
+				}
+				for _, s := range subfields {
+					account[s.ID] = s.Value
+				}
+				if account[idGloballyUnique] == "" {
+					return nil, fmt.Errorf("qris: merchant account %s has no globally unique identifier", f.ID)
+				}
+			} else {
+				account[""] = f.Value
+			}
+			p.Accounts[f.ID] = account
+		case f.ID == idMCC:
+			p.MCC = f.Value
+		case f.ID == idCurrency:
+			p.Currency = f.Value
+		case f.ID == idAmount:
+			if len(f.Value) > 13 || !amount.MatchString(f.Value) {
+				return nil, fmt.Errorf("qris: invalid amount %q", f.Value)
+			}
+			p.Amount = f.Value
+		case f.ID == idCountry:
+			p.Country = f.Value
+		case f.ID == idName:
+			p.Name = f.Value
+		case f.ID == idCity:
+			p.City = f.Value
+		case f.ID == idPostalCode:
+			p.PostalCode = f.Value
+		case f.ID == idAdditionalData:
+			subfields, err := decodeFields(f.Value)
+			if err != nil {
+				return nil, fmt.Errorf("qris: additional data: %w", err)
+			}
+			for _, s := range subfields {
+				if s.ID == idReferenceLabel {
+					p.Reference = s.Value
+				}
+			}
+		}
+	}
+
+	switch {
+	case len(p.Accounts) == 0:
+		return nil, errors.New("qris: the payload has no merchant account")
+	case len(p.MCC) != 4 || !digits.MatchString(p.MCC):
+		return nil, fmt.Errorf("qris: invalid merchant category code %q", p.MCC)
+	case len(p.Currency) != 3 || !digits.MatchString(p.Currency):
+		return nil, fmt.Errorf("qris: invalid currency %q", p.Currency)
+	case len(p.Country) != 2:
+		return nil, fmt.Errorf("qris: invalid country %q", p.Country)
+	case p.Name == "" || p.City == "":
+		return nil, errors.New("qris: the payload needs the merchant name and city")
+	case p.Initiation == InitiationDynamic && p.Amount == "":
+		return nil, errors.New("qris: a dynamic payload needs an amount")
+	}
+
+	return p, nil
+}
@@ -0,0 +1,264 @@
+package qris
+
+import (
+	"bytes"
+	"image/png"
+	"order/domain"
+	"strings"
+	"testing"
+)
+
+var testMerchant = domain.QRISMerchant{
+	NMID:     "ID1020000000001",
+	Criteria: "UMI",
+	MCC:      "5814",
+	Name:     "Toko Kopi",
+	City:     "Jakarta",
+}
+
+func TestEncodeFields(t *testing.T) {
+	fields := []field{
+		{ID: "00", Value: "01"},
+		{ID: "26", Value: ""},
+		{ID: "59", Value: "BEST TRANSPORT"},
+	}
+
+	// empty values are left out, lengths are two digits
+	data := encodeFields(fields)
+	if want := "0002015914BEST TRANSPORT"; data != want {
+		t.Fatalf("encodeFields = %q; want %q", data, want)
+	}
+
+	decoded, err := decodeFields(data)
+	if err != nil || len(decoded) != 2 || decoded[0] != fields[0] || decoded[1] != fields[2] {
+		t.Errorf("decodeFields(%q) = %v, %v", data, decoded, err)
+	}
+}
+
+func TestDecodeFieldsErrors(t *testing.T) {
+	for _, data := range []string{
+		"000",          // truncated header
+		"A00201",       // invalid ID
+		"00XX01",       // invalid length
+		"000001",       // empty value
+		"0005012",      // value shorter than its length
+		"0002010103a1", // second object too short
+	} {
+		if fields, err := decodeFields(data); err == nil {
+			t.Errorf("decodeFields(%q) = %v; want an error", data, fields)
+		}
+	}
+}
+
+func TestCRC16(t *testing.T) {
+	// the check value of CRC-16/CCITT-FALSE
+	if crc := crc16("123456789"); crc != 0x29B1 {
+		t.Errorf("crc16(123456789) = %04X; want 29B1", crc)
+	}
+
+	// the example payload of the EMVCo merchant-presented mode specification
+	sample := "00020101021229300012D156000000000510A93FO3230Q31280012D15600000001030812345678520441115802CN5914BEST TRANSPORT6007BEIJING64200002ZH0104最佳运输0202北京540523.7253031565502016233030412340603***0708A60086670902ME91320016A0112233449988770708123456786304A13A"
+	if crc := crc16(sample[:len(sample)-4]); crc != 0xA13A {
+		t.Errorf("crc16 of the EMVCo example = %04X; want A13A", crc)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		currency string
+		want     string
+	}{
+		{150000, "IDR", "150000"},
+		{1250, "USD", "12.50"},
+		{5, "EUR", "0.05"},
+		{300, "JPY", "300"},
+	}
+	for _, tt := range tests {
+		if got := formatAmount(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("formatAmount(%d, %s) = %q; want %q", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestPayloadRoundTrip(t *testing.T) {
+	merchant := testMerchant
+	merchant.AcquirerDomain = "ID.CO.BANKMANDIRI.WWW"
+	merchant.MerchantPAN = "9360000812345678901"
+	merchant.MerchantId = "123456789012345"
+	merchant.PostalCode = "10110"
+	g, err := NewGenerator(merchant)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := g.Payload(1250, "USD", "INV-0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(payload, "000201010212") {
+		t.Errorf("payload %s does not start as a dynamic payload", payload)
+	}
+
+	p, err := Parse(payload)
+	if err != nil {
+		t.Fatalf("Parse(%s): %v", payload, err)
+	}
+	if p.Initiation != InitiationDynamic || p.MCC != "5814" || p.Currency != "840" || p.Amount != "12.50" ||
+		p.Country != "ID" || p.Name != "Toko Kopi" || p.City != "Jakarta" || p.PostalCode != "10110" || p.Reference != "INV-0001" {
+		t.Errorf("Parse = %+v", p)
+	}
+
+	qris := p.Accounts[idQRISAccount]
+	if qris[idGloballyUnique] != qrisDomain || qris[idMerchantId] != "ID1020000000001" || qris[idCriteria] != "UMI" {
+		t.Errorf("QRIS account = %v", qris)
+	}
+	acquirer := p.Accounts[idAcquirerAccount]
+	if acquirer[idGloballyUnique] != "ID.CO.BANKMANDIRI.WWW" || acquirer[idMerchantPAN] != "9360000812345678901" || acquirer[idMerchantId] != "123456789012345" {
+		t.Errorf("acquirer account = %v", acquirer)
+	}
+}
+
+func TestPayloadLimits(t *testing.T) {
+	g, err := NewGenerator(testMerchant)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := g.Payload(10000, "IDR", strings.Repeat("x", 25)); err != nil {
+		t.Errorf("Payload with a 25 characters reference: %v", err)
+	}
+
+	for name, call := range map[string]func() (string, error){
+		"long reference":       func() (string, error) { return g.Payload(10000, "IDR", strings.Repeat("x", 26)) },
+		"unsupported currency": func() (string, error) { return g.Payload(10000, "GBP", "r1") },
+		"zero amount":          func() (string, error) { return g.Payload(0, "IDR", "r1") },
+		"amount too large":     func() (string, error) { return g.Payload(1e13, "IDR", "r1") },
+	} {
+		if payload, err := call(); err == nil {
+			t.Errorf("%s: Payload = %s; want an error", name, payload)
+		}
+	}
+}
+
+func TestParseRejectsTamperedPayload(t *testing.T) {
+	g, err := NewGenerator(testMerchant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := g.Payload(10000, "IDR", "r1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := strings.Replace(payload, "540510000", "540590000", 1)
+	if tampered == payload {
+		t.Fatalf("payload %s has no amount of 10000", payload)
+	}
+	if _, err := Parse(tampered); err == nil || !strings.Contains(err.Error(), "CRC") {
+		t.Errorf("Parse of a tampered payload = %v; want a CRC error", err)
+	}
+	if _, err := Parse(payload[:len(payload)-8]); err == nil {
+		t.Error("Parse of a payload without its CRC succeeded")
+	}
+}
+
+func TestEncodeQRVersion(t *testing.T) {
+	// the byte mode capacities of versions at level M
+	tests := []struct {
+		length  int
+		version int
+	}{
+		{1, 1},
+		{14, 1},
+		{15, 2},
+		{26, 2},
+		{27, 3},
+		{180, 9},
+		{181, 10},
+		{2331, 40},
+	}
+	for _, tt := range tests {
+		code, err := encodeQR(bytes.Repeat([]byte("a"), tt.length))
+		if err != nil {
+			t.Fatalf("encodeQR of %d bytes: %v", tt.length, err)
+		}
+		if code.version != tt.version || code.size != 17+4*tt.version {
+			t.Errorf("%d bytes are encoded in version %d of %d modules; want version %d", tt.length, code.version, code.size, tt.version)
+		}
+	}
+
+	if _, err := encodeQR(bytes.Repeat([]byte("a"), 2332)); err != errTooLong {
+		t.Errorf("encodeQR beyond version 40 = %v; want %v", err, errTooLong)
+	}
+}
+
+func TestEncodeQRPatterns(t *testing.T) {
+	code, err := encodeQR([]byte("HELLO WORLD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the finder patterns are dark rings around a dark center
+	for _, corner := range [][2]int{{0, 0}, {code.size - 7, 0}, {0, code.size - 7}} {
+		for y := 0; y < 7; y++ {
+			for x := 0; x < 7; x++ {
+				ring := x == 0 || x == 6 || y == 0 || y == 6
+				center := x >= 2 && x <= 4 && y >= 2 && y <= 4
+				if dark := code.modules[corner[1]+y][corner[0]+x]; dark != (ring || center) {
+					t.Fatalf("finder pattern at %v: module %d,%d is dark %v", corner, x, y, dark)
+				}
+			}
+		}
+	}
+
+	// the timing patterns alternate between the finder patterns
+	for i := 8; i < code.size-8; i++ {
+		if code.modules[6][i] != (i%2 == 0) || code.modules[i][6] != (i%2 == 0) {
+			t.Fatalf("timing pattern module %d is wrong", i)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	g, err := NewGenerator(testMerchant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := g.Payload(10000, "IDR", "r1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := encodeQR([]byte(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, size := range []int{10, 256, 1000} {
+		data, err := g.Render(payload, size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Render(%d) is not a PNG image: %v", size, err)
+		}
+
+		scale := size / (code.size + 8)
+		if scale < 1 {
+			scale = 1
+		}
+		width := (code.size + 8) * scale
+		if bounds := img.Bounds(); bounds.Dx() != width || bounds.Dy() != width {
+			t.Errorf("Render(%d) is %v; want %d pixels wide", size, bounds, width)
+		}
+
+		// a white quiet zone, then the dark corner of the finder pattern
+		if r, _, _, _ := img.At(0, 0).RGBA(); r != 0xffff {
+			t.Errorf("Render(%d): the quiet zone is not white", size)
+		}
+		if r, _, _, _ := img.At(4*scale, 4*scale).RGBA(); r != 0 {
+			t.Errorf("Render(%d): the finder pattern is not dark", size)
+		}
+	}
+}
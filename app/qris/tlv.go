@@ -0,0 +1,70 @@
+package qris
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// field is a data object of a payload: a two digit ID, a two digit length
+// and the value.
+type field struct {
+	ID    string
+	Value string
+}
+
+func encodeFields(fields []field) string {
+	payload := ""
+	for _, f := range fields {
+		if f.Value == "" {
+			continue
+		}
+		payload += fmt.Sprintf("%s%02d%s", f.ID, len(f.Value), f.Value)
+	}
+
+	return payload
+}
+
+// decodeFields splits data into its data objects.
+func decodeFields(data string) ([]field, error) {
+	var fields []field
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return nil, fmt.Errorf("truncated data object %q", data)
+		}
+
+		id := data[:2]
+		if _, err := strconv.Atoi(id); err != nil {
+			return nil, fmt.Errorf("invalid ID %q", id)
+		}
+		length, err := strconv.Atoi(data[2:4])
+		if err != nil || length < 1 {
+			return nil, fmt.Errorf("invalid length %q of %s", data[2:4], id)
+		}
+		if len(data) < 4+length {
+			return nil, fmt.Errorf("%s is %d characters long, %d are left", id, length, len(data)-4)
+		}
+
+		fields = append(fields, field{ID: id, Value: data[4 : 4+length]})
+		data = data[4+length:]
+	}
+
+	return fields, nil
+}
+
+// crc16 returns the CRC-16/CCITT-FALSE checksum of data, polynomial 0x1021
+// starting from 0xFFFF, as EMVCo payloads are checked with.
+func crc16(data string) uint16 {
+	crc := uint16(0xffff)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
@@ -0,0 +1,235 @@
+// Package reconcile reads the settlement reports payment gateways export as
+// CSV files.
+package reconcile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"order/domain"
+	"order/variable"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields of a settlement row, each read from the first column named after
+// one of its aliases.
+const (
+	fieldReference     = "reference"
+	fieldVaNumber      = "va_number"
+	fieldBank          = "bank"
+	fieldTransactionId = "transaction_id"
+	fieldAmount        = "amount"
+	fieldFee           = "fee"
+	fieldCurrency      = "currency"
+	fieldStatus        = "status"
+	fieldTime          = "time"
+)
+
+// aliases are the column names gateways use for each field, normalized.
+var aliases = map[string][]string{
+	fieldReference:     {"order_id", "reference", "payment_reference", "merchant_reference"},
+	fieldVaNumber:      {"va_number", "virtual_account", "virtual_account_number", "va"},
+	fieldBank:          {"bank", "bank_code"},
+	fieldTransactionId: {"transaction_id", "trx_id", "transaction_reference"},
+	fieldAmount:        {"gross_amount", "amount", "settlement_amount", "paid_amount"},
+	fieldFee:           {"fee", "fee_amount", "transaction_fee", "mdr"},
+	fieldCurrency:      {"currency"},
+	fieldStatus:        {"transaction_status", "status"},
+	fieldTime:          {"settlement_time", "settled_at", "transaction_time", "paid_at"},
+}
+
+// settledStatuses are the statuses gateways report paid transactions with.
+var settledStatuses = map[string]bool{
+	"settlement": true,
+	"settled":    true,
+	"success":    true,
+	"succeeded":  true,
+	"capture":    true,
+	"paid":       true,
+}
+
+// timeLayouts are the layouts of the times without a zone reports are read
+// with, besides Unix times and RFC 3339.
+var timeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// Parser reads CSV settlement reports, with a header row naming the columns
+// and comma or semicolon separated values. Amounts are in major units, e.g.
+// "15000.00". Rows without a status are settled transactions.
+type Parser struct {
+	location *time.Location
+}
+
+// NewParser returns a Parser reading the times without a zone in location,
+// UTC when nil.
+func NewParser(location *time.Location) *Parser {
+	if location == nil {
+		location = time.UTC
+	}
+
+	return &Parser{location: location}
+}
+
+// normalize lowercases name and joins its words with underscores, so that
+// "Transaction Status" and "transaction-status" both read
+// "transaction_status".
+func normalize(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-' || r == '.'
+	}), "_")
+}
+
+// columns returns the column index of each field found in header.
+func columns(header []string) map[string]int {
+	index := map[string]int{}
+	for i, name := range header {
+		index[normalize(name)] = i
+	}
+
+	found := map[string]int{}
+	for field, names := range aliases {
+		for _, name := range names {
+			if i, ok := index[name]; ok {
+				found[field] = i
+				break
+			}
+		}
+	}
+
+	return found
+}
+
+// parseTime reads value as Unix seconds, RFC 3339, or one of timeLayouts in
+// location.
+func (p *Parser) parseTime(value string) (int64, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return seconds, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Unix(), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, p.location); err == nil {
+			return t.Unix(), nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid time %q", value)
+}
+
+func (p *Parser) Parse(content []byte, currency string) (rows []domain.SettlementRow, err error) {
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+	firstLine := content
+	if i := bytes.IndexByte(content, '\n'); i >= 0 {
+		firstLine = content[:i]
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the report is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	cols := columns(header)
+	if _, ok := cols[fieldAmount]; !ok {
+		return nil, errors.New("the report has no amount column")
+	}
+	_, hasReference := cols[fieldReference]
+	_, hasVA := cols[fieldVaNumber]
+	if !hasReference && !hasVA {
+		return nil, errors.New("the report has neither an order_id nor a va_number column")
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row, err := p.parseRow(record, cols, currency)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if row == nil {
+			continue
+		}
+		row.Line = int64(line)
+		rows = append(rows, *row)
+	}
+
+	return
+}
+
+// parseRow reads the row of record, or returns nil for a blank record.
+func (p *Parser) parseRow(record []string, cols map[string]int, currency string) (*domain.SettlementRow, error) {
+	get := func(field string) string {
+		if i, ok := cols[field]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+
+		return ""
+	}
+
+	blank := true
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			blank = false
+			break
+		}
+	}
+	if blank {
+		return nil, nil
+	}
+
+	row := &domain.SettlementRow{
+		Reference:     get(fieldReference),
+		Bank:          strings.ToLower(get(fieldBank)),
+		VaNumber:      get(fieldVaNumber),
+		TransactionId: get(fieldTransactionId),
+		Status:        strings.ToLower(get(fieldStatus)),
+	}
+	if row.Reference == "" && row.VaNumber == "" {
+		return nil, errors.New("neither an order_id nor a va_number")
+	}
+	if row.Status == "" || settledStatuses[row.Status] {
+		row.Status = variable.PaymentStatusSettlement
+	}
+
+	if code := strings.ToUpper(get(fieldCurrency)); code != "" {
+		currency = code
+	}
+	amount, err := domain.ParseMoney(currency, strings.ReplaceAll(get(fieldAmount), ",", ""))
+	if err != nil {
+		return nil, err
+	}
+	row.Amount, row.Fee = amount, domain.NewMoney(currency, 0)
+	if value := get(fieldFee); value != "" {
+		if row.Fee, err = domain.ParseMoney(currency, strings.ReplaceAll(value, ",", "")); err != nil {
+			return nil, err
+		}
+	}
+
+	if value := get(fieldTime); value != "" {
+		if row.SettledAt, err = p.parseTime(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return row, nil
+}
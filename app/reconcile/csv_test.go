@@ -0,0 +1,73 @@
+package reconcile
+
+import (
+	"order/domain"
+	"order/variable"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	idr := func(amount int64) domain.Money { return domain.NewMoney("IDR", amount) }
+
+	tests := []struct {
+		name    string
+		content string
+		rows    []domain.SettlementRow
+	}{
+		{
+			name:    "gateway column names",
+			content: "\ufeffOrder ID,Transaction Status,Gross Amount,MDR,Settlement Time\no1,success,\"10,000.00\",250,2023-03-01 07:30:00\n",
+			rows:    []domain.SettlementRow{{Line: 2, Reference: "o1", Status: variable.PaymentStatusSettlement, Amount: idr(10000), Fee: idr(250), SettledAt: 1677630600}},
+		},
+		{
+			name:    "semicolons and blank lines",
+			content: "va_number;bank;amount;currency\n\n8800001;BCA;12.50;usd\n;;;\n",
+			rows:    []domain.SettlementRow{{Line: 3, VaNumber: "8800001", Bank: "bca", Status: variable.PaymentStatusSettlement, Amount: domain.NewMoney("USD", 1250), Fee: domain.NewMoney("USD", 0)}},
+		},
+		{
+			name:    "duplicate rows are kept",
+			content: "order_id,trx_id,amount,status,paid_at\no1,t1,4000,settled,1677630600\no1,t1,4000,settled,1677630600\n",
+			rows: []domain.SettlementRow{
+				{Line: 2, Reference: "o1", TransactionId: "t1", Status: variable.PaymentStatusSettlement, Amount: idr(4000), Fee: idr(0), SettledAt: 1677630600},
+				{Line: 3, Reference: "o1", TransactionId: "t1", Status: variable.PaymentStatusSettlement, Amount: idr(4000), Fee: idr(0), SettledAt: 1677630600},
+			},
+		},
+		{
+			name:    "failed transactions",
+			content: "order_id,amount,status\no1,4000,Expire\n",
+			rows:    []domain.SettlementRow{{Line: 2, Reference: "o1", Status: "expire", Amount: idr(4000), Fee: idr(0)}},
+		},
+	}
+
+	p := NewParser(time.FixedZone("WIB", 7*3600))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := p.Parse([]byte(tt.content), "IDR")
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("Parse = %+v; want %+v", rows, tt.rows)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for name, content := range map[string]string{
+		"empty":                        "",
+		"no amount column":             "order_id,status\no1,settlement\n",
+		"no reference column":          "bank,amount\nbca,4000\n",
+		"row without an order or a VA": "order_id,va_number,amount\n,,4000\n",
+		"invalid amount":               "order_id,amount\no1,lots\n",
+		"too many decimals":            "order_id,amount\no1,4000.5\n",
+		"unknown currency":             "order_id,amount,currency\no1,4000,XXX\n",
+		"invalid time":                 "order_id,amount,settled_at\no1,4000,yesterday\n",
+	} {
+		if rows, err := NewParser(nil).Parse([]byte(content), "IDR"); err == nil {
+			t.Errorf("%s: Parse = %+v; want an error", name, rows)
+		}
+	}
+}
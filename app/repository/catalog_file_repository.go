@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"order/domain"
+	"os"
+)
+
+// CatalogFileRepository is a ProductCatalog read from a JSON file holding a
+// list of products, for local development and tests.
+type CatalogFileRepository struct {
+	products map[string]domain.CatalogProduct
+}
+
+// NewCatalogFileRepository loads the products of the JSON file at path, see
+// config/catalog.example.json.
+func NewCatalogFileRepository(path string) (domain.ProductCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []domain.CatalogProduct
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	products := map[string]domain.CatalogProduct{}
+	for _, product := range list {
+		if product.ProductId == "" || product.Price < 0 {
+			return nil, fmt.Errorf("%s: products need a product_id and a non-negative price", path)
+		}
+		if _, ok := products[product.ProductId]; ok {
+			return nil, fmt.Errorf("%s: product %s is listed twice", path, product.ProductId)
+		}
+
+		products[product.ProductId] = product
+	}
+
+	return &CatalogFileRepository{products: products}, nil
+}
+
+func (c *CatalogFileRepository) FindProduct(ctx context.Context, productId string) (product *domain.CatalogProduct, err error) {
+	found, ok := c.products[productId]
+	if !ok {
+		return nil, nil
+	}
+
+	return &found, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"order/pb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CatalogGRPCRepository is a ProductCatalog asking the catalog service.
+type CatalogGRPCRepository struct {
+	client pb.CatalogServiceClient
+}
+
+func NewCatalogGRPCRepository(conn grpc.ClientConnInterface) domain.ProductCatalog {
+	return &CatalogGRPCRepository{
+		client: pb.NewCatalogServiceClient(conn),
+	}
+}
+
+func (c *CatalogGRPCRepository) FindProduct(ctx context.Context, productId string) (product *domain.CatalogProduct, err error) {
+	res, err := c.client.GetProduct(ctx, &pb.CatalogProductRequest{ProductId: productId})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		// the order cannot be priced while the catalog is down
+		return nil, status.Errorf(codes.Unavailable, "product catalog: %v", status.Convert(err).Message())
+	}
+
+	product = &domain.CatalogProduct{
+		ProductId:   res.ProductId,
+		Name:        res.Name,
+		Price:       res.Price,
+		Duration:    res.Duration,
+		Description: res.Description,
+		Category:    res.Category,
+		Currency:    res.Currency,
+	}
+
+	return
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"sync"
+)
+
+// CouponMemoryRepository is an in-memory CouponRepository counting the
+// redemptions of each coupon per customer under the lock that checks its
+// caps.
+type CouponMemoryRepository struct {
+	mu          sync.Mutex
+	coupons     map[string]domain.Coupon
+	redemptions map[[2]string]int64
+}
+
+func NewCouponMemoryRepository() domain.CouponRepository {
+	return &CouponMemoryRepository{
+		coupons:     map[string]domain.Coupon{},
+		redemptions: map[[2]string]int64{},
+	}
+}
+
+func (c *CouponMemoryRepository) Save(ctx context.Context, coupon *domain.Coupon) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.coupons[coupon.Code]; ok {
+		return domain.ErrCouponExists
+	}
+
+	saved := *coupon
+	saved.ProductIds = append([]string{}, coupon.ProductIds...)
+	c.coupons[coupon.Code] = saved
+
+	return
+}
+
+func (c *CouponMemoryRepository) FindOne(ctx context.Context, code string) (coupon *domain.Coupon, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	found, ok := c.coupons[code]
+	if !ok {
+		return nil, nil
+	}
+
+	found.ProductIds = append([]string{}, found.ProductIds...)
+
+	return &found, nil
+}
+
+func (c *CouponMemoryRepository) Redeem(ctx context.Context, code string, customerId string) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	coupon, ok := c.coupons[code]
+	if !ok || (coupon.MaxUses > 0 && coupon.Used >= coupon.MaxUses) {
+		return domain.ErrCouponExhausted
+	}
+
+	key := [2]string{code, customerId}
+	if coupon.MaxUsesPerCustomer > 0 && c.redemptions[key] >= coupon.MaxUsesPerCustomer {
+		return domain.ErrCouponExhausted
+	}
+
+	coupon.Used++
+	c.coupons[code] = coupon
+	c.redemptions[key]++
+
+	return
+}
+
+func (c *CouponMemoryRepository) Release(ctx context.Context, code string, customerId string) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if coupon, ok := c.coupons[code]; ok && coupon.Used > 0 {
+		coupon.Used--
+		c.coupons[code] = coupon
+	}
+
+	key := [2]string{code, customerId}
+	if c.redemptions[key] > 0 {
+		c.redemptions[key]--
+	}
+
+	return
+}
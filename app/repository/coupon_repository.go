@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CouponRepository struct {
+	coupons     *mongo.Collection
+	redemptions *mongo.Collection
+}
+
+func NewCouponRepository(db *mongo.Database) domain.CouponRepository {
+	return &CouponRepository{
+		coupons:     db.Collection("coupons"),
+		redemptions: db.Collection("coupon_redemptions"),
+	}
+}
+
+// CreateCouponIndexes creates the unique indexes the coupon codes and the
+// atomic per customer usage caps rely on. It is safe to call repeatedly.
+func CreateCouponIndexes(ctx context.Context, db *mongo.Database) (err error) {
+	unique := options.Index().SetUnique(true)
+	_, err = db.Collection("coupons").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}},
+		Options: unique,
+	})
+	if err != nil {
+		return
+	}
+
+	_, err = db.Collection("coupon_redemptions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}, {Key: "customer_id", Value: 1}},
+		Options: unique,
+	})
+
+	return
+}
+
+func (c *CouponRepository) Save(ctx context.Context, coupon *domain.Coupon) (err error) {
+	if coupon.ProductIds == nil {
+		coupon.ProductIds = []string{}
+	}
+
+	_, err = c.coupons.InsertOne(ctx, coupon)
+	if mongo.IsDuplicateKeyError(err) {
+		err = domain.ErrCouponExists
+	}
+
+	return
+}
+
+func (c *CouponRepository) FindOne(ctx context.Context, code string) (coupon *domain.Coupon, err error) {
+	coupon = &domain.Coupon{}
+	err = c.coupons.FindOne(ctx, bson.M{"code": code}).Decode(coupon)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Redeem counts the use in a single conditional update per cap, so
+// concurrent redemptions can never push a counter past its cap.
+func (c *CouponRepository) Redeem(ctx context.Context, code string, customerId string) (err error) {
+	filter := bson.M{
+		"code": code,
+		"$or": []bson.M{
+			{"max_uses": bson.M{"$lte": 0}},
+			{"$expr": bson.M{"$lt": bson.A{"$used", "$max_uses"}}},
+		},
+	}
+	update := bson.M{"$inc": bson.M{"used": 1}}
+	var coupon domain.Coupon
+	err = c.coupons.FindOneAndUpdate(ctx, filter, update).Decode(&coupon)
+	if err == mongo.ErrNoDocuments {
+		return domain.ErrCouponExhausted
+	}
+	if err != nil {
+		return
+	}
+
+	// when the customer is at the cap the filter misses the existing
+	// counter and the upsert collides with it on the unique index
+	filter = bson.M{"code": code, "customer_id": customerId}
+	if coupon.MaxUsesPerCustomer > 0 {
+		filter["count"] = bson.M{"$lt": coupon.MaxUsesPerCustomer}
+	}
+	update = bson.M{"$inc": bson.M{"count": 1}}
+	_, err = c.redemptions.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err == nil {
+		return
+	}
+
+	if _, releaseErr := c.coupons.UpdateOne(ctx, bson.M{"code": code}, bson.M{"$inc": bson.M{"used": -1}}); releaseErr != nil {
+		return releaseErr
+	}
+
+	if mongo.IsDuplicateKeyError(err) {
+		err = domain.ErrCouponExhausted
+	}
+
+	return
+}
+
+func (c *CouponRepository) Release(ctx context.Context, code string, customerId string) (err error) {
+	filter := bson.M{"code": code, "used": bson.M{"$gt": 0}}
+	_, err = c.coupons.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"used": -1}})
+	if err != nil {
+		return
+	}
+
+	filter = bson.M{"code": code, "customer_id": customerId, "count": bson.M{"$gt": 0}}
+	_, err = c.redemptions.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"count": -1}})
+
+	return
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"order/variable"
+	"sort"
+	"sync"
+)
+
+// EntitlementMemoryRepository is an in-memory EntitlementRepository holding
+// one entitlement per customer and product.
+type EntitlementMemoryRepository struct {
+	mu           sync.Mutex
+	entitlements map[[2]string]*domain.Entitlement
+	outbox       *OutboxMemoryRepository
+}
+
+// NewEntitlementMemoryRepository writes the dunning events of renewals to
+// outbox.
+func NewEntitlementMemoryRepository(outbox *OutboxMemoryRepository) domain.EntitlementRepository {
+	return &EntitlementMemoryRepository{
+		entitlements: map[[2]string]*domain.Entitlement{},
+		outbox:       outbox,
+	}
+}
+
+func copyEntitlement(entitlement *domain.Entitlement) domain.Entitlement {
+	copied := *entitlement
+	copied.Grants = append([]domain.EntitlementGrant(nil), entitlement.Grants...)
+	if entitlement.Renewal != nil {
+		renewal := *entitlement.Renewal
+		copied.Renewal = &renewal
+	}
+
+	return copied
+}
+
+func (e *EntitlementMemoryRepository) Grant(ctx context.Context, customerId string, product domain.OrderProduct, grant domain.EntitlementGrant, updatedTime int64) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	key := [2]string{customerId, product.ProductId}
+	entitlement, ok := e.entitlements[key]
+	if !ok {
+		entitlement = &domain.Entitlement{CustomerId: customerId, ProductId: product.ProductId}
+		e.entitlements[key] = entitlement
+	}
+
+	for _, granted := range entitlement.Grants {
+		if granted.OrderId == grant.OrderId {
+			return
+		}
+	}
+
+	grant.StartsAt = grant.SettledAt
+	if entitlement.EndsAt > grant.StartsAt {
+		grant.StartsAt = entitlement.EndsAt
+	}
+	grant.EndsAt = grant.StartsAt + grant.Days*secondsPerDay
+
+	if entitlement.EndsAt < grant.SettledAt {
+		entitlement.StartsAt = grant.SettledAt
+	}
+	entitlement.ProductName = product.Name
+	entitlement.EndsAt = grant.EndsAt
+	entitlement.Grants = append(entitlement.Grants, grant)
+	entitlement.UpdatedAt = updatedTime
+
+	return
+}
+
+func (e *EntitlementMemoryRepository) Revoke(ctx context.Context, customerId string, productId string, orderId string, updatedTime int64) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entitlement, ok := e.entitlements[[2]string{customerId, productId}]
+	if !ok {
+		return
+	}
+
+	if revokeGrant(entitlement, orderId, updatedTime) {
+		entitlement.UpdatedAt = updatedTime
+	}
+
+	return
+}
+
+func (e *EntitlementMemoryRepository) Terminate(ctx context.Context, customerId string, productId string, orderId string, at int64, updatedTime int64) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entitlement, ok := e.entitlements[[2]string{customerId, productId}]
+	if !ok {
+		return
+	}
+
+	for _, grant := range entitlement.Grants {
+		if grant.OrderId == orderId {
+			return
+		}
+	}
+
+	if entitlement.EndsAt > at {
+		entitlement.EndsAt = at
+	}
+	entitlement.Grants = append(entitlement.Grants, domain.EntitlementGrant{
+		OrderId:   orderId,
+		SettledAt: at,
+		StartsAt:  at,
+		EndsAt:    at,
+	})
+	entitlement.UpdatedAt = updatedTime
+
+	return
+}
+
+func (e *EntitlementMemoryRepository) FindOne(ctx context.Context, customerId string, productId string) (entitlement *domain.Entitlement, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	found, ok := e.entitlements[[2]string{customerId, productId}]
+	if !ok {
+		return nil, nil
+	}
+
+	copied := copyEntitlement(found)
+
+	return &copied, nil
+}
+
+func (e *EntitlementMemoryRepository) FindAll(ctx context.Context, customerId string) (entitlements []domain.Entitlement, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for key, entitlement := range e.entitlements {
+		if key[0] == customerId {
+			entitlements = append(entitlements, copyEntitlement(entitlement))
+		}
+	}
+
+	sort.Slice(entitlements, func(i, j int) bool {
+		return entitlements[i].ProductId < entitlements[j].ProductId
+	})
+
+	return
+}
+
+func dunning(renewal *domain.EntitlementRenewal) bool {
+	if renewal == nil {
+		return false
+	}
+
+	for _, stage := range variable.DunningStages {
+		if renewal.Stage == stage {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *EntitlementMemoryRepository) FindRenewals(ctx context.Context, endsBefore int64, now int64) (entitlements []domain.Entitlement, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, entitlement := range e.entitlements {
+		due := !entitlement.RenewalOptOut &&
+			entitlement.EndsAt > now && entitlement.EndsAt <= endsBefore &&
+			(entitlement.Renewal == nil || entitlement.Renewal.PeriodEnd != entitlement.EndsAt)
+
+		if due || dunning(entitlement.Renewal) {
+			entitlements = append(entitlements, copyEntitlement(entitlement))
+		}
+	}
+
+	sort.Slice(entitlements, func(i, j int) bool {
+		if entitlements[i].CustomerId != entitlements[j].CustomerId {
+			return entitlements[i].CustomerId < entitlements[j].CustomerId
+		}
+
+		return entitlements[i].ProductId < entitlements[j].ProductId
+	})
+
+	return
+}
+
+func (e *EntitlementMemoryRepository) ClaimRenewal(ctx context.Context, customerId string, productId string, renewal domain.EntitlementRenewal) (claimed bool, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entitlement, ok := e.entitlements[[2]string{customerId, productId}]
+	if !ok || entitlement.EndsAt != renewal.PeriodEnd || entitlement.RenewalOptOut {
+		return
+	}
+	if entitlement.Renewal != nil && entitlement.Renewal.PeriodEnd == renewal.PeriodEnd {
+		return
+	}
+
+	entitlement.Renewal = &renewal
+
+	return true, nil
+}
+
+func (e *EntitlementMemoryRepository) UpdateRenewal(ctx context.Context, customerId string, productId string, current domain.EntitlementRenewal, next domain.EntitlementRenewal, event *domain.OutboxEvent) (updated bool, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entitlement, ok := e.entitlements[[2]string{customerId, productId}]
+	if !ok || entitlement.Renewal == nil {
+		return
+	}
+
+	renewal := entitlement.Renewal
+	if renewal.OrderId != current.OrderId || renewal.Stage != current.Stage || renewal.Reminders != current.Reminders {
+		return
+	}
+
+	entitlement.Renewal = &next
+	if event != nil {
+		e.outbox.add(*event)
+	}
+
+	return true, nil
+}
+
+func (e *EntitlementMemoryRepository) ReleaseRenewal(ctx context.Context, customerId string, productId string, orderId string) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entitlement, ok := e.entitlements[[2]string{customerId, productId}]
+	if ok && entitlement.Renewal != nil && entitlement.Renewal.OrderId == orderId {
+		entitlement.Renewal = nil
+	}
+
+	return
+}
+
+func (e *EntitlementMemoryRepository) SetRenewalOptOut(ctx context.Context, customerId string, productId string, optOut bool, updatedTime int64) (found bool, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entitlement, ok := e.entitlements[[2]string{customerId, productId}]
+	if !ok {
+		return
+	}
+
+	entitlement.RenewalOptOut = optOut
+	entitlement.UpdatedAt = updatedTime
+
+	return true, nil
+}
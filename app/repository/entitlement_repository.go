@@ -0,0 +1,363 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"order/domain"
+	"order/variable"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// secondsPerDay converts grant days into entitlement time.
+const secondsPerDay = 24 * 60 * 60
+
+type EntitlementRepository struct {
+	db           *mongo.Database
+	entitlements *mongo.Collection
+	outbox       *mongo.Collection
+}
+
+// NewEntitlementRepository writes the dunning events of renewals to the
+// outbox of db in a transaction, so MongoDB must run as a replica set.
+func NewEntitlementRepository(db *mongo.Database) domain.EntitlementRepository {
+	return &EntitlementRepository{
+		db:           db,
+		entitlements: db.Collection("entitlements"),
+		outbox:       db.Collection("outbox"),
+	}
+}
+
+// CreateEntitlementIndexes creates the unique index keeping a single
+// entitlement per customer and product. It is safe to call repeatedly.
+func CreateEntitlementIndexes(ctx context.Context, db *mongo.Database) (err error) {
+	_, err = db.Collection("entitlements").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "customer_id", Value: 1}, {Key: "product_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	return
+}
+
+// Grant stacks the grant in a single upsert. When the order was already
+// granted the filter misses the existing entitlement and the upsert collides
+// with it on the unique index, which is how a repeated grant is detected.
+func (e *EntitlementRepository) Grant(ctx context.Context, customerId string, product domain.OrderProduct, grant domain.EntitlementGrant, updatedTime int64) (err error) {
+	filter := bson.M{
+		"customer_id":     customerId,
+		"product_id":      product.ProductId,
+		"grants.order_id": bson.M{"$ne": grant.OrderId},
+	}
+
+	seconds := grant.Days * secondsPerDay
+	end := bson.M{"$add": bson.A{"$_start", seconds}}
+	update := bson.A{
+		bson.M{"$set": bson.M{"_end": bson.M{"$ifNull": bson.A{"$ends_at", 0}}}},
+		bson.M{"$set": bson.M{"_start": bson.M{"$max": bson.A{grant.SettledAt, "$_end"}}}},
+		bson.M{"$set": bson.M{
+			"product_name": bson.M{"$literal": product.Name},
+			"starts_at": bson.M{"$cond": bson.A{
+				bson.M{"$gte": bson.A{"$_end", grant.SettledAt}},
+				"$starts_at",
+				grant.SettledAt,
+			}},
+			"ends_at": end,
+			"grants": bson.M{"$concatArrays": bson.A{
+				bson.M{"$ifNull": bson.A{"$grants", bson.A{}}},
+				bson.A{bson.M{
+					"order_id":   bson.M{"$literal": grant.OrderId},
+					"days":       grant.Days,
+					"settled_at": grant.SettledAt,
+					"starts_at":  "$_start",
+					"ends_at":    end,
+				}},
+			}},
+			"updated_at": updatedTime,
+		}},
+		bson.M{"$unset": bson.A{"_end", "_start"}},
+	}
+
+	_, err = e.entitlements.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		err = nil
+	}
+
+	return
+}
+
+// revokeGrant removes the grant of orderId from entitlement at now, taking
+// back the part of it that was not used yet. The grants stacked directly on
+// it, and on each other, start earlier by as much, though never before they
+// were settled. It reports false when entitlement has no such grant.
+func revokeGrant(entitlement *domain.Entitlement, orderId string, now int64) bool {
+	index := -1
+	for i, grant := range entitlement.Grants {
+		if grant.OrderId == orderId {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return false
+	}
+
+	revoked := entitlement.Grants[index]
+	grants := append(append([]domain.EntitlementGrant(nil), entitlement.Grants[:index]...), entitlement.Grants[index+1:]...)
+
+	// end is where the grants moved so far ended, and moved where they end
+	// now
+	end, moved := revoked.EndsAt, revoked.StartsAt
+	if now > moved {
+		moved = now
+	}
+	if moved > end {
+		moved = end
+	}
+	for i := index; i < len(grants) && moved < end && grants[i].StartsAt == end; i++ {
+		grant := &grants[i]
+		start := moved
+		if start < grant.SettledAt {
+			start = grant.SettledAt
+		}
+
+		end = grant.EndsAt
+		grant.EndsAt = start + grant.EndsAt - grant.StartsAt
+		grant.StartsAt = start
+		moved = grant.EndsAt
+	}
+
+	if entitlement.EndsAt == end {
+		entitlement.EndsAt = moved
+	}
+	entitlement.Grants = grants
+
+	return true
+}
+
+// Revoke rewrites the grants with revokeGrant, only if they did not change
+// since they were read.
+func (e *EntitlementRepository) Revoke(ctx context.Context, customerId string, productId string, orderId string, updatedTime int64) (err error) {
+	filter := bson.M{
+		"customer_id":     customerId,
+		"product_id":      productId,
+		"grants.order_id": orderId,
+	}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		entitlement := &domain.Entitlement{}
+		err = e.entitlements.FindOne(ctx, filter).Decode(entitlement)
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		if err != nil {
+			return
+		}
+
+		current := bson.M{
+			"customer_id": customerId,
+			"product_id":  productId,
+			"ends_at":     entitlement.EndsAt,
+			"grants":      entitlement.Grants,
+		}
+		revokeGrant(entitlement, orderId, updatedTime)
+
+		res, err := e.entitlements.UpdateOne(ctx, current, bson.M{"$set": bson.M{
+			"ends_at":    entitlement.EndsAt,
+			"grants":     entitlement.Grants,
+			"updated_at": updatedTime,
+		}})
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount > 0 {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("revoke %s: the entitlement kept changing", orderId)
+}
+
+func (e *EntitlementRepository) Terminate(ctx context.Context, customerId string, productId string, orderId string, at int64, updatedTime int64) (err error) {
+	filter := bson.M{
+		"customer_id":     customerId,
+		"product_id":      productId,
+		"grants.order_id": bson.M{"$ne": orderId},
+	}
+
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"ends_at": bson.M{"$min": bson.A{"$ends_at", at}},
+			"grants": bson.M{"$concatArrays": bson.A{
+				bson.M{"$ifNull": bson.A{"$grants", bson.A{}}},
+				bson.A{bson.M{
+					"order_id":   bson.M{"$literal": orderId},
+					"days":       0,
+					"settled_at": at,
+					"starts_at":  at,
+					"ends_at":    at,
+				}},
+			}},
+			"updated_at": updatedTime,
+		}},
+	}
+
+	_, err = e.entitlements.UpdateOne(ctx, filter, update)
+
+	return
+}
+
+func (e *EntitlementRepository) FindOne(ctx context.Context, customerId string, productId string) (entitlement *domain.Entitlement, err error) {
+	entitlement = &domain.Entitlement{}
+	filter := bson.M{"customer_id": customerId, "product_id": productId}
+	err = e.entitlements.FindOne(ctx, filter).Decode(entitlement)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (e *EntitlementRepository) FindAll(ctx context.Context, customerId string) (entitlements []domain.Entitlement, err error) {
+	findOpt := options.Find().SetSort(bson.M{"product_id": 1})
+	cur, err := e.entitlements.Find(ctx, bson.M{"customer_id": customerId}, findOpt)
+	if err != nil {
+		return
+	}
+
+	defer cur.Close(ctx)
+
+	err = cur.All(ctx, &entitlements)
+
+	return
+}
+
+func renewalDocument(renewal domain.EntitlementRenewal) bson.D {
+	return bson.D{
+		{Key: "order_id", Value: renewal.OrderId},
+		{Key: "period_end", Value: renewal.PeriodEnd},
+		{Key: "pay_exp", Value: renewal.PayExp},
+		{Key: "stage", Value: renewal.Stage},
+		{Key: "reminders", Value: renewal.Reminders},
+		{Key: "updated_at", Value: renewal.UpdatedAt},
+	}
+}
+
+func (e *EntitlementRepository) FindRenewals(ctx context.Context, endsBefore int64, now int64) (entitlements []domain.Entitlement, err error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{
+			"renewal_opt_out": bson.M{"$ne": true},
+			"ends_at":         bson.M{"$gt": now, "$lte": endsBefore},
+			"$expr":           bson.M{"$ne": bson.A{"$renewal.period_end", "$ends_at"}},
+		},
+		bson.M{"renewal.stage": bson.M{"$in": variable.DunningStages}},
+	}}
+
+	findOpt := options.Find().SetSort(bson.D{{Key: "customer_id", Value: 1}, {Key: "product_id", Value: 1}})
+	cur, err := e.entitlements.Find(ctx, filter, findOpt)
+	if err != nil {
+		return
+	}
+
+	defer cur.Close(ctx)
+
+	err = cur.All(ctx, &entitlements)
+
+	return
+}
+
+func (e *EntitlementRepository) ClaimRenewal(ctx context.Context, customerId string, productId string, renewal domain.EntitlementRenewal) (claimed bool, err error) {
+	filter := bson.M{
+		"customer_id":        customerId,
+		"product_id":         productId,
+		"ends_at":            renewal.PeriodEnd,
+		"renewal_opt_out":    bson.M{"$ne": true},
+		"renewal.period_end": bson.M{"$ne": renewal.PeriodEnd},
+	}
+	update := bson.M{"$set": bson.M{"renewal": renewalDocument(renewal)}}
+
+	resp, err := e.entitlements.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return
+	}
+
+	claimed = resp.ModifiedCount > 0
+
+	return
+}
+
+func (e *EntitlementRepository) UpdateRenewal(ctx context.Context, customerId string, productId string, current domain.EntitlementRenewal, next domain.EntitlementRenewal, event *domain.OutboxEvent) (updated bool, err error) {
+	filter := bson.M{
+		"customer_id":       customerId,
+		"product_id":        productId,
+		"renewal.order_id":  current.OrderId,
+		"renewal.stage":     current.Stage,
+		"renewal.reminders": current.Reminders,
+	}
+	update := bson.M{"$set": bson.M{"renewal": renewalDocument(next)}}
+
+	if event == nil {
+		resp, err := e.entitlements.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return false, err
+		}
+
+		return resp.ModifiedCount > 0, nil
+	}
+
+	err = e.db.Client().UseSession(ctx, func(sc mongo.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(sc mongo.SessionContext) (interface{}, error) {
+			resp, err := e.entitlements.UpdateOne(sc, filter, update)
+			if err != nil {
+				return nil, err
+			}
+
+			// a stale renewal has nothing to notify
+			updated = resp.ModifiedCount > 0
+			if !updated {
+				return nil, nil
+			}
+
+			_, err = e.outbox.InsertOne(sc, event)
+
+			return nil, err
+		})
+
+		return err
+	})
+	if err != nil {
+		updated = false
+	}
+
+	return
+}
+
+func (e *EntitlementRepository) ReleaseRenewal(ctx context.Context, customerId string, productId string, orderId string) (err error) {
+	filter := bson.M{
+		"customer_id":      customerId,
+		"product_id":       productId,
+		"renewal.order_id": orderId,
+	}
+
+	_, err = e.entitlements.UpdateOne(ctx, filter, bson.M{"$unset": bson.M{"renewal": ""}})
+
+	return
+}
+
+func (e *EntitlementRepository) SetRenewalOptOut(ctx context.Context, customerId string, productId string, optOut bool, updatedTime int64) (found bool, err error) {
+	filter := bson.M{"customer_id": customerId, "product_id": productId}
+	update := bson.M{"$set": bson.M{"renewal_opt_out": optOut, "updated_at": updatedTime}}
+
+	resp, err := e.entitlements.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return
+	}
+
+	found = resp.MatchedCount > 0
+
+	return
+}
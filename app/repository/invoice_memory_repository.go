@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"sync"
+)
+
+// InvoiceMemoryRepository is an in-memory InvoiceRepository numbering the
+// invoices of each year with a counter that only moves once an invoice is
+// built.
+type InvoiceMemoryRepository struct {
+	mu       sync.Mutex
+	invoices map[string]domain.Invoice
+	counters map[int64]int64
+}
+
+func NewInvoiceMemoryRepository() domain.InvoiceRepository {
+	return &InvoiceMemoryRepository{
+		invoices: map[string]domain.Invoice{},
+		counters: map[int64]int64{},
+	}
+}
+
+func copyInvoice(invoice domain.Invoice) *domain.Invoice {
+	invoice.HTML = append([]byte(nil), invoice.HTML...)
+	invoice.PDF = append([]byte(nil), invoice.PDF...)
+
+	return &invoice
+}
+
+func (i *InvoiceMemoryRepository) FindOne(ctx context.Context, orderId string) (invoice *domain.Invoice, err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	found, ok := i.invoices[orderId]
+	if !ok {
+		return
+	}
+
+	return copyInvoice(found), nil
+}
+
+func (i *InvoiceMemoryRepository) Issue(ctx context.Context, orderId string, year int64, build func(sequence int64) (*domain.Invoice, error)) (invoice *domain.Invoice, err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if found, ok := i.invoices[orderId]; ok {
+		return copyInvoice(found), nil
+	}
+
+	sequence := i.counters[year] + 1
+	invoice, err = build(sequence)
+	if err != nil {
+		return nil, err
+	}
+
+	i.counters[year] = sequence
+	i.invoices[orderId] = *copyInvoice(*invoice)
+
+	return
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InvoiceRepository stores invoices in the "invoices" collection, numbering
+// them from one counter document per year in "invoice_counters". A counter
+// is only incremented in the transaction saving the invoice, so numbers are
+// gap-free.
+type InvoiceRepository struct {
+	db       *mongo.Database
+	invoices *mongo.Collection
+	counters *mongo.Collection
+}
+
+func NewInvoiceRepository(db *mongo.Database) domain.InvoiceRepository {
+	return &InvoiceRepository{
+		db:       db,
+		invoices: db.Collection("invoices"),
+		counters: db.Collection("invoice_counters"),
+	}
+}
+
+// CreateInvoiceIndexes creates the unique indexes giving an order a single
+// invoice, and a number to a single invoice. It is safe to call repeatedly.
+func CreateInvoiceIndexes(ctx context.Context, db *mongo.Database) (err error) {
+	_, err = db.Collection("invoices").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "order_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "year", Value: 1}, {Key: "sequence", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+
+	return
+}
+
+func (i *InvoiceRepository) FindOne(ctx context.Context, orderId string) (invoice *domain.Invoice, err error) {
+	invoice = &domain.Invoice{}
+	err = i.invoices.FindOne(ctx, bson.M{"order_id": orderId}).Decode(invoice)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (i *InvoiceRepository) Issue(ctx context.Context, orderId string, year int64, build func(sequence int64) (*domain.Invoice, error)) (invoice *domain.Invoice, err error) {
+	err = i.db.Client().UseSession(ctx, func(sc mongo.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(sc mongo.SessionContext) (interface{}, error) {
+			existing, err := i.FindOne(sc, orderId)
+			if err != nil || existing != nil {
+				invoice = existing
+				return nil, err
+			}
+
+			var counter struct {
+				Sequence int64 `bson:"sequence"`
+			}
+			opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+			err = i.counters.FindOneAndUpdate(sc, bson.M{"_id": year}, bson.M{"$inc": bson.M{"sequence": 1}}, opts).Decode(&counter)
+			if err != nil {
+				return nil, err
+			}
+
+			invoice, err = build(counter.Sequence)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = i.invoices.InsertOne(sc, invoice)
+
+			return nil, err
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LedgerMemoryRepository is an in-memory LedgerRepository keeping the
+// journals in the order they were posted, and their IDs to post each once.
+type LedgerMemoryRepository struct {
+	mu       sync.RWMutex
+	journals []domain.Journal
+	posted   map[string]bool
+}
+
+func NewLedgerMemoryRepository() domain.LedgerRepository {
+	return &LedgerMemoryRepository{posted: map[string]bool{}}
+}
+
+func (l *LedgerMemoryRepository) Post(ctx context.Context, journal *domain.Journal) (posted bool, err error) {
+	if err = journal.Check(); err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.posted[journal.JournalId] {
+		return false, nil
+	}
+
+	saved := *journal
+	saved.Lines = append([]domain.JournalLine(nil), journal.Lines...)
+	l.journals = append(l.journals, saved)
+	l.posted[journal.JournalId] = true
+
+	return true, nil
+}
+
+// inAccount mirrors accountFilter.
+func inAccount(name string, account string) bool {
+	return account == "" || name == account || strings.HasPrefix(name, account+":")
+}
+
+func (l *LedgerMemoryRepository) Balances(ctx context.Context, account string, currency string, from int64, to int64) (balances []domain.AccountBalance, err error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	type key struct{ account, currency string }
+	sums := map[key]*domain.AccountBalance{}
+	for _, journal := range l.journals {
+		if !inRange(journal.PostedAt, from, to) || (currency != "" && journal.Currency != currency) {
+			continue
+		}
+
+		for _, line := range journal.Lines {
+			if !inAccount(line.Account, account) {
+				continue
+			}
+
+			k := key{line.Account, journal.Currency}
+			if sums[k] == nil {
+				sums[k] = &domain.AccountBalance{Account: line.Account, Currency: journal.Currency}
+			}
+			sums[k].Debit += line.Debit
+			sums[k].Credit += line.Credit
+			sums[k].Balance += line.Debit - line.Credit
+		}
+	}
+
+	for _, balance := range sums {
+		balances = append(balances, *balance)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].Account != balances[j].Account {
+			return balances[i].Account < balances[j].Account
+		}
+		return balances[i].Currency < balances[j].Currency
+	})
+
+	return
+}
+
+func (l *LedgerMemoryRepository) Journals(ctx context.Context, from int64, to int64, fn func(journal domain.Journal) error) error {
+	l.mu.RLock()
+	journals := append([]domain.Journal(nil), l.journals...)
+	l.mu.RUnlock()
+
+	sort.SliceStable(journals, func(i, j int) bool { return journals[i].RecordedAt < journals[j].RecordedAt })
+	for _, journal := range journals {
+		if !inRange(journal.PostedAt, from, to) {
+			continue
+		}
+		if err := fn(journal); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
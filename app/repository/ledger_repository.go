@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LedgerRepository stores the journals of the ledger in the "ledger"
+// collection, one document per journal. Documents are only ever inserted.
+type LedgerRepository struct {
+	journals *mongo.Collection
+}
+
+func NewLedgerRepository(db *mongo.Database) domain.LedgerRepository {
+	return &LedgerRepository{journals: db.Collection("ledger")}
+}
+
+// CreateLedgerIndexes creates the unique index posting each journal once,
+// and the index of the periods balances are asked for. It is safe to call
+// repeatedly.
+func CreateLedgerIndexes(ctx context.Context, db *mongo.Database) (err error) {
+	_, err = db.Collection("ledger").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "journal_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "posted_at", Value: 1}}},
+	})
+
+	return
+}
+
+func (l *LedgerRepository) Post(ctx context.Context, journal *domain.Journal) (posted bool, err error) {
+	if err = journal.Check(); err != nil {
+		return
+	}
+
+	_, err = l.journals.InsertOne(ctx, journal)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// accountFilter matches account and its sub-accounts.
+func accountFilter(account string) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"lines.account": account},
+		bson.M{"lines.account": bson.M{"$regex": "^" + regexp.QuoteMeta(account) + ":"}},
+	}}
+}
+
+func (l *LedgerRepository) Balances(ctx context.Context, account string, currency string, from int64, to int64) (balances []domain.AccountBalance, err error) {
+	match := bson.M{}
+	if period := timeRange(from, to); period != nil {
+		match["posted_at"] = period
+	}
+	if currency != "" {
+		match["currency"] = currency
+	}
+	lines := bson.M{}
+	if account != "" {
+		lines = accountFilter(account)
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$unwind": "$lines"},
+		{"$match": lines},
+		{"$group": bson.M{
+			"_id":    bson.M{"account": "$lines.account", "currency": "$currency"},
+			"debit":  bson.M{"$sum": "$lines.debit"},
+			"credit": bson.M{"$sum": "$lines.credit"},
+		}},
+		{"$sort": bson.D{{Key: "_id.account", Value: 1}, {Key: "_id.currency", Value: 1}}},
+	}
+
+	cur, err := l.journals.Aggregate(ctx, pipeline)
+	if err != nil {
+		return
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var row struct {
+			Id struct {
+				Account  string `bson:"account"`
+				Currency string `bson:"currency"`
+			} `bson:"_id"`
+			Debit  int64 `bson:"debit"`
+			Credit int64 `bson:"credit"`
+		}
+		if err = cur.Decode(&row); err != nil {
+			return nil, err
+		}
+
+		balances = append(balances, domain.AccountBalance{
+			Account:  row.Id.Account,
+			Currency: row.Id.Currency,
+			Debit:    row.Debit,
+			Credit:   row.Credit,
+			Balance:  row.Debit - row.Credit,
+		})
+	}
+
+	return balances, cur.Err()
+}
+
+func (l *LedgerRepository) Journals(ctx context.Context, from int64, to int64, fn func(journal domain.Journal) error) error {
+	filter := bson.M{}
+	if period := timeRange(from, to); period != nil {
+		filter["posted_at"] = period
+	}
+
+	cur, err := l.journals.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "recorded_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var journal domain.Journal
+		if err := cur.Decode(&journal); err != nil {
+			return err
+		}
+		if err := fn(journal); err != nil {
+			return err
+		}
+	}
+
+	return cur.Err()
+}
@@ -0,0 +1,61 @@
+package repository_test
+
+import (
+	"order/app/repository"
+	"order/app/repository/repositorytest"
+	"order/domain"
+	"order/helper"
+	"testing"
+)
+
+func TestMemoryOrders(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, clock helper.Clock) domain.OrderRepository {
+		return repository.NewOrderMemoryRepository(clock, repository.NewOutboxMemoryRepository())
+	})
+}
+
+func TestMemoryCoupons(t *testing.T) {
+	repositorytest.RunCoupons(t, func(t *testing.T) domain.CouponRepository {
+		return repository.NewCouponMemoryRepository()
+	})
+}
+
+func TestMemoryEntitlements(t *testing.T) {
+	repositorytest.RunEntitlements(t, func(t *testing.T) (domain.EntitlementRepository, domain.OutboxRepository) {
+		outbox := repository.NewOutboxMemoryRepository()
+
+		return repository.NewEntitlementMemoryRepository(outbox), outbox
+	})
+}
+
+func TestMemoryOutbox(t *testing.T) {
+	repositorytest.RunOutbox(t, func(t *testing.T, clock helper.Clock) (domain.OrderRepository, domain.OutboxRepository) {
+		outbox := repository.NewOutboxMemoryRepository()
+
+		return repository.NewOrderMemoryRepository(clock, outbox), outbox
+	})
+}
+
+func TestMemoryWebhooks(t *testing.T) {
+	repositorytest.RunWebhooks(t, func(t *testing.T) domain.WebhookRepository {
+		return repository.NewWebhookMemoryRepository()
+	})
+}
+
+func TestMemoryNotifications(t *testing.T) {
+	repositorytest.RunNotifications(t, func(t *testing.T) domain.NotificationRepository {
+		return repository.NewNotificationMemoryRepository()
+	})
+}
+
+func TestMemoryInvoices(t *testing.T) {
+	repositorytest.RunInvoices(t, func(t *testing.T) domain.InvoiceRepository {
+		return repository.NewInvoiceMemoryRepository()
+	})
+}
+
+func TestMemoryLedger(t *testing.T) {
+	repositorytest.RunLedger(t, func(t *testing.T) domain.LedgerRepository {
+		return repository.NewLedgerMemoryRepository()
+	})
+}
@@ -0,0 +1,146 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"order/app/repository"
+	"order/app/repository/repositorytest"
+	"order/domain"
+	"order/helper"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The MongoDB suites run against the replica set at MONGO_TEST_URI, e.g.
+// mongodb://localhost:27017/?replicaSet=rs0, and are skipped without one.
+var (
+	mongoOnce   sync.Once
+	mongoClient *mongo.Client
+	mongoErr    error
+	databases   int64
+)
+
+func skipWithoutMongo(t *testing.T) {
+	if os.Getenv("MONGO_TEST_URI") == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+}
+
+// testDatabase returns a fresh database with every index of the service,
+// dropped when t ends.
+func testDatabase(t *testing.T) *mongo.Database {
+	skipWithoutMongo(t)
+
+	uri := os.Getenv("MONGO_TEST_URI")
+	mongoOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		mongoClient, mongoErr = mongo.Connect(ctx, options.Client().ApplyURI(uri))
+		if mongoErr == nil {
+			mongoErr = mongoClient.Ping(ctx, nil)
+		}
+	})
+	if mongoErr != nil {
+		t.Fatalf("connect to %s: %v", uri, mongoErr)
+	}
+
+	ctx := context.Background()
+	db := mongoClient.Database(fmt.Sprintf("order_test_%d_%d", os.Getpid(), atomic.AddInt64(&databases, 1)))
+	t.Cleanup(func() {
+		if err := db.Drop(ctx); err != nil {
+			t.Errorf("drop %s: %v", db.Name(), err)
+		}
+	})
+
+	for _, create := range []func(context.Context, *mongo.Database) error{
+		repository.CreateCouponIndexes,
+		repository.CreateEntitlementIndexes,
+		repository.CreateOutboxIndexes,
+		repository.CreateWebhookIndexes,
+		repository.CreateNotificationIndexes,
+		repository.CreateInvoiceIndexes,
+		repository.CreateVirtualAccountIndexes,
+		repository.CreateLedgerIndexes,
+	} {
+		if err := create(ctx, db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return db
+}
+
+func TestMongoOrders(t *testing.T) {
+	skipWithoutMongo(t)
+
+	repositorytest.Run(t, func(t *testing.T, clock helper.Clock) domain.OrderRepository {
+		return repository.NewOrderRepository(testDatabase(t), clock)
+	})
+}
+
+func TestMongoCoupons(t *testing.T) {
+	skipWithoutMongo(t)
+
+	repositorytest.RunCoupons(t, func(t *testing.T) domain.CouponRepository {
+		return repository.NewCouponRepository(testDatabase(t))
+	})
+}
+
+func TestMongoEntitlements(t *testing.T) {
+	skipWithoutMongo(t)
+
+	repositorytest.RunEntitlements(t, func(t *testing.T) (domain.EntitlementRepository, domain.OutboxRepository) {
+		db := testDatabase(t)
+
+		return repository.NewEntitlementRepository(db), repository.NewOutboxRepository(db)
+	})
+}
+
+func TestMongoOutbox(t *testing.T) {
+	skipWithoutMongo(t)
+
+	repositorytest.RunOutbox(t, func(t *testing.T, clock helper.Clock) (domain.OrderRepository, domain.OutboxRepository) {
+		db := testDatabase(t)
+
+		return repository.NewOrderRepository(db, clock), repository.NewOutboxRepository(db)
+	})
+}
+
+func TestMongoWebhooks(t *testing.T) {
+	skipWithoutMongo(t)
+
+	repositorytest.RunWebhooks(t, func(t *testing.T) domain.WebhookRepository {
+		return repository.NewWebhookRepository(testDatabase(t))
+	})
+}
+
+func TestMongoNotifications(t *testing.T) {
+	skipWithoutMongo(t)
+
+	repositorytest.RunNotifications(t, func(t *testing.T) domain.NotificationRepository {
+		return repository.NewNotificationRepository(testDatabase(t))
+	})
+}
+
+func TestMongoInvoices(t *testing.T) {
+	skipWithoutMongo(t)
+
+	repositorytest.RunInvoices(t, func(t *testing.T) domain.InvoiceRepository {
+		return repository.NewInvoiceRepository(testDatabase(t))
+	})
+}
+
+func TestMongoLedger(t *testing.T) {
+	skipWithoutMongo(t)
+
+	repositorytest.RunLedger(t, func(t *testing.T) domain.LedgerRepository {
+		return repository.NewLedgerRepository(testDatabase(t))
+	})
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"order/variable"
+	"sync"
+)
+
+// NotificationMemoryRepository is an in-memory NotificationRepository
+// holding the notifications by ID.
+type NotificationMemoryRepository struct {
+	mu            sync.Mutex
+	notifications map[string]*domain.Notification
+}
+
+func NewNotificationMemoryRepository() domain.NotificationRepository {
+	return &NotificationMemoryRepository{
+		notifications: map[string]*domain.Notification{},
+	}
+}
+
+func (n *NotificationMemoryRepository) Claim(ctx context.Context, notification *domain.Notification, staleBefore int64) (claimed bool, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	stored, ok := n.notifications[notification.NotificationId]
+	if !ok {
+		claim := *notification
+		claim.Status = variable.NotificationSending
+		claim.Attempts = 1
+		n.notifications[claim.NotificationId] = &claim
+
+		return true, nil
+	}
+
+	stale := stored.Status == variable.NotificationSending && stored.UpdatedAt < staleBefore
+	if stored.Status != variable.NotificationFailed && !stale {
+		return
+	}
+
+	stored.Recipient = notification.Recipient
+	stored.Status = variable.NotificationSending
+	stored.Error = ""
+	stored.UpdatedAt = notification.UpdatedAt
+	stored.Attempts++
+
+	return true, nil
+}
+
+func (n *NotificationMemoryRepository) Complete(ctx context.Context, notification domain.Notification) (err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	stored, ok := n.notifications[notification.NotificationId]
+	if !ok {
+		return
+	}
+
+	stored.Status = notification.Status
+	stored.Error = notification.Error
+	stored.UpdatedAt = notification.UpdatedAt
+	stored.SentAt = notification.SentAt
+
+	return
+}
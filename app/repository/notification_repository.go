@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"order/variable"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NotificationRepository struct {
+	notifications *mongo.Collection
+}
+
+func NewNotificationRepository(db *mongo.Database) domain.NotificationRepository {
+	return &NotificationRepository{
+		notifications: db.Collection("notifications"),
+	}
+}
+
+// CreateNotificationIndexes creates the unique index a notification is
+// claimed once with, and the index listing the notifications of an order.
+// It is safe to call repeatedly.
+func CreateNotificationIndexes(ctx context.Context, db *mongo.Database) (err error) {
+	_, err = db.Collection("notifications").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "notification_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "order_id", Value: 1}, {Key: "created_at", Value: 1}}},
+	})
+
+	return
+}
+
+func (n *NotificationRepository) Claim(ctx context.Context, notification *domain.Notification, staleBefore int64) (claimed bool, err error) {
+	// take over a notification that failed, or whose sender went away
+	filter := bson.M{
+		"notification_id": notification.NotificationId,
+		"$or": bson.A{
+			bson.M{"status": variable.NotificationFailed},
+			bson.M{"status": variable.NotificationSending, "updated_at": bson.M{"$lt": staleBefore}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"recipient":  notification.Recipient,
+			"status":     variable.NotificationSending,
+			"error":      "",
+			"updated_at": notification.UpdatedAt,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	resp, err := n.notifications.UpdateOne(ctx, filter, update)
+	if err != nil || resp.MatchedCount > 0 {
+		return err == nil, err
+	}
+
+	claim := *notification
+	claim.Status = variable.NotificationSending
+	claim.Attempts = 1
+	_, err = n.notifications.InsertOne(ctx, claim)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (n *NotificationRepository) Complete(ctx context.Context, notification domain.Notification) (err error) {
+	_, err = n.notifications.UpdateOne(ctx, bson.M{"notification_id": notification.NotificationId}, bson.M{
+		"$set": bson.M{
+			"status":     notification.Status,
+			"error":      notification.Error,
+			"updated_at": notification.UpdatedAt,
+			"sent_at":    notification.SentAt,
+		},
+	})
+
+	return
+}
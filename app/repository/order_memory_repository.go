@@ -0,0 +1,656 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"regexp"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// OrderMemoryRepository is an in-memory OrderRepository, meant for tests and
+// local development without a database.
+type OrderMemoryRepository struct {
+	mu     sync.RWMutex
+	orders []domain.Order
+	outbox *OutboxMemoryRepository
+	clock  helper.Clock
+}
+
+// NewOrderMemoryRepository returns an empty repository writing the events of
+// its orders to outbox.
+func NewOrderMemoryRepository(clock helper.Clock, outbox *OutboxMemoryRepository) domain.OrderRepository {
+	return &OrderMemoryRepository{clock: clock, outbox: outbox}
+}
+
+// record writes the event of type eventType about order to the outbox. The
+// caller holds o.mu, so the event is written with the change.
+func (o *OrderMemoryRepository) record(eventType string, order domain.Order) error {
+	event, err := newOutboxEvent(eventType, order, helper.Unix(o.clock))
+	if err != nil {
+		return err
+	}
+	o.outbox.add(event)
+
+	return nil
+}
+
+func (o *OrderMemoryRepository) Expire(ctx context.Context, now int64) (affected int64, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.orders {
+		order := &o.orders[i]
+		if !isUnpaid(order.Status) || order.PayExp <= 0 || order.PayExp >= now {
+			continue
+		}
+
+		order.Status = variable.PayementStatusExpire
+		order.UpdatedAt = now
+		order.StatusReason = "payment window elapsed"
+		order.Version++
+		affected++
+
+		if err = o.record(variable.OrderEventExpired, *order); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (o *OrderMemoryRepository) Cancel(ctx context.Context, req *pb.OrderCancelRequest) (res *pb.OperationResponse, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	updatedTime := helper.Unix(o.clock)
+	res = &pb.OperationResponse{}
+	for i := range o.orders {
+		order := &o.orders[i]
+		if order.OrderId != req.OrderId || order.Buyer.CustomerId != req.UserId {
+			continue
+		}
+
+		if req.ExpectedVersion > 0 && order.Version != req.ExpectedVersion {
+			err = &domain.VersionConflictError{OrderId: req.OrderId, Current: order.Version}
+			return
+		}
+
+		if order.Status != "pending" {
+			continue
+		}
+
+		// UpdateOne modifies the first matching document only
+		order.Status = "cancel"
+		order.UpdatedAt = updatedTime
+		order.Version++
+		res.IsAffected = true
+
+		err = o.record(variable.OrderEventCancelled, *order)
+
+		break
+	}
+
+	return
+}
+
+func (o *OrderMemoryRepository) SumIncome(ctx context.Context, req *pb.OrderSumIncomeRequest) (totals []domain.Money, err error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	status := "settlement"
+	if req.Status != "" {
+		status = req.Status
+	}
+
+	settled := status == variable.PaymentStatusSettlement
+	sums := domain.MoneyTotals{}
+	for _, order := range o.orders {
+		if req.Currency != "" && order.Currency != req.Currency {
+			continue
+		}
+		if req.UserId != "" && order.Buyer.CustomerId != req.UserId {
+			continue
+		}
+
+		if !settled {
+			if order.Status == status && inRange(order.CreatedAt, req.From, req.To) {
+				amount := order.Payment.GrossAmount
+				if status == variable.PaymentStatusPartiallyPaid {
+					amount = order.PaidTotal
+				}
+				if err = sums.Add(domain.NewMoney(order.Currency, amount)); err != nil {
+					return
+				}
+			}
+
+			continue
+		}
+
+		// orders not paid in full count under their own status
+		if !isSettled(order.Status) {
+			continue
+		}
+
+		if len(order.Receipts) == 0 && inRange(order.SettlementTime, req.From, req.To) {
+			if err = sums.Add(domain.NewMoney(order.Currency, order.Payment.GrossAmount)); err != nil {
+				return
+			}
+		}
+
+		for _, receipt := range order.Receipts {
+			if inRange(receipt.ReceivedAt, req.From, req.To) {
+				if err = sums.Add(domain.NewMoney(order.Currency, receipt.Amount)); err != nil {
+					return
+				}
+			}
+		}
+
+		for _, refund := range order.Refunds {
+			if inRange(refund.CreatedAt, req.From, req.To) {
+				if err = sums.Add(domain.NewMoney(order.Currency, -refund.Amount)); err != nil {
+					return
+				}
+			}
+		}
+	}
+
+	if len(sums) == 0 {
+		err = mongo.ErrNoDocuments
+	}
+
+	totals = sums.List()
+
+	return
+}
+
+// inRange mirrors timeRange.
+func inRange(t int64, from int64, to int64) bool {
+	return (from == 0 && to == 0) || (t >= from && (to == 0 || t < to))
+}
+
+func isSettled(status string) bool {
+	for _, settled := range variable.SettledStatuses {
+		if status == settled {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *OrderMemoryRepository) Refund(ctx context.Context, orderId string, refund *domain.OrderRefund, expectedVersion int64) (res *pb.Order, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.orders {
+		order := &o.orders[i]
+		if order.OrderId != orderId {
+			continue
+		}
+
+		if err = refundError(*order, refund, expectedVersion); err != nil {
+			return
+		}
+
+		order.Refunds = append(order.Refunds, *refund)
+		order.RefundedTotal += refund.Amount
+		order.StatusReason = refund.Reason
+		order.UpdatedAt = refund.CreatedAt
+		order.Version++
+		order.Status = variable.PaymentStatusPartiallyRefunded
+		if order.RefundedTotal >= order.AmountPaid() {
+			order.Status = variable.PaymentStatusRefunded
+		}
+
+		if err = o.record(variable.OrderEventRefunded, *order); err != nil {
+			return
+		}
+
+		return parseOrderResponse(*order), nil
+	}
+
+	return
+}
+
+func (o *OrderMemoryRepository) StampCurrency(ctx context.Context, currency string) (affected int64, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.orders {
+		if o.orders[i].Currency == "" {
+			o.orders[i].Currency = currency
+			affected++
+		}
+	}
+
+	return
+}
+
+func (o *OrderMemoryRepository) TaxReport(ctx context.Context, req *pb.OrderTaxReportRequest) (rows []*pb.OrderTaxReportRow, err error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return taxReport(o.orders, req)
+}
+
+// match mirrors the filter built by OrderRepository.FindAll.
+func (o *OrderMemoryRepository) match(order domain.Order, req *pb.OrderFindAllRequest, search *regexp.Regexp, now int64) bool {
+	switch {
+	case req.Status == "none" || req.Status == "":
+		if order.Status == "" {
+			return false
+		}
+	case req.Status == "cancel":
+		overdue := order.PayExp < now && !isSettled(order.Status)
+		if order.Status != "cancel" && !overdue {
+			return false
+		}
+	case order.Status != req.Status:
+		return false
+	}
+
+	if req.Status == "pending" && order.PayExp <= now {
+		return false
+	}
+
+	if req.UserId != "" && order.Buyer.CustomerId != req.UserId {
+		return false
+	}
+
+	if req.ProductId != "" && !hasProduct(order, req.ProductId) {
+		return false
+	}
+
+	fields := []string{order.OrderId, order.Status, order.Buyer.Name, order.Buyer.User, order.Product.Name}
+	for _, line := range order.Items {
+		fields = append(fields, line.Product.Name)
+	}
+	for _, field := range fields {
+		if search.MatchString(field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasProduct(order domain.Order, productId string) bool {
+	if order.Product.ProductId == productId {
+		return true
+	}
+
+	for _, line := range order.Items {
+		if line.Product.ProductId == productId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *OrderMemoryRepository) FindAll(ctx context.Context, req *pb.OrderFindAllRequest) (result *pb.OrderFindAllResponse, err error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	result = &pb.OrderFindAllResponse{}
+
+	search, err := regexp.Compile("(?i)" + req.Search)
+	if err != nil {
+		return nil, err
+	}
+
+	now := helper.Unix(o.clock)
+	var matched []domain.Order
+	for _, order := range o.orders {
+		if o.match(order, req, search, now) {
+			matched = append(matched, order)
+		}
+	}
+
+	if req.Sort == "desc" {
+		sort.SliceStable(matched, func(i, j int) bool {
+			return matched[i].OrderId > matched[j].OrderId
+		})
+	}
+
+	page := req.Page
+	perPage := req.PerPage
+	offset := page * perPage
+
+	var orders []*pb.Order
+	if !req.CountOnly {
+		for i, each := range matched {
+			if int64(i) < offset {
+				continue
+			}
+
+			if perPage > 0 && int64(len(orders)) >= perPage {
+				break
+			}
+
+			orders = append(orders, parseOrderResponse(each))
+		}
+
+		if len(orders) < 1 {
+			result.IsEmpty = true
+
+			return result, nil
+		}
+	}
+
+	result.Payload = &pb.OrderFindAllPayload{
+		Orders: orders,
+	}
+
+	rows := int64(len(matched))
+
+	dataSize := int64(len(result.Payload.Orders))
+	result.Payload.Rows = rows
+	result.Payload.Pages = int64(math.Ceil(float64(rows) / float64(perPage)))
+	if dataSize < 1 {
+		result.Payload.Pages = 0
+	} else if perPage == 0 {
+		result.Payload.Pages = 1
+	}
+
+	result.Payload.PerPage = perPage
+	result.Payload.ActivePage = page + 1
+	if dataSize < 1 {
+		result.Payload.ActivePage = 0
+	}
+	result.Payload.Total = dataSize
+
+	return
+}
+
+func (o *OrderMemoryRepository) FindOne(ctx context.Context, req *pb.OrderFindOneRequest) (res *pb.OrderFindOneResponse, err error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	for _, order := range o.orders {
+		if order.OrderId == req.OrderId {
+			res = &pb.OrderFindOneResponse{Payload: parseOrderResponse(order)}
+			return
+		}
+	}
+
+	res = &pb.OrderFindOneResponse{IsEmpty: true}
+
+	return
+}
+
+// paidBy reports whether order is paid under reference: by its active
+// attempt, or by any of its attempts for a settlement.
+func paidBy(order domain.Order, reference string, settle bool) bool {
+	if order.Payment.OrderID == reference {
+		return true
+	}
+	if !settle {
+		return false
+	}
+
+	for _, attempt := range order.Attempts {
+		if attempt.Reference == reference {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *OrderMemoryRepository) ChangeStatus(ctx context.Context, req *pb.OrderChangeStatus, updatedTime int64) (affected bool, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	settle := req.Status == variable.PaymentStatusSettlement
+	for i := range o.orders {
+		order := &o.orders[i]
+		if !paidBy(*order, req.OrderId, settle) {
+			continue
+		}
+
+		if req.ExpectedVersion > 0 && order.Version != req.ExpectedVersion {
+			err = &domain.VersionConflictError{OrderId: req.OrderId, Current: order.Version}
+			return
+		}
+
+		if req.Status == variable.PayementStatusExpire && order.Status == variable.PayementStatusCancel {
+			continue
+		}
+		if settle && order.Status != variable.PaymentStatusSettlement && isSettled(order.Status) {
+			continue
+		}
+
+		changed := order.Status != req.Status ||
+			order.UpdatedAt != updatedTime ||
+			order.StatusReason != req.Reason ||
+			(settle && order.SettlementTime != req.SettlementTime)
+
+		// like MongoDB, a write that changes nothing does not bump the version
+		if !changed {
+			return
+		}
+
+		order.Status = req.Status
+		order.UpdatedAt = updatedTime
+		order.StatusReason = req.Reason
+		if settle {
+			order.SettlementTime = req.SettlementTime
+
+			attempts, paid := settleAttempt(order.Attempts, req.OrderId)
+			if paid != nil {
+				order.Attempts, order.Payment = attempts, paid.Payment()
+			}
+		}
+		order.Version++
+		affected = true
+
+		err = o.record(statusEvent(req.Status), *order)
+
+		return
+	}
+
+	return
+}
+
+func (o *OrderMemoryRepository) FindByPaymentReference(ctx context.Context, reference string) (res *pb.Order, err error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	for _, order := range o.orders {
+		if paidBy(order, reference, true) {
+			return parseOrderResponse(order), nil
+		}
+	}
+
+	return
+}
+
+// paidInto mirrors vaFilter.
+func paidInto(order domain.Order, bank string, vaNumber string) bool {
+	if order.Payment.VaNumber == vaNumber && (bank == "" || order.Payment.Bank == bank) {
+		return true
+	}
+
+	for _, attempt := range order.Attempts {
+		if attempt.VaNumber == vaNumber && (bank == "" || attempt.Bank == bank) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *OrderMemoryRepository) FindByVANumber(ctx context.Context, bank string, vaNumber string, at int64) (res *pb.Order, err error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var latest *domain.Order
+	for i, order := range o.orders {
+		if !paidInto(order, bank, vaNumber) || (at > 0 && order.CreatedAt > at) {
+			continue
+		}
+		if latest == nil || order.CreatedAt > latest.CreatedAt || (order.CreatedAt == latest.CreatedAt && order.OrderId > latest.OrderId) {
+			latest = &o.orders[i]
+		}
+	}
+	if latest == nil {
+		return
+	}
+
+	return parseOrderResponse(*latest), nil
+}
+
+func (o *OrderMemoryRepository) FindPaid(ctx context.Context, from int64, to int64) (orders []*pb.Order, err error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	for _, order := range o.orders {
+		if order.Payment.GrossAmount <= 0 {
+			continue
+		}
+
+		paid := len(order.Receipts) == 0 && isSettled(order.Status) && inRange(order.SettlementTime, from, to)
+		for _, receipt := range order.Receipts {
+			paid = paid || inRange(receipt.ReceivedAt, from, to)
+		}
+		if paid {
+			orders = append(orders, parseOrderResponse(order))
+		}
+	}
+
+	sort.Slice(orders, func(i, j int) bool { return orders[i].OrderId < orders[j].OrderId })
+
+	return
+}
+
+// isUnpaid reports whether an order in status holds on to its virtual
+// account number.
+func isUnpaid(status string) bool {
+	for _, unpaid := range variable.UnpaidStatuses {
+		if status == unpaid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// vaTaken reports whether another unpaid order of the bank of payment has
+// its virtual account number, like the partial unique index of the MongoDB
+// implementation. The caller holds o.mu.
+func (o *OrderMemoryRepository) vaTaken(orderId string, payment domain.OrderPayment) bool {
+	if payment.VaNumber == "" {
+		return false
+	}
+
+	for _, other := range o.orders {
+		if other.OrderId != orderId && isUnpaid(other.Status) && other.Payment.Bank == payment.Bank && other.Payment.VaNumber == payment.VaNumber {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o *OrderMemoryRepository) RecordPayment(ctx context.Context, receipt *domain.OrderReceipt, policy domain.PaymentPolicy, updatedTime int64, expectedVersion int64) (res *pb.Order, affected bool, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.orders {
+		order := &o.orders[i]
+		if !paidBy(*order, receipt.Reference, true) {
+			continue
+		}
+
+		if expectedVersion > 0 && order.Version != expectedVersion {
+			err = &domain.VersionConflictError{OrderId: order.OrderId, Current: order.Version}
+			return
+		}
+
+		var event string
+		if event, err = applyReceipt(order, *receipt, policy, updatedTime); err != nil {
+			return
+		}
+		if event != "" {
+			if err = o.record(event, *order); err != nil {
+				return
+			}
+			affected = true
+		}
+
+		return parseOrderResponse(*order), affected, nil
+	}
+
+	return
+}
+
+func (o *OrderMemoryRepository) RetryPayment(ctx context.Context, orderId string, attempt *domain.PaymentAttempt, expectedVersion int64) (res *pb.Order, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.orders {
+		order := &o.orders[i]
+		if order.OrderId != orderId {
+			continue
+		}
+
+		if err = retryError(*order, attempt, expectedVersion); err != nil {
+			return
+		}
+		if o.vaTaken(orderId, attempt.Payment()) {
+			return nil, domain.ErrVANumberTaken
+		}
+
+		order.Attempts = retryAttempts(*order, *attempt)
+		order.Payment = attempt.Payment()
+		order.PayExp = attempt.ExpiresAt
+		order.Status = variable.PaymentStatusPending
+		order.StatusReason = "payment retried"
+		order.UpdatedAt = attempt.CreatedAt
+		order.Version++
+
+		if err = o.record(variable.OrderEventPaymentRetried, *order); err != nil {
+			return
+		}
+
+		return parseOrderResponse(*order), nil
+	}
+
+	return
+}
+
+func (o *OrderMemoryRepository) Save(ctx context.Context, order *domain.Order) (err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if isUnpaid(order.Status) && o.vaTaken(order.OrderId, order.Payment) {
+		return domain.ErrVANumberTaken
+	}
+
+	saved := *order
+	saved.Items = append([]domain.OrderItem(nil), order.Items...)
+	saved.Discounts = append([]domain.OrderDiscount(nil), order.Discounts...)
+	saved.Taxes = append([]domain.OrderTax(nil), order.Taxes...)
+	saved.Refunds = append([]domain.OrderRefund(nil), order.Refunds...)
+	saved.Attempts = append([]domain.PaymentAttempt(nil), order.Attempts...)
+	saved.Receipts = append([]domain.OrderReceipt(nil), order.Receipts...)
+	if order.PlanChange != nil {
+		change := *order.PlanChange
+		saved.PlanChange = &change
+	}
+
+	if err = o.record(variable.OrderEventCreated, saved); err != nil {
+		return
+	}
+	o.orders = append(o.orders, saved)
+
+	return
+}
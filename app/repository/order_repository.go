@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"order/domain"
 	"order/helper"
 	"order/pb"
 	"order/variable"
+	"sort"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,40 +20,166 @@ import (
 type OrderRepository struct {
 	db     *mongo.Database
 	orders *mongo.Collection
+	outbox *mongo.Collection
+	clock  helper.Clock
 }
 
-func NewOrderRepository(db *mongo.Database) domain.OrderRepository {
+// NewOrderRepository returns the repository of the orders of db. Every
+// change to an order is written in a transaction together with its event in
+// the outbox, so MongoDB must run as a replica set.
+func NewOrderRepository(db *mongo.Database, clock helper.Clock) domain.OrderRepository {
 	return &OrderRepository{
 		db:     db,
 		orders: db.Collection("orders"),
+		outbox: db.Collection("outbox"),
+		clock:  clock,
 	}
 }
 
-func (pr *OrderRepository) parseOrderResponse(each domain.Order) (order *pb.Order) {
-	product := &pb.OrderProduct{
-		ProductId:   each.Product.ProductId,
-		Name:        each.Product.Name,
-		Price:       each.Product.Price,
-		Duration:    each.Product.Duration,
-		Description: each.Product.Name,
+// transact runs fn in a transaction, retrying it on transient errors. An
+// error returned by fn aborts the transaction and is returned as is.
+func (o *OrderRepository) transact(ctx context.Context, fn func(ctx mongo.SessionContext) error) error {
+	return o.db.Client().UseSession(ctx, func(sc mongo.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(sc mongo.SessionContext) (interface{}, error) {
+			return nil, fn(sc)
+		})
+
+		return err
+	})
+}
+
+// record writes the event of type eventType about the orders matched by
+// filter, as they are after the change, to the outbox.
+func (o *OrderRepository) record(ctx context.Context, eventType string, filter bson.M) (err error) {
+	cur, err := o.orders.Find(ctx, filter)
+	if err != nil {
+		return
+	}
+
+	defer cur.Close(ctx)
+
+	var orders []domain.Order
+	if err = cur.All(ctx, &orders); err != nil {
+		return
+	}
+
+	now := helper.Unix(o.clock)
+	events := []interface{}{}
+	for _, order := range orders {
+		event, err := newOutboxEvent(eventType, order, now)
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	_, err = o.outbox.InsertMany(ctx, events)
+
+	return
+}
+
+// parseOrderResponse converts a stored order into its protobuf representation.
+func parseOrderResponse(each domain.Order) (order *pb.Order) {
+	var items []*pb.OrderItem
+	for _, line := range each.Lines() {
+		items = append(items, &pb.OrderItem{
+			Product:   parseProductResponse(line.Product),
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+			LineTotal: line.LineTotal,
+			Discount:  line.Discount,
+		})
+	}
+
+	var discounts []*pb.OrderDiscount
+	for _, discount := range each.Discounts {
+		discounts = append(discounts, &pb.OrderDiscount{
+			Code:        discount.Code,
+			Description: discount.Description,
+			Amount:      discount.Amount,
+		})
+	}
+
+	var taxes []*pb.OrderTax
+	for _, tax := range each.Taxes {
+		taxes = append(taxes, &pb.OrderTax{
+			Name:    tax.Name,
+			Rate:    tax.Rate,
+			Taxable: tax.Taxable,
+			Amount:  tax.Amount,
+		})
+	}
+
+	var refunds []*pb.OrderRefund
+	for _, refund := range each.Refunds {
+		refunds = append(refunds, &pb.OrderRefund{
+			RefundId:  refund.RefundId,
+			Amount:    refund.Amount,
+			Reason:    refund.Reason,
+			Actor:     refund.Actor,
+			Reference: refund.Reference,
+			CreatedAt: refund.CreatedAt,
+		})
+	}
+
+	var attempts []*pb.PaymentAttempt
+	for _, attempt := range each.PaymentAttempts() {
+		attempts = append(attempts, &pb.PaymentAttempt{
+			Reference:   attempt.Reference,
+			PaymentType: attempt.PaymentType,
+			Bank:        attempt.Bank,
+			VaNumber:    attempt.VaNumber,
+			QrString:    attempt.QrString,
+			Amount:      attempt.Amount,
+			ExpiresAt:   attempt.ExpiresAt,
+			Status:      attempt.Status,
+			CreatedAt:   attempt.CreatedAt,
+		})
+	}
+
+	var receipts []*pb.OrderReceipt
+	for _, receipt := range each.Receipts {
+		receipts = append(receipts, &pb.OrderReceipt{
+			TransactionId: receipt.TransactionId,
+			Reference:     receipt.Reference,
+			Amount:        receipt.Amount,
+			Reason:        receipt.Reason,
+			ReceivedAt:    receipt.ReceivedAt,
+			Fee:           receipt.Fee,
+		})
 	}
 
 	payment := &pb.OrderPayment{
 		PaymentType: each.Payment.PaymentType,
-		OrderId:     each.Payment.PaymentType,
+		OrderId:     each.Payment.OrderID,
 		Bank:        each.Payment.Bank,
 		VaNumber:    each.Payment.VaNumber,
 		GrossAmount: each.Payment.GrossAmount,
+		QrString:    each.Payment.QrString,
 	}
 
 	order = &pb.Order{
 		OrderId: each.OrderId,
 		Buyer: &pb.OrderBuyer{
-			CustomerId: each.Buyer.CustomerId,
-			Name:       each.Buyer.Name,
-			User:       each.Buyer.User,
+			CustomerId:   each.Buyer.CustomerId,
+			Name:         each.Buyer.Name,
+			User:         each.Buyer.User,
+			Jurisdiction: each.Buyer.Jurisdiction,
+			Email:        each.Buyer.Email,
+			Phone:        each.Buyer.Phone,
+			Locale:       each.Buyer.Locale,
 		},
-		Product:        product,
+		Product:        parseProductResponse(each.Product),
+		Items:          items,
+		Subtotal:       each.OrderSubtotal(),
+		Discounts:      discounts,
+		Taxes:          taxes,
+		TaxInclusive:   each.TaxInclusive,
+		TaxTotal:       each.TaxTotal,
+		Total:          each.OrderTotal(),
 		Payment:        payment,
 		Status:         each.Status,
 		CreatedAt:      each.CreatedAt,
@@ -59,29 +187,113 @@ func (pr *OrderRepository) parseOrderResponse(each domain.Order) (order *pb.Orde
 		SettlementTime: each.SettlementTime,
 		TrxTime:        each.TrxTime,
 		PayExp:         each.PayExp,
+		StatusReason:   each.StatusReason,
+		Version:        each.Version,
+		Currency:       each.Currency,
+		Refunds:        refunds,
+		RefundedTotal:  each.RefundedTotal,
+		MerchantId:     each.MerchantId,
+		Attempts:       attempts,
+		Receipts:       receipts,
+		PaidTotal:      each.AmountPaid(),
+	}
+
+	if change := each.PlanChange; change != nil {
+		order.PlanChange = &pb.OrderPlanChange{
+			FromProductId: change.FromProductId,
+			UnusedValue:   change.UnusedValue,
+			Credit:        change.Credit,
+		}
+	}
+	if surplus := each.Overpayment; surplus != nil {
+		order.Overpayment = &pb.OrderOverpayment{
+			Amount:      surplus.Amount,
+			Disposition: surplus.Disposition,
+		}
 	}
 
 	return
 }
 
+func parseProductResponse(product domain.OrderProduct) *pb.OrderProduct {
+	return &pb.OrderProduct{
+		ProductId:   product.ProductId,
+		Name:        product.Name,
+		Price:       product.Price,
+		Duration:    product.Duration,
+		Description: product.Description,
+		Category:    product.Category,
+	}
+}
+
 // Template
 // func (pr *OrderRepository) {
 // }
 
+func (o *OrderRepository) Expire(ctx context.Context, now int64) (affected int64, err error) {
+	filter := bson.M{
+		"status":  bson.M{"$in": variable.UnpaidStatuses},
+		"pay_exp": bson.M{"$gt": 0, "$lt": now},
+	}
+	payload := bson.M{
+		"status":        variable.PayementStatusExpire,
+		"updated_at":    now,
+		"status_reason": "payment window elapsed",
+	}
+	update := bson.M{"$set": payload, "$inc": bson.M{"version": 1}}
+
+	err = o.transact(ctx, func(ctx mongo.SessionContext) error {
+		// the transaction sees no concurrent change, so the orders found are
+		// the ones the update expires
+		ids, err := o.orders.Distinct(ctx, "order_id", filter)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		resp, err := o.orders.UpdateMany(ctx, filter, update)
+		if err != nil {
+			return err
+		}
+		affected = resp.ModifiedCount
+
+		return o.record(ctx, variable.OrderEventExpired, bson.M{"order_id": bson.M{"$in": ids}})
+	})
+
+	return
+}
+
 func (o *OrderRepository) Cancel(ctx context.Context, req *pb.OrderCancelRequest) (res *pb.OperationResponse, err error) {
 	isAffected := true
-	updatedTime := time.Now().UTC().Unix()
+	updatedTime := helper.Unix(o.clock)
 
 	filter := bson.M{
 		"status":            "pending",
 		"order_id":          req.OrderId,
 		"buyer.customer_id": req.UserId,
 	}
+	if req.ExpectedVersion > 0 {
+		filter["version"] = req.ExpectedVersion
+	}
 	payload := bson.M{"status": "cancel", "updated_at": updatedTime}
-	set := bson.M{"$set": payload}
-	resp, err := o.orders.UpdateOne(ctx, filter, set)
-	if resp.ModifiedCount < 1 {
-		isAffected = false
+	set := bson.M{"$set": payload, "$inc": bson.M{"version": 1}}
+	owner := bson.M{"order_id": req.OrderId, "buyer.customer_id": req.UserId}
+
+	err = o.transact(ctx, func(ctx mongo.SessionContext) error {
+		resp, err := o.orders.UpdateOne(ctx, filter, set)
+		if err != nil {
+			return err
+		}
+
+		if resp.ModifiedCount < 1 {
+			isAffected = false
+
+			return o.checkVersion(ctx, owner, req.OrderId, req.ExpectedVersion)
+		}
+
+		return o.record(ctx, variable.OrderEventCancelled, owner)
+	})
+	if err != nil {
+		return
 	}
 
 	res = &pb.OperationResponse{IsAffected: isAffected}
@@ -89,46 +301,475 @@ func (o *OrderRepository) Cancel(ctx context.Context, req *pb.OrderCancelRequest
 	return
 }
 
-func (o *OrderRepository) SumIncome(ctx context.Context, req *pb.OrderSumIncomeRequest) (res int64, err error) {
+// checkVersion explains why a write guarded by expected did not match:
+// when the order identified by filter exists at another version it returns
+// a *domain.VersionConflictError, otherwise nil.
+func (o *OrderRepository) checkVersion(ctx context.Context, filter bson.M, orderId string, expected int64) error {
+	if expected <= 0 {
+		return nil
+	}
+
+	var current domain.Order
+	err := o.orders.FindOne(ctx, filter).Decode(&current)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if current.Version != expected {
+		return &domain.VersionConflictError{OrderId: orderId, Current: current.Version}
+	}
+
+	return nil
+}
+
+// timeRange matches times in [from, to), an unset bound being open. It
+// returns nil when both bounds are unset.
+func timeRange(from int64, to int64) bson.M {
+	if from == 0 && to == 0 {
+		return nil
+	}
+
+	r := bson.M{"$gte": from}
+	if to > 0 {
+		r["$lt"] = to
+	}
+
+	return r
+}
+
+// sumByCurrency runs pipeline, which must group amounts into total by
+// currency, and adds the totals multiplied by sign to sums.
+func (o *OrderRepository) sumByCurrency(ctx context.Context, pipeline []bson.M, sign int64, sums domain.MoneyTotals) (err error) {
+	cur, err := o.orders.Aggregate(ctx, pipeline)
+	if err != nil {
+		return
+	}
+
+	defer cur.Close(ctx)
+
+	var results []struct {
+		Currency string `bson:"_id"`
+		Total    int64  `bson:"total"`
+	}
+	if err = cur.All(ctx, &results); err != nil {
+		return
+	}
+
+	for _, result := range results {
+		if err = sums.Add(domain.NewMoney(result.Currency, sign*result.Total)); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// sumEntries adds to sums the amounts of the entries of the array field of
+// the settled orders, such as their refunds, dated in period by timeField
+// and multiplied by sign. Empty currency and userId match every order.
+func (o *OrderRepository) sumEntries(ctx context.Context, field string, timeField string, period bson.M, currency string, userId string, sign int64, sums domain.MoneyTotals) error {
+	match := bson.M{field + ".0": bson.M{"$exists": true}}
+	if period != nil {
+		match = bson.M{field + "." + timeField: period}
+	}
+	match["status"] = bson.M{"$in": variable.SettledStatuses}
+	if currency != "" {
+		match["currency"] = currency
+	}
+	if userId != "" {
+		match["buyer.customer_id"] = userId
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$unwind": "$" + field},
+	}
+	if period != nil {
+		pipeline = append(pipeline, bson.M{"$match": bson.M{field + "." + timeField: period}})
+	}
+	pipeline = append(pipeline, bson.M{"$group": bson.M{
+		"_id":   "$currency",
+		"total": bson.M{"$sum": "$" + field + ".amount"},
+	}})
+
+	return o.sumByCurrency(ctx, pipeline, sign, sums)
+}
+
+func (o *OrderRepository) SumIncome(ctx context.Context, req *pb.OrderSumIncomeRequest) (totals []domain.Money, err error) {
 	status := "settlement"
 	if req.Status != "" {
 		status = req.Status
 	}
 
+	settled := status == variable.PaymentStatusSettlement
+	match := bson.M{"status": status}
+	period := timeRange(req.From, req.To)
+	if settled {
+		// refunded orders still count as income of the period they settled
+		// in, and orders with receipts count what they received
+		match["status"] = bson.M{"$in": variable.SettledStatuses}
+		match["receipts.0"] = bson.M{"$exists": false}
+		if period != nil {
+			match["settlement_time"] = period
+		}
+	} else if period != nil {
+		match["created_at"] = period
+	}
+	if req.Currency != "" {
+		match["currency"] = req.Currency
+	}
+	if req.UserId != "" {
+		match["buyer.customer_id"] = req.UserId
+	}
+
+	amount := "$payment.gross_amount"
+	if status == variable.PaymentStatusPartiallyPaid {
+		// partially paid orders count what they received so far
+		amount = "$paid_total"
+	}
+
 	pipeline := []bson.M{
 		{
-			"$match": bson.M{
-				"status": status,
-			},
+			"$match": match,
 		},
 		{
 			"$group": bson.M{
-				"_id": nil,
+				"_id": "$currency",
 				"total": bson.M{
-					"$sum": "$payment.gross_amount",
+					"$sum": amount,
 				},
 			},
 		},
 	}
 
-	cur, err := o.orders.Aggregate(ctx, pipeline)
+	sums := domain.MoneyTotals{}
+	if err = o.sumByCurrency(ctx, pipeline, 1, sums); err != nil {
+		return
+	}
+
+	if settled {
+		// money is income in the period it was received in, and refunds
+		// are deducted in the period they were issued in. What orders not
+		// paid in full received is left to their own status.
+		if err = o.sumEntries(ctx, "receipts", "received_at", period, req.Currency, req.UserId, 1, sums); err != nil {
+			return
+		}
+		if err = o.sumEntries(ctx, "refunds", "created_at", period, req.Currency, req.UserId, -1, sums); err != nil {
+			return
+		}
+	}
+
+	if len(sums) == 0 {
+		err = mongo.ErrNoDocuments
+		return
+	}
+
+	totals = sums.List()
+
+	return
+}
+
+// refundError explains why refund cannot be recorded on order, or returns
+// nil when it can.
+func refundError(order domain.Order, refund *domain.OrderRefund, expectedVersion int64) error {
+	if expectedVersion > 0 && order.Version != expectedVersion {
+		return &domain.VersionConflictError{OrderId: order.OrderId, Current: order.Version}
+	}
+
+	if order.Status != variable.PaymentStatusSettlement && order.Status != variable.PaymentStatusPartiallyRefunded {
+		return domain.ErrNotRefundable
+	}
+
+	for _, previous := range order.Refunds {
+		if refund.Reference != "" && previous.Reference == refund.Reference {
+			return domain.ErrDuplicateRefund
+		}
+	}
+
+	refunded, err := domain.NewMoney(order.Currency, order.RefundedTotal).Add(domain.NewMoney(order.Currency, refund.Amount))
+	if err != nil {
+		return err
+	}
+	cmp, err := refunded.Cmp(domain.NewMoney(order.Currency, order.AmountPaid()))
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		return domain.ErrRefundExceedsPaid
+	}
+
+	return nil
+}
+
+func refundDocument(refund *domain.OrderRefund) bson.D {
+	return bson.D{
+		{Key: "refund_id", Value: refund.RefundId},
+		{Key: "amount", Value: refund.Amount},
+		{Key: "reason", Value: refund.Reason},
+		{Key: "actor", Value: refund.Actor},
+		{Key: "reference", Value: refund.Reference},
+		{Key: "created_at", Value: refund.CreatedAt},
+	}
+}
+
+// Refund records the refund with a single conditional update, so concurrent
+// refunds can never give back more than was paid.
+func (o *OrderRepository) Refund(ctx context.Context, orderId string, refund *domain.OrderRefund, expectedVersion int64) (order *pb.Order, err error) {
+	refunded := bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$refunded_total", 0}}, refund.Amount}}
+	// mirrors domain.Order.AmountPaid
+	paid := bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{bson.M{"$size": bson.M{"$ifNull": bson.A{"$receipts", bson.A{}}}}, 0}},
+		"$paid_total",
+		"$payment.gross_amount",
+	}}
+
+	filter := bson.M{
+		"order_id": orderId,
+		"status": bson.M{"$in": bson.A{
+			variable.PaymentStatusSettlement,
+			variable.PaymentStatusPartiallyRefunded,
+		}},
+		"$expr": bson.M{"$lte": bson.A{refunded, paid}},
+	}
+	if refund.Reference != "" {
+		filter["refunds.reference"] = bson.M{"$ne": refund.Reference}
+	}
+	if expectedVersion > 0 {
+		filter["version"] = expectedVersion
+	}
+
+	// the update is a pipeline so the status can depend on the new total;
+	// $literal keeps user input starting with $ from reading as a field path
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"refunded_total": refunded,
+			"refunds": bson.M{"$concatArrays": bson.A{
+				bson.M{"$ifNull": bson.A{"$refunds", bson.A{}}},
+				bson.A{bson.M{"$literal": refundDocument(refund)}},
+			}},
+			"status_reason": bson.M{"$literal": refund.Reason},
+			"updated_at":    refund.CreatedAt,
+			"version":       bson.M{"$add": bson.A{"$version", 1}},
+		}},
+		bson.M{"$set": bson.M{
+			"status": bson.M{"$cond": bson.A{
+				bson.M{"$gte": bson.A{"$refunded_total", paid}},
+				variable.PaymentStatusRefunded,
+				variable.PaymentStatusPartiallyRefunded,
+			}},
+		}},
+	}
+
+	err = o.transact(ctx, func(ctx mongo.SessionContext) error {
+		order = nil
+
+		var updated domain.Order
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err := o.orders.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+		if err == nil {
+			order = parseOrderResponse(updated)
+
+			return o.record(ctx, variable.OrderEventRefunded, bson.M{"order_id": orderId})
+		}
+		if err != mongo.ErrNoDocuments {
+			return err
+		}
+
+		var current domain.Order
+		err = o.orders.FindOne(ctx, bson.M{"order_id": orderId}).Decode(&current)
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = refundError(current, refund, expectedVersion)
+		if err == nil {
+			// the order changed between the update and the read
+			err = &domain.VersionConflictError{OrderId: orderId, Current: current.Version}
+		}
+
+		return err
+	})
+	if err != nil {
+		order = nil
+	}
+
+	return
+}
+
+// reportPeriods maps a report period to its MongoDB $dateToString format
+// and the equivalent Go layout, both rendering UTC times.
+var reportPeriods = map[string]struct{ mongo, layout string }{
+	variable.ReportPeriodDay:   {mongo: "%Y-%m-%d", layout: "2006-01-02"},
+	variable.ReportPeriodMonth: {mongo: "%Y-%m", layout: "2006-01"},
+	variable.ReportPeriodYear:  {mongo: "%Y", layout: "2006"},
+}
+
+func (o *OrderRepository) TaxReport(ctx context.Context, req *pb.OrderTaxReportRequest) (rows []*pb.OrderTaxReportRow, err error) {
+	period := bson.M{"$gte": req.From}
+	if req.To > 0 {
+		period["$lt"] = req.To
+	}
+
+	cur, err := o.orders.Find(ctx, bson.M{
+		"status": bson.M{"$in": variable.SettledStatuses},
+		"$or": bson.A{
+			bson.M{"settlement_time": period},
+			bson.M{"refunds.created_at": period},
+		},
+	})
 	if err != nil {
 		return
 	}
 
 	defer cur.Close(ctx)
 
-	var results []struct {
-		Total int64 `bson:"total"`
+	var orders []domain.Order
+	if err = cur.All(ctx, &orders); err != nil {
+		return
 	}
-	cur.All(ctx, &results)
 
-	if len(results) == 0 {
-		err = mongo.ErrNoDocuments
+	return taxReport(orders, req)
+}
+
+// taxReport sums the taxes the settled orders collected per period of their
+// settlement time, and the taxes their refunds gave back as negative refund
+// rows per period of the refund. A refund gives back the share of every tax
+// that it is of the order total, the overpaid surplus being refunded first
+// without any.
+func taxReport(orders []domain.Order, req *pb.OrderTaxReportRequest) (rows []*pb.OrderTaxReportRow, err error) {
+	type key struct {
+		period   string
+		currency string
+		name     string
+		rate     int64
+		refunds  bool
+	}
+
+	index := map[key]*pb.OrderTaxReportRow{}
+	counted := map[key]map[string]bool{}
+	add := func(order *domain.Order, at int64, tax domain.OrderTax, taxable domain.Money, amount domain.Money, refunds bool) error {
+		k := key{
+			period:   time.Unix(at, 0).UTC().Format(reportPeriods[req.Period].layout),
+			currency: order.Currency,
+			name:     tax.Name,
+			rate:     tax.Rate,
+			refunds:  refunds,
+		}
+		row, ok := index[k]
+		if !ok {
+			row = &pb.OrderTaxReportRow{Period: k.period, Currency: k.currency, Name: k.name, Rate: k.rate, Refunds: refunds}
+			index[k] = row
+			counted[k] = map[string]bool{}
+			rows = append(rows, row)
+		}
+
+		sum, err := domain.NewMoney(row.Currency, row.Taxable).Add(taxable)
+		if err != nil {
+			return err
+		}
+		row.Taxable = sum.Amount
+		if sum, err = domain.NewMoney(row.Currency, row.Amount).Add(amount); err != nil {
+			return err
+		}
+		row.Amount = sum.Amount
+
+		if !counted[k][order.OrderId] {
+			counted[k][order.OrderId] = true
+			row.Orders++
+		}
+
+		return nil
+	}
+
+	for i := range orders {
+		order := &orders[i]
+		if !isSettled(order.Status) {
+			continue
+		}
+
+		if inRange(order.SettlementTime, req.From, req.To) {
+			for _, tax := range order.Taxes {
+				err = add(order, order.SettlementTime, tax, domain.NewMoney(order.Currency, tax.Taxable), domain.NewMoney(order.Currency, tax.Amount), false)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if order.Total <= 0 {
+			continue
+		}
+		surplus := order.AmountPaid() - order.Total
+		for _, refund := range order.Refunds {
+			owed := int64(0)
+			if surplus > 0 {
+				owed = refund.Amount
+				if owed > surplus {
+					owed = surplus
+				}
+				surplus -= owed
+			}
+
+			taxed := refund.Amount - owed
+			if taxed <= 0 || !inRange(refund.CreatedAt, req.From, req.To) {
+				continue
+			}
+			for _, tax := range order.Taxes {
+				taxable, err := domain.NewMoney(order.Currency, tax.Taxable).Mul(-taxed)
+				if err != nil {
+					return nil, err
+				}
+				amount, err := domain.NewMoney(order.Currency, tax.Amount).Mul(-taxed)
+				if err != nil {
+					return nil, err
+				}
+				taxable.Amount /= order.Total
+				amount.Amount /= order.Total
+				if err = add(order, refund.CreatedAt, tax, taxable, amount, true); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.Period != b.Period {
+			return a.Period < b.Period
+		}
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Rate != b.Rate {
+			return a.Rate < b.Rate
+		}
+
+		return !a.Refunds && b.Refunds
+	})
+
+	return
+}
+
+func (o *OrderRepository) StampCurrency(ctx context.Context, currency string) (affected int64, err error) {
+	filter := bson.M{"$or": []bson.M{
+		{"currency": bson.M{"$exists": false}},
+		{"currency": ""},
+	}}
+	resp, err := o.orders.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"currency": currency}})
+	if err != nil {
 		return
 	}
 
-	res = results[0].Total
+	affected = resp.ModifiedCount
 
 	return
 }
@@ -136,6 +777,7 @@ func (o *OrderRepository) SumIncome(ctx context.Context, req *pb.OrderSumIncomeR
 func (o *OrderRepository) FindAll(ctx context.Context, req *pb.OrderFindAllRequest) (result *pb.OrderFindAllResponse, err error) {
 	result = &pb.OrderFindAllResponse{}
 	s := req.Search
+	now := helper.Unix(o.clock)
 
 	status := bson.M{"status": req.Status}
 	if (req.Status == "none" || req.Status == "") && req.Status != "deleted" {
@@ -144,7 +786,7 @@ func (o *OrderRepository) FindAll(ctx context.Context, req *pb.OrderFindAllReque
 
 	expired := bson.M{}
 	if req.Status == "pending" {
-		expired = bson.M{"pay_exp": bson.M{"$gt": helper.GetTime(nil)}}
+		expired = bson.M{"pay_exp": bson.M{"$gt": now}}
 	}
 
 	if req.Status == "cancel" {
@@ -154,8 +796,8 @@ func (o *OrderRepository) FindAll(ctx context.Context, req *pb.OrderFindAllReque
 			},
 			{
 				"$and": []bson.M{
-					{"pay_exp": bson.M{"$lt": helper.GetTime(nil)}},
-					{"status": bson.M{"$ne": "settlement"}},
+					{"pay_exp": bson.M{"$lt": now}},
+					{"status": bson.M{"$nin": variable.SettledStatuses}},
 				},
 			},
 		}}
@@ -166,6 +808,14 @@ func (o *OrderRepository) FindAll(ctx context.Context, req *pb.OrderFindAllReque
 		userId = bson.M{"buyer.customer_id": req.UserId}
 	}
 
+	productId := bson.M{}
+	if req.ProductId != "" {
+		productId = bson.M{"$or": []bson.M{
+			{"items.product.product_id": req.ProductId},
+			{"product.product_id": req.ProductId},
+		}}
+	}
+
 	filter := bson.M{
 		"$or": []bson.M{
 			{
@@ -208,10 +858,19 @@ func (o *OrderRepository) FindAll(ctx context.Context, req *pb.OrderFindAllReque
 					},
 				},
 			},
+			{
+				"items.product.name": bson.M{
+					"$regex": primitive.Regex{
+						Pattern: s,
+						Options: "i",
+					},
+				},
+			},
 		},
 		"$and": []bson.M{
 			status,
 			userId,
+			productId,
 			expired,
 		},
 	}
@@ -244,7 +903,7 @@ func (o *OrderRepository) FindAll(ctx context.Context, req *pb.OrderFindAllReque
 				return nil, err
 			}
 
-			order := o.parseOrderResponse(each)
+			order := parseOrderResponse(each)
 
 			orders = append(orders, order)
 		}
@@ -293,80 +952,587 @@ func (o *OrderRepository) FindOne(ctx context.Context, req *pb.OrderFindOneReque
 	}
 
 	res = &pb.OrderFindOneResponse{
-		Payload: o.parseOrderResponse(order),
+		Payload: parseOrderResponse(order),
 	}
 
 	return
 }
 
+// paymentFilter matches the order paid under reference. Only settlements
+// are matched against the attempts the order no longer offers, the other
+// statuses apply to its active attempt.
+func paymentFilter(reference string, settle bool) bson.M {
+	if !settle {
+		return bson.M{"payment.order_id": reference}
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{"payment.order_id": reference},
+		bson.M{"attempts.reference": reference},
+	}}
+}
+
 func (o *OrderRepository) ChangeStatus(ctx context.Context, req *pb.OrderChangeStatus, updatedTime int64) (affected bool, err error) {
-	filter := bson.M{"payment.order_id": req.OrderId}
+	settle := req.Status == variable.PaymentStatusSettlement
+	filter := paymentFilter(req.OrderId, settle)
 	if req.Status == variable.PayementStatusExpire {
 		filter["status"] = bson.M{"$ne": variable.PayementStatusCancel}
 	}
-	data := bson.M{"status": req.Status, "updated_at": updatedTime}
-	if req.Status == "settlement" {
+	// a replayed settlement must not undo refunds
+	if settle {
+		filter["status"] = bson.M{"$nin": bson.A{variable.PaymentStatusPartiallyRefunded, variable.PaymentStatusRefunded}}
+	}
+	data := bson.M{"status": req.Status, "updated_at": updatedTime, "status_reason": req.Reason}
+	if settle {
 		data["settlement_time"] = req.SettlementTime
 	}
 
-	set := bson.M{"$set": data}
+	// skip writes that would change nothing, so they do not bump the version
+	filter["$nor"] = []bson.M{data}
+	if req.ExpectedVersion > 0 {
+		filter["version"] = req.ExpectedVersion
+	}
+
+	order := paymentFilter(req.OrderId, settle)
+
+	err = o.transact(ctx, func(ctx mongo.SessionContext) error {
+		payload := bson.M{}
+		for key, value := range data {
+			payload[key] = value
+		}
+
+		// the attempt paid becomes the payment of the order
+		if settle {
+			var current domain.Order
+			err := o.orders.FindOne(ctx, order).Decode(&current)
+			if err != nil && err != mongo.ErrNoDocuments {
+				return err
+			}
+
+			if attempts, paid := settleAttempt(current.Attempts, req.OrderId); paid != nil {
+				payload["attempts"] = attemptDocuments(attempts)
+				payload["payment"] = paymentDocument(paid.Payment())
+			}
+		}
+
+		set := bson.M{"$set": payload, "$inc": bson.M{"version": 1}}
+		resp, err := o.orders.UpdateOne(ctx, filter, set)
+		if err != nil {
+			return err
+		}
+
+		affected = resp.ModifiedCount > 0
+		if !affected {
+			return o.checkVersion(ctx, order, req.OrderId, req.ExpectedVersion)
+		}
+
+		return o.record(ctx, statusEvent(req.Status), order)
+	})
+
+	return
+}
+
+// FindByPaymentReference returns the order paid under reference, by any of
+// its attempts, or nil when there is none.
+func (o *OrderRepository) FindByPaymentReference(ctx context.Context, reference string) (res *pb.Order, err error) {
+	var order domain.Order
+	err = o.orders.FindOne(ctx, paymentFilter(reference, true)).Decode(&order)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+
+	return parseOrderResponse(order), nil
+}
+
+// vaFilter matches the orders with an attempt paid into the virtual account
+// vaNumber of bank, any bank when empty.
+func vaFilter(bank string, vaNumber string) bson.M {
+	payment, attempt := bson.M{"payment.va_number": vaNumber}, bson.M{"va_number": vaNumber}
+	if bank != "" {
+		payment["payment.bank"], attempt["bank"] = bank, bank
+	}
+
+	return bson.M{"$or": bson.A{payment, bson.M{"attempts": bson.M{"$elemMatch": attempt}}}}
+}
+
+func (o *OrderRepository) FindByVANumber(ctx context.Context, bank string, vaNumber string, at int64) (res *pb.Order, err error) {
+	filter := vaFilter(bank, vaNumber)
+	if at > 0 {
+		filter["created_at"] = bson.M{"$lte": at}
+	}
+
+	var order domain.Order
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "order_id", Value: -1}})
+	err = o.orders.FindOne(ctx, filter, opts).Decode(&order)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+
+	return parseOrderResponse(order), nil
+}
+
+func (o *OrderRepository) FindPaid(ctx context.Context, from int64, to int64) (orders []*pb.Order, err error) {
+	settled := bson.M{
+		"status":     bson.M{"$in": variable.SettledStatuses},
+		"receipts.0": bson.M{"$exists": false},
+	}
+	received := bson.M{"receipts.0": bson.M{"$exists": true}}
+	if period := timeRange(from, to); period != nil {
+		settled["settlement_time"] = period
+		received["receipts"] = bson.M{"$elemMatch": bson.M{"received_at": period}}
+	}
+	filter := bson.M{
+		"payment.gross_amount": bson.M{"$gt": 0},
+		"$or":                  bson.A{settled, received},
+	}
+
+	cur, err := o.orders.Find(ctx, filter, options.Find().SetSort(bson.M{"order_id": 1}))
+	if err != nil {
+		return
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var order domain.Order
+		if err = cur.Decode(&order); err != nil {
+			return nil, err
+		}
+		orders = append(orders, parseOrderResponse(order))
+	}
+
+	return orders, cur.Err()
+}
+
+// applyReceipt records receipt on order, settling it once its total is
+// covered, and returns the type of the event to record. It returns "" when
+// the receipt changes nothing, and fails when the paid total would overflow.
+func applyReceipt(order *domain.Order, receipt domain.OrderReceipt, policy domain.PaymentPolicy, updatedTime int64) (string, error) {
+	if order.Status == variable.PaymentStatusPartiallyRefunded || order.Status == variable.PaymentStatusRefunded {
+		return "", nil
+	}
+	for _, previous := range order.Receipts {
+		if previous.TransactionId == receipt.TransactionId {
+			return "", nil
+		}
+	}
+
+	total, paid := order.OrderTotal(), order.AmountPaid()
+	settled := isSettled(order.Status)
+	if receipt.Amount == 0 {
+		receipt.Amount = total - paid
+	}
+	if receipt.Amount < 0 || (receipt.Amount == 0 && settled) {
+		return "", nil
+	}
 
-	resp, err := o.orders.UpdateOne(ctx, filter, set)
+	paidTotal, err := domain.NewMoney(order.Currency, paid).Add(domain.NewMoney(order.Currency, receipt.Amount))
+	if err != nil {
+		return "", err
+	}
 
-	if resp.ModifiedCount > 0 {
+	if receipt.Amount > 0 {
+		receipts := append([]domain.OrderReceipt(nil), order.Receipts...)
+		// orders settled before receipts existed were paid in one go
+		if len(receipts) == 0 && paid > 0 {
+			receipts = append(receipts, domain.OrderReceipt{
+				TransactionId: order.Payment.OrderID,
+				Reference:     order.Payment.OrderID,
+				Amount:        paid,
+				ReceivedAt:    order.SettlementTime,
+			})
+		}
+		order.Receipts = append(receipts, receipt)
+		order.PaidTotal = paidTotal.Amount
+	}
+
+	previous := order.Status
+	order.UpdatedAt = updatedTime
+	order.StatusReason = receipt.Reason
+	order.Version++
+
+	if order.PaidTotal < total {
+		order.Status = variable.PaymentStatusPartiallyPaid
+		order.StatusReason = fmt.Sprintf("received %d of %d", order.PaidTotal, total)
+
+		return statusEvent(order.Status), nil
+	}
+
+	if !settled {
+		order.Status = variable.PaymentStatusSettlement
+		order.SettlementTime = receipt.ReceivedAt
+
+		if attempts, attempt := settleAttempt(order.Attempts, receipt.Reference); attempt != nil {
+			order.Attempts, order.Payment = attempts, attempt.Payment()
+		}
+	}
+
+	if surplus := order.PaidTotal - total; surplus > 0 {
+		disposition := policy.Overpayment
+		if disposition == "" {
+			disposition = variable.OverpaymentCredit
+		}
+		order.Overpayment = &domain.OrderOverpayment{Amount: surplus, Disposition: disposition}
+		order.StatusReason = fmt.Sprintf("overpaid by %d", surplus)
+	}
+
+	if order.Status == previous {
+		return variable.OrderEventPaymentReceived, nil
+	}
+
+	return statusEvent(order.Status), nil
+}
+
+// RecordPayment reads and writes the order in one transaction, so that
+// concurrent notifications are all counted.
+func (o *OrderRepository) RecordPayment(ctx context.Context, receipt *domain.OrderReceipt, policy domain.PaymentPolicy, updatedTime int64, expectedVersion int64) (order *pb.Order, affected bool, err error) {
+	err = o.transact(ctx, func(ctx mongo.SessionContext) error {
+		order, affected = nil, false
+
+		var current domain.Order
+		err := o.orders.FindOne(ctx, paymentFilter(receipt.Reference, true)).Decode(&current)
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if expectedVersion > 0 && current.Version != expectedVersion {
+			return &domain.VersionConflictError{OrderId: current.OrderId, Current: current.Version}
+		}
+
+		version := current.Version
+		event, err := applyReceipt(&current, *receipt, policy, updatedTime)
+		if err != nil {
+			return err
+		}
+		order = parseOrderResponse(current)
+		if event == "" {
+			return nil
+		}
+
+		set := bson.M{
+			"status":          current.Status,
+			"status_reason":   current.StatusReason,
+			"updated_at":      current.UpdatedAt,
+			"settlement_time": current.SettlementTime,
+			"version":         current.Version,
+			"receipts":        receiptDocuments(current.Receipts),
+			"paid_total":      current.PaidTotal,
+			"attempts":        attemptDocuments(current.Attempts),
+			"payment":         paymentDocument(current.Payment),
+		}
+		if surplus := current.Overpayment; surplus != nil {
+			set["overpayment"] = bson.D{
+				{Key: "amount", Value: surplus.Amount},
+				{Key: "disposition", Value: surplus.Disposition},
+			}
+		}
+
+		filter := bson.M{"order_id": current.OrderId, "version": version}
+		resp, err := o.orders.UpdateOne(ctx, filter, bson.M{"$set": set})
+		if err != nil {
+			return err
+		}
+		if resp.MatchedCount == 0 {
+			return &domain.VersionConflictError{OrderId: current.OrderId, Current: version}
+		}
 		affected = true
+
+		return o.record(ctx, event, bson.M{"order_id": current.OrderId})
+	})
+	if err != nil {
+		order, affected = nil, false
+	}
+
+	return
+}
+
+// settleAttempt marks the attempt of reference paid and the others
+// superseded. It returns the updated copy of attempts and the attempt
+// paid, which is nil when no attempt has reference.
+func settleAttempt(attempts []domain.PaymentAttempt, reference string) (settled []domain.PaymentAttempt, paid *domain.PaymentAttempt) {
+	settled = append([]domain.PaymentAttempt(nil), attempts...)
+	for i := range settled {
+		if settled[i].Reference == reference {
+			paid = &settled[i]
+		}
+	}
+	if paid == nil {
+		return attempts, nil
 	}
 
+	for i := range settled {
+		settled[i].Status = variable.PaymentAttemptSuperseded
+	}
+	paid.Status = variable.PaymentAttemptPaid
+
 	return
 }
 
-func (o *OrderRepository) Save(ctx context.Context, req *pb.OrderCreateRequest, createdTime int64) (err error) {
+// retryError explains why attempt cannot be opened on order, or returns nil
+// when it can.
+func retryError(order domain.Order, attempt *domain.PaymentAttempt, expectedVersion int64) error {
+	if expectedVersion > 0 && order.Version != expectedVersion {
+		return &domain.VersionConflictError{OrderId: order.OrderId, Current: order.Version}
+	}
+
+	if order.Status != variable.PaymentStatusPending && order.Status != variable.PayementStatusExpire {
+		return domain.ErrNotRetryable
+	}
+
+	// another retry opened the attempt since the caller read the order
+	for _, previous := range order.PaymentAttempts() {
+		if previous.Reference == attempt.Reference {
+			return &domain.VersionConflictError{OrderId: order.OrderId, Current: order.Version}
+		}
+	}
+
+	return nil
+}
+
+// retryAttempts returns the attempts of order with attempt opened, the
+// attempt it had open being superseded.
+func retryAttempts(order domain.Order, attempt domain.PaymentAttempt) (attempts []domain.PaymentAttempt) {
+	for _, previous := range order.PaymentAttempts() {
+		if previous.Status == variable.PaymentAttemptActive {
+			previous.Status = variable.PaymentAttemptSuperseded
+		}
+		attempts = append(attempts, previous)
+	}
+
+	attempt.Status = variable.PaymentAttemptActive
+
+	return append(attempts, attempt)
+}
+
+// RetryPayment opens attempt on the order, making it pending again until
+// attempt.ExpiresAt, and returns the updated order. The order is read and
+// written in one transaction, so concurrent retries cannot both succeed.
+func (o *OrderRepository) RetryPayment(ctx context.Context, orderId string, attempt *domain.PaymentAttempt, expectedVersion int64) (order *pb.Order, err error) {
+	err = o.transact(ctx, func(ctx mongo.SessionContext) error {
+		order = nil
+
+		var current domain.Order
+		err := o.orders.FindOne(ctx, bson.M{"order_id": orderId}).Decode(&current)
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = retryError(current, attempt, expectedVersion); err != nil {
+			return err
+		}
+
+		attempts := retryAttempts(current, *attempt)
+		update := bson.M{
+			"$set": bson.M{
+				"attempts":      attemptDocuments(attempts),
+				"payment":       paymentDocument(attempt.Payment()),
+				"pay_exp":       attempt.ExpiresAt,
+				"status":        variable.PaymentStatusPending,
+				"status_reason": "payment retried",
+				"updated_at":    attempt.CreatedAt,
+			},
+			"$inc": bson.M{"version": 1},
+		}
+
+		var updated domain.Order
+		filter := bson.M{"order_id": orderId, "version": current.Version}
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+		err = o.orders.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrVANumberTaken
+		}
+		if err == mongo.ErrNoDocuments {
+			return &domain.VersionConflictError{OrderId: orderId, Current: current.Version}
+		}
+		if err != nil {
+			return err
+		}
+		order = parseOrderResponse(updated)
+
+		return o.record(ctx, variable.OrderEventPaymentRetried, bson.M{"order_id": orderId})
+	})
+	if err != nil {
+		order = nil
+	}
+
+	return
+}
+
+func productDocument(product domain.OrderProduct) bson.D {
+	return bson.D{
+		{Key: "product_id", Value: product.ProductId},
+		{Key: "name", Value: product.Name},
+		{Key: "price", Value: product.Price},
+		{Key: "duration", Value: product.Duration},
+		{Key: "description", Value: product.Description},
+		{Key: "category", Value: product.Category},
+	}
+}
+
+func paymentDocument(payment domain.OrderPayment) bson.D {
+	return bson.D{
+		{Key: "payment_type", Value: payment.PaymentType},
+		{Key: "order_id", Value: payment.OrderID},
+		{Key: "bank", Value: payment.Bank},
+		{Key: "va_number", Value: payment.VaNumber},
+		{Key: "gross_amount", Value: payment.GrossAmount},
+		{Key: "qr_string", Value: payment.QrString},
+	}
+}
+
+func attemptDocuments(attempts []domain.PaymentAttempt) bson.A {
+	documents := bson.A{}
+	for _, attempt := range attempts {
+		documents = append(documents, bson.D{
+			{Key: "reference", Value: attempt.Reference},
+			{Key: "payment_type", Value: attempt.PaymentType},
+			{Key: "bank", Value: attempt.Bank},
+			{Key: "va_number", Value: attempt.VaNumber},
+			{Key: "qr_string", Value: attempt.QrString},
+			{Key: "amount", Value: attempt.Amount},
+			{Key: "expires_at", Value: attempt.ExpiresAt},
+			{Key: "status", Value: attempt.Status},
+			{Key: "created_at", Value: attempt.CreatedAt},
+		})
+	}
+
+	return documents
+}
+
+func receiptDocuments(receipts []domain.OrderReceipt) bson.A {
+	documents := bson.A{}
+	for _, receipt := range receipts {
+		documents = append(documents, bson.D{
+			{Key: "transaction_id", Value: receipt.TransactionId},
+			{Key: "reference", Value: receipt.Reference},
+			{Key: "amount", Value: receipt.Amount},
+			{Key: "reason", Value: receipt.Reason},
+			{Key: "received_at", Value: receipt.ReceivedAt},
+			{Key: "fee", Value: receipt.Fee},
+		})
+	}
+
+	return documents
+}
+
+func (o *OrderRepository) Save(ctx context.Context, order *domain.Order) (err error) {
 	buyer := bson.D{
-		{Key: "customer_id", Value: req.Buyer.CustomerId},
-		{Key: "name", Value: req.Buyer.Name},
-		{Key: "user", Value: req.Buyer.User},
+		{Key: "customer_id", Value: order.Buyer.CustomerId},
+		{Key: "name", Value: order.Buyer.Name},
+		{Key: "user", Value: order.Buyer.User},
+		{Key: "jurisdiction", Value: order.Buyer.Jurisdiction},
+		{Key: "email", Value: order.Buyer.Email},
+		{Key: "phone", Value: order.Buyer.Phone},
+		{Key: "locale", Value: order.Buyer.Locale},
 	}
 
-	product := bson.D{
-		{Key: "product_id", Value: req.Product.ProductId},
-		{Key: "name", Value: req.Product.Name},
-		{Key: "price", Value: req.Product.Price},
-		{Key: "duration", Value: req.Product.Duration},
-		{Key: "description", Value: req.Product.Description},
+	items := bson.A{}
+	for _, line := range order.Items {
+		items = append(items, bson.D{
+			{Key: "product", Value: productDocument(line.Product)},
+			{Key: "quantity", Value: line.Quantity},
+			{Key: "unit_price", Value: line.UnitPrice},
+			{Key: "line_total", Value: line.LineTotal},
+			{Key: "discount", Value: line.Discount},
+		})
 	}
 
+	discounts := bson.A{}
+	for _, discount := range order.Discounts {
+		discounts = append(discounts, bson.D{
+			{Key: "code", Value: discount.Code},
+			{Key: "description", Value: discount.Description},
+			{Key: "amount", Value: discount.Amount},
+		})
+	}
+
+	taxes := bson.A{}
+	for _, tax := range order.Taxes {
+		taxes = append(taxes, bson.D{
+			{Key: "name", Value: tax.Name},
+			{Key: "rate", Value: tax.Rate},
+			{Key: "taxable", Value: tax.Taxable},
+			{Key: "amount", Value: tax.Amount},
+		})
+	}
+
+	hasPayment := order.Payment != (domain.OrderPayment{})
 	payment := bson.D{}
-	if req.Payment != nil {
-		payment = bson.D{
-			{Key: "payment_type", Value: req.Payment.PaymentType},
-			{Key: "order_id", Value: req.Payment.OrderId},
-			{Key: "bank", Value: req.Payment.Bank},
-			{Key: "va_number", Value: req.Payment.VaNumber},
-			{Key: "gross_amount", Value: req.Payment.GrossAmount},
-		}
+	if hasPayment {
+		payment = paymentDocument(order.Payment)
 	}
 
 	data := bson.D{
-		{Key: "order_id", Value: req.Payment.OrderId},
+		{Key: "order_id", Value: order.OrderId},
 		{Key: "buyer", Value: buyer},
-		{Key: "product", Value: product},
+		{Key: "product", Value: productDocument(order.Product)},
+		{Key: "items", Value: items},
+		{Key: "subtotal", Value: order.Subtotal},
+		{Key: "discounts", Value: discounts},
+		{Key: "taxes", Value: taxes},
+		{Key: "tax_inclusive", Value: order.TaxInclusive},
+		{Key: "tax_total", Value: order.TaxTotal},
+		{Key: "total", Value: order.Total},
 		{Key: "payment", Value: payment},
-		{Key: "status", Value: "pending"},
-		{Key: "created_at", Value: createdTime},
-		{Key: "trx_time", Value: req.TrxTime},
-		{Key: "pay_exp", Value: req.PayExp},
+		{Key: "status", Value: order.Status},
+		{Key: "created_at", Value: order.CreatedAt},
+		{Key: "trx_time", Value: order.TrxTime},
+		{Key: "pay_exp", Value: order.PayExp},
+		{Key: "version", Value: order.Version},
+		{Key: "currency", Value: order.Currency},
+		{Key: "refunds", Value: bson.A{}},
+		{Key: "refunded_total", Value: order.RefundedTotal},
+		{Key: "merchant_id", Value: order.MerchantId},
+		{Key: "attempts", Value: attemptDocuments(order.Attempts)},
+		{Key: "receipts", Value: receiptDocuments(order.Receipts)},
+		{Key: "paid_total", Value: order.PaidTotal},
+	}
+	if change := order.PlanChange; change != nil {
+		data = append(data, bson.E{Key: "plan_change", Value: bson.D{
+			{Key: "from_product_id", Value: change.FromProductId},
+			{Key: "unused_value", Value: change.UnusedValue},
+			{Key: "credit", Value: change.Credit},
+		}})
 	}
 
 	filteredData := bson.D{}
 	for _, x := range data {
-		if x.Key == "payment" && req.Payment == nil {
+		if x.Key == "payment" && !hasPayment {
 			continue
 		}
 
 		filteredData = append(filteredData, x)
 	}
 
-	_, err = o.orders.InsertOne(ctx, filteredData)
+	err = o.transact(ctx, func(ctx mongo.SessionContext) error {
+		if _, err := o.orders.InsertOne(ctx, filteredData); err != nil {
+			// the only unique index of orders is the one of open virtual
+			// account numbers
+			if mongo.IsDuplicateKeyError(err) {
+				return domain.ErrVANumberTaken
+			}
+			return err
+		}
+
+		event, err := newOutboxEvent(variable.OrderEventCreated, *order, helper.Unix(o.clock))
+		if err != nil {
+			return err
+		}
+		_, err = o.outbox.InsertOne(ctx, event)
+
+		return err
+	})
 
 	return
 }
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"sort"
+	"sync"
+)
+
+// OutboxMemoryRepository is the in-memory outbox the memory repositories
+// add their events to while they hold the lock of the change the events
+// record. Dead letters are kept apart from the pending events.
+type OutboxMemoryRepository struct {
+	mu          sync.Mutex
+	events      []domain.OutboxEvent
+	deadLetters []domain.OutboxEvent
+}
+
+func NewOutboxMemoryRepository() *OutboxMemoryRepository {
+	return &OutboxMemoryRepository{}
+}
+
+// add writes events to the outbox.
+func (o *OutboxMemoryRepository) add(events ...domain.OutboxEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.events = append(o.events, events...)
+}
+
+func (o *OutboxMemoryRepository) Pending(ctx context.Context, limit int64) (events []domain.OutboxEvent, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	events = append([]domain.OutboxEvent{}, o.events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.CreatedAt != b.CreatedAt {
+			return a.CreatedAt < b.CreatedAt
+		}
+		if a.OrderId != b.OrderId {
+			return a.OrderId < b.OrderId
+		}
+
+		return a.Sequence < b.Sequence
+	})
+
+	if limit > 0 && int64(len(events)) > limit {
+		events = events[:limit]
+	}
+
+	return
+}
+
+// remove deletes the event from the outbox. The caller holds o.mu.
+func (o *OutboxMemoryRepository) remove(eventId string) {
+	for i := range o.events {
+		if o.events[i].EventId == eventId {
+			o.events = append(o.events[:i], o.events[i+1:]...)
+			return
+		}
+	}
+}
+
+func (o *OutboxMemoryRepository) Published(ctx context.Context, eventId string) (err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.remove(eventId)
+
+	return
+}
+
+func (o *OutboxMemoryRepository) Failed(ctx context.Context, event domain.OutboxEvent) (err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.events {
+		stored := &o.events[i]
+		if stored.EventId != event.EventId {
+			continue
+		}
+
+		stored.Attempts = event.Attempts
+		stored.NextAttemptAt = event.NextAttemptAt
+		stored.LastError = event.LastError
+		stored.Delivered = append([]string{}, event.Delivered...)
+	}
+
+	return
+}
+
+func (o *OutboxMemoryRepository) DeadLetter(ctx context.Context, event domain.OutboxEvent) (err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.remove(event.EventId)
+	for _, dead := range o.deadLetters {
+		if dead.EventId == event.EventId {
+			return
+		}
+	}
+	o.deadLetters = append(o.deadLetters, event)
+
+	return
+}
+
+func (o *OutboxMemoryRepository) DeadLetters(ctx context.Context) (events []domain.OutboxEvent, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	events = append([]domain.OutboxEvent{}, o.deadLetters...)
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].DeadAt != events[j].DeadAt {
+			return events[i].DeadAt < events[j].DeadAt
+		}
+
+		return events[i].CreatedAt < events[j].CreatedAt
+	})
+
+	return
+}
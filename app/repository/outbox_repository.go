@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"order/helper"
+	"order/variable"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// eventJSON renders event payloads the way the HTTP gateway renders orders.
+var eventJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// newOutboxEvent returns the event of type eventType recording that order
+// changed at now.
+func newOutboxEvent(eventType string, order domain.Order, now int64) (event domain.OutboxEvent, err error) {
+	payload, err := eventJSON.Marshal(parseOrderResponse(order))
+	if err != nil {
+		return
+	}
+
+	event = domain.OutboxEvent{
+		EventId:   helper.NewID(),
+		Type:      eventType,
+		OrderId:   order.OrderId,
+		Sequence:  order.Version,
+		CreatedAt: now,
+		Payload:   payload,
+	}
+
+	return
+}
+
+// statusEvent returns the type of the event recording that an order moved
+// to status.
+func statusEvent(status string) string {
+	switch status {
+	case variable.PaymentStatusSettlement:
+		return variable.OrderEventSettled
+	case variable.PayementStatusCancel:
+		return variable.OrderEventCancelled
+	case variable.PayementStatusExpire:
+		return variable.OrderEventExpired
+	case variable.PaymentStatusPartiallyRefunded, variable.PaymentStatusRefunded:
+		return variable.OrderEventRefunded
+	case variable.PaymentStatusPartiallyPaid:
+		return variable.OrderEventPartiallyPaid
+	}
+
+	return variable.OrderEventStatusChanged
+}
+
+type OutboxRepository struct {
+	outbox      *mongo.Collection
+	deadLetters *mongo.Collection
+}
+
+// NewOutboxRepository reads the outbox the OrderRepository of db writes.
+func NewOutboxRepository(db *mongo.Database) domain.OutboxRepository {
+	return &OutboxRepository{
+		outbox:      db.Collection("outbox"),
+		deadLetters: db.Collection("outbox_dead_letters"),
+	}
+}
+
+// CreateOutboxIndexes creates the index reading the outbox in write order
+// and the unique event indexes making dead-lettering idempotent. It is safe
+// to call repeatedly.
+func CreateOutboxIndexes(ctx context.Context, db *mongo.Database) (err error) {
+	_, err = db.Collection("outbox").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "order_id", Value: 1}, {Key: "sequence", Value: 1}}},
+		{Keys: bson.D{{Key: "event_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+	if err != nil {
+		return
+	}
+
+	_, err = db.Collection("outbox_dead_letters").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	return
+}
+
+func (o *OutboxRepository) Pending(ctx context.Context, limit int64) (events []domain.OutboxEvent, err error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "order_id", Value: 1}, {Key: "sequence", Value: 1}}).
+		SetLimit(limit)
+
+	cur, err := o.outbox.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return
+	}
+
+	defer cur.Close(ctx)
+
+	events = []domain.OutboxEvent{}
+	err = cur.All(ctx, &events)
+
+	return
+}
+
+func (o *OutboxRepository) Published(ctx context.Context, eventId string) (err error) {
+	_, err = o.outbox.DeleteOne(ctx, bson.M{"event_id": eventId})
+
+	return
+}
+
+func (o *OutboxRepository) Failed(ctx context.Context, event domain.OutboxEvent) (err error) {
+	update := bson.M{"$set": bson.M{
+		"attempts":        event.Attempts,
+		"next_attempt_at": event.NextAttemptAt,
+		"last_error":      event.LastError,
+		"delivered":       event.Delivered,
+	}}
+	_, err = o.outbox.UpdateOne(ctx, bson.M{"event_id": event.EventId}, update)
+
+	return
+}
+
+// DeadLetter copies the event before removing it, so a crash in between
+// leaves it in both collections, and the copy made again collides with the
+// first on the unique index.
+func (o *OutboxRepository) DeadLetter(ctx context.Context, event domain.OutboxEvent) (err error) {
+	_, err = o.deadLetters.InsertOne(ctx, event)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return
+	}
+
+	_, err = o.outbox.DeleteOne(ctx, bson.M{"event_id": event.EventId})
+
+	return
+}
+
+func (o *OutboxRepository) DeadLetters(ctx context.Context) (events []domain.OutboxEvent, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "dead_at", Value: 1}, {Key: "created_at", Value: 1}})
+	cur, err := o.deadLetters.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return
+	}
+
+	defer cur.Close(ctx)
+
+	events = []domain.OutboxEvent{}
+	err = cur.All(ctx, &events)
+
+	return
+}
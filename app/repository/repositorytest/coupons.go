@@ -0,0 +1,135 @@
+package repositorytest
+
+import (
+	"context"
+	"order/domain"
+	"sync"
+	"testing"
+)
+
+// CouponFactory returns a new, empty coupon repository for a single subtest.
+type CouponFactory func(t *testing.T) domain.CouponRepository
+
+// RunCoupons runs the conformance suite of domain.CouponRepository against
+// the repositories built by factory.
+func RunCoupons(t *testing.T, factory CouponFactory) {
+	ctx := context.Background()
+
+	save := func(t *testing.T, repo domain.CouponRepository, coupon domain.Coupon) {
+		t.Helper()
+
+		if err := repo.Save(ctx, &coupon); err != nil {
+			t.Fatalf("Save(%s): %v", coupon.Code, err)
+		}
+	}
+
+	used := func(t *testing.T, repo domain.CouponRepository, code string) int64 {
+		t.Helper()
+
+		coupon, err := repo.FindOne(ctx, code)
+		if err != nil || coupon == nil {
+			t.Fatalf("FindOne(%s) = %v, %v", code, coupon, err)
+		}
+
+		return coupon.Used
+	}
+
+	t.Run("SaveAndFindOne", func(t *testing.T) {
+		repo := factory(t)
+		save(t, repo, domain.Coupon{Code: "LAUNCH", Type: "percentage", Amount: 20, ProductIds: []string{"p1"}, CreatedAt: 10})
+
+		got, err := repo.FindOne(ctx, "LAUNCH")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got == nil || got.Amount != 20 || len(got.ProductIds) != 1 || got.CreatedAt != 10 {
+			t.Errorf("FindOne = %+v", got)
+		}
+
+		if err := repo.Save(ctx, &domain.Coupon{Code: "LAUNCH", Type: "fixed", Amount: 5}); err != domain.ErrCouponExists {
+			t.Errorf("saving a taken code = %v; want ErrCouponExists", err)
+		}
+
+		if got, err := repo.FindOne(ctx, "MISSING"); got != nil || err != nil {
+			t.Errorf("FindOne(MISSING) = %v, %v; want nil, nil", got, err)
+		}
+	})
+
+	t.Run("GlobalCap", func(t *testing.T) {
+		repo := factory(t)
+		save(t, repo, domain.Coupon{Code: "FIRST10", Type: "fixed", Amount: 5, MaxUses: 10})
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		redeemed, exhausted := 0, 0
+		for i := 0; i < 25; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				err := repo.Redeem(ctx, "FIRST10", string(rune('a'+i)))
+
+				mu.Lock()
+				defer mu.Unlock()
+				switch err {
+				case nil:
+					redeemed++
+				case domain.ErrCouponExhausted:
+					exhausted++
+				default:
+					t.Error(err)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		if redeemed != 10 || exhausted != 15 {
+			t.Errorf("redeemed %d, exhausted %d; want 10 and 15", redeemed, exhausted)
+		}
+		if got := used(t, repo, "FIRST10"); got != 10 {
+			t.Errorf("used = %d; want 10", got)
+		}
+	})
+
+	t.Run("PerCustomerCap", func(t *testing.T) {
+		repo := factory(t)
+		save(t, repo, domain.Coupon{Code: "TWICE", Type: "fixed", Amount: 5, MaxUsesPerCustomer: 2})
+
+		for i := 0; i < 2; i++ {
+			if err := repo.Redeem(ctx, "TWICE", "c1"); err != nil {
+				t.Fatalf("redemption %d: %v", i, err)
+			}
+		}
+
+		if err := repo.Redeem(ctx, "TWICE", "c1"); err != domain.ErrCouponExhausted {
+			t.Errorf("third redemption = %v; want ErrCouponExhausted", err)
+		}
+		if err := repo.Redeem(ctx, "TWICE", "c2"); err != nil {
+			t.Errorf("another customer: %v", err)
+		}
+
+		// the rejected redemption must not count against the global usage
+		if got := used(t, repo, "TWICE"); got != 3 {
+			t.Errorf("used = %d; want 3", got)
+		}
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		repo := factory(t)
+		save(t, repo, domain.Coupon{Code: "ONCE", Type: "fixed", Amount: 5, MaxUses: 1, MaxUsesPerCustomer: 1})
+
+		if err := repo.Redeem(ctx, "ONCE", "c1"); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Release(ctx, "ONCE", "c1"); err != nil {
+			t.Fatal(err)
+		}
+		if got := used(t, repo, "ONCE"); got != 0 {
+			t.Errorf("used after release = %d; want 0", got)
+		}
+
+		if err := repo.Redeem(ctx, "ONCE", "c1"); err != nil {
+			t.Errorf("redeeming a released use: %v", err)
+		}
+	})
+}
@@ -0,0 +1,318 @@
+package repositorytest
+
+import (
+	"context"
+	"order/domain"
+	"order/variable"
+	"testing"
+)
+
+const day = 24 * 60 * 60
+
+// EntitlementFactory returns a new, empty entitlement repository for a single
+// subtest, and the outbox it writes the dunning events of renewals to.
+type EntitlementFactory func(t *testing.T) (domain.EntitlementRepository, domain.OutboxRepository)
+
+// RunEntitlements runs the conformance suite of domain.EntitlementRepository
+// against the repositories built by factory.
+func RunEntitlements(t *testing.T, factory EntitlementFactory) {
+	ctx := context.Background()
+	product := domain.OrderProduct{ProductId: "p1", Name: "Premium", Duration: 30}
+
+	grant := func(t *testing.T, repo domain.EntitlementRepository, orderId string, days, settledAt int64) {
+		t.Helper()
+
+		g := domain.EntitlementGrant{OrderId: orderId, Days: days, SettledAt: settledAt}
+		if err := repo.Grant(ctx, "c1", product, g, settledAt); err != nil {
+			t.Fatalf("Grant(%s): %v", orderId, err)
+		}
+	}
+
+	find := func(t *testing.T, repo domain.EntitlementRepository) *domain.Entitlement {
+		t.Helper()
+
+		entitlement, err := repo.FindOne(ctx, "c1", "p1")
+		if err != nil || entitlement == nil {
+			t.Fatalf("FindOne = %v, %v", entitlement, err)
+		}
+
+		return entitlement
+	}
+
+	t.Run("Stacking", func(t *testing.T) {
+		repo, _ := factory(t)
+		grant(t, repo, "o1", 30, 1000)
+		grant(t, repo, "o2", 30, 2000)
+
+		got := find(t, repo)
+		if got.StartsAt != 1000 || got.EndsAt != 1000+60*day || len(got.Grants) != 2 {
+			t.Errorf("stacked entitlement = %+v", got)
+		}
+		if got.Grants[1].StartsAt != 1000+30*day {
+			t.Errorf("second grant starts at %d; want %d", got.Grants[1].StartsAt, 1000+30*day)
+		}
+		if got.ProductName != "Premium" || !got.Active(2000) || got.Active(1000+60*day) {
+			t.Errorf("entitlement = %+v", got)
+		}
+	})
+
+	t.Run("LapseRestartsPeriod", func(t *testing.T) {
+		repo, _ := factory(t)
+		grant(t, repo, "o1", 1, 1000)
+		grant(t, repo, "o2", 1, 1000+5*day)
+
+		got := find(t, repo)
+		if got.StartsAt != 1000+5*day || got.EndsAt != 1000+6*day {
+			t.Errorf("entitlement after lapse = %+v", got)
+		}
+		if got.Active(1000 + 2*day) {
+			t.Error("entitlement active during the lapse")
+		}
+	})
+
+	t.Run("RegrantIsNoop", func(t *testing.T) {
+		repo, _ := factory(t)
+		grant(t, repo, "o1", 30, 1000)
+		grant(t, repo, "o1", 30, 5000)
+
+		got := find(t, repo)
+		if got.EndsAt != 1000+30*day || len(got.Grants) != 1 {
+			t.Errorf("entitlement after re-grant = %+v", got)
+		}
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		repo, _ := factory(t)
+		grant(t, repo, "o1", 30, 1000)
+		grant(t, repo, "o2", 10, 2000)
+
+		if err := repo.Revoke(ctx, "c1", "p1", "o1", 3000); err != nil {
+			t.Fatal(err)
+		}
+
+		// o1 was used from 1000 to 3000, and o2 stacked on it starts when
+		// o1 now ends
+		got := find(t, repo)
+		if got.EndsAt != 3000+10*day || len(got.Grants) != 1 || got.Grants[0].OrderId != "o2" || got.UpdatedAt != 3000 {
+			t.Errorf("entitlement after revoke = %+v", got)
+		}
+		if g := got.Grants[0]; g.StartsAt != 3000 || g.EndsAt != 3000+10*day {
+			t.Errorf("stacked grant after revoke = %+v; want it to start at 3000", g)
+		}
+
+		if err := repo.Revoke(ctx, "c1", "p1", "o1", 4000); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Revoke(ctx, "c2", "p1", "o1", 4000); err != nil {
+			t.Errorf("revoking a missing entitlement: %v", err)
+		}
+		if got := find(t, repo); got.EndsAt != 3000+10*day || got.UpdatedAt != 3000 {
+			t.Errorf("entitlement after revoking twice = %+v", got)
+		}
+	})
+
+	t.Run("RevokeKeepsAccessPaidAfterALapse", func(t *testing.T) {
+		repo, _ := factory(t)
+		grant(t, repo, "o1", 30, 0)
+		grant(t, repo, "o2", 30, 100*day)
+
+		// o1 lapsed long ago, and o2 did not stack on it
+		if err := repo.Revoke(ctx, "c1", "p1", "o1", 110*day); err != nil {
+			t.Fatal(err)
+		}
+
+		got := find(t, repo)
+		if got.StartsAt != 100*day || got.EndsAt != 130*day || !got.Active(110*day) {
+			t.Errorf("entitlement after revoking the lapsed grant = %+v; want access from day 100 to 130", got)
+		}
+		if len(got.Grants) != 1 || got.Grants[0].StartsAt != 100*day || got.Grants[0].EndsAt != 130*day {
+			t.Errorf("grants = %+v; want o2 untouched", got.Grants)
+		}
+	})
+
+	t.Run("RevokeUnusedGrant", func(t *testing.T) {
+		repo, _ := factory(t)
+		grant(t, repo, "o1", 30, 0)
+		grant(t, repo, "o2", 10, day)
+		grant(t, repo, "o3", 5, 2*day)
+
+		// o2 starts on day 30 and was not used yet, so all of it is taken
+		// back and o3 moves to where o2 started
+		if err := repo.Revoke(ctx, "c1", "p1", "o2", 5*day); err != nil {
+			t.Fatal(err)
+		}
+
+		got := find(t, repo)
+		if got.EndsAt != 35*day || len(got.Grants) != 2 {
+			t.Fatalf("entitlement after revoking an unused grant = %+v; want it to end on day 35", got)
+		}
+		if g := got.Grants[1]; g.OrderId != "o3" || g.StartsAt != 30*day || g.EndsAt != 35*day {
+			t.Errorf("o3 after revoke = %+v; want days 30 to 35", g)
+		}
+		if g := got.Grants[0]; g.StartsAt != 0 || g.EndsAt != 30*day {
+			t.Errorf("o1 after revoke = %+v; want it untouched", g)
+		}
+
+		// o1 revoked mid-way keeps the days used, and o3 follows them
+		if err := repo.Revoke(ctx, "c1", "p1", "o1", 6*day); err != nil {
+			t.Fatal(err)
+		}
+		got = find(t, repo)
+		if got.EndsAt != 11*day || got.Grants[0].StartsAt != 6*day {
+			t.Errorf("entitlement after revoking o1 on day 6 = %+v; want o3 moved to days 6 to 11", got)
+		}
+	})
+
+	t.Run("Terminate", func(t *testing.T) {
+		repo, _ := factory(t)
+		grant(t, repo, "o1", 30, 1000)
+
+		if err := repo.Terminate(ctx, "c1", "p1", "x1", 2000, 2000); err != nil {
+			t.Fatal(err)
+		}
+
+		got := find(t, repo)
+		if got.EndsAt != 2000 || len(got.Grants) != 2 || got.Grants[1].OrderId != "x1" || got.Grants[1].Days != 0 {
+			t.Errorf("entitlement after terminate = %+v", got)
+		}
+
+		// a repeated termination must not cut access bought since
+		grant(t, repo, "o2", 30, 3000)
+		if err := repo.Terminate(ctx, "c1", "p1", "x1", 2000, 4000); err != nil {
+			t.Fatal(err)
+		}
+		if got := find(t, repo); got.StartsAt != 3000 || got.EndsAt != 3000+30*day {
+			t.Errorf("entitlement after terminating twice = %+v", got)
+		}
+	})
+
+	t.Run("FindAll", func(t *testing.T) {
+		repo, _ := factory(t)
+		grant(t, repo, "o1", 30, 1000)
+		other := domain.OrderProduct{ProductId: "p0", Name: "Basic"}
+		if err := repo.Grant(ctx, "c1", other, domain.EntitlementGrant{OrderId: "o2", Days: 7, SettledAt: 1000}, 1000); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Grant(ctx, "c2", product, domain.EntitlementGrant{OrderId: "o3", Days: 7, SettledAt: 1000}, 1000); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := repo.FindAll(ctx, "c1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 || got[0].ProductId != "p0" || got[1].ProductId != "p1" {
+			t.Errorf("FindAll(c1) = %+v", got)
+		}
+
+		if got, err := repo.FindOne(ctx, "c3", "p1"); got != nil || err != nil {
+			t.Errorf("FindOne(c3) = %v, %v; want nil, nil", got, err)
+		}
+	})
+
+	t.Run("Renewals", func(t *testing.T) {
+		repo, outbox := factory(t)
+		grant(t, repo, "o1", 30, 1000)
+		end := int64(1000 + 30*day)
+
+		renewals := func(endsBefore, now int64) int {
+			t.Helper()
+
+			found, err := repo.FindRenewals(ctx, endsBefore, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return len(found)
+		}
+
+		if n := renewals(end-1, 1000); n != 0 {
+			t.Errorf("FindRenewals before the lead time = %d; want 0", n)
+		}
+		if n := renewals(end, 1000); n != 1 {
+			t.Fatalf("FindRenewals within the lead time = %d; want 1", n)
+		}
+
+		renewal := domain.EntitlementRenewal{OrderId: "r1", PeriodEnd: end, PayExp: end, Stage: variable.RenewalStageCreated}
+		if claimed, err := repo.ClaimRenewal(ctx, "c1", "p1", renewal); !claimed || err != nil {
+			t.Fatalf("ClaimRenewal = %v, %v; want claimed", claimed, err)
+		}
+
+		again := renewal
+		again.OrderId = "r2"
+		if claimed, err := repo.ClaimRenewal(ctx, "c1", "p1", again); claimed || err != nil {
+			t.Errorf("claiming the same period twice = %v, %v; want not claimed", claimed, err)
+		}
+
+		// dunning renewals are found whatever the lead time
+		if n := renewals(0, end+day); n != 1 {
+			t.Errorf("FindRenewals while dunning = %d; want 1", n)
+		}
+
+		next := renewal
+		next.Stage, next.Reminders = variable.RenewalStageReminder, 1
+		reminder := &domain.OutboxEvent{EventId: "e1", Type: variable.OrderEventRenewalReminder, OrderId: "r1", CreatedAt: 1000, Payload: []byte(`{}`)}
+		if updated, err := repo.UpdateRenewal(ctx, "c1", "p1", renewal, next, reminder); !updated || err != nil {
+			t.Fatalf("UpdateRenewal = %v, %v; want updated", updated, err)
+		}
+		stale := *reminder
+		stale.EventId = "e2"
+		if updated, err := repo.UpdateRenewal(ctx, "c1", "p1", renewal, next, &stale); updated || err != nil {
+			t.Errorf("UpdateRenewal from a stale renewal = %v, %v; want not updated", updated, err)
+		}
+
+		// only the update made writes its event
+		events, err := outbox.Pending(ctx, 10)
+		if err != nil || len(events) != 1 || events[0].EventId != "e1" || events[0].Type != variable.OrderEventRenewalReminder {
+			t.Errorf("outbox = %+v, %v; want the reminder only", events, err)
+		}
+
+		paid := next
+		paid.Stage = variable.RenewalStagePaid
+		if _, err := repo.UpdateRenewal(ctx, "c1", "p1", next, paid, nil); err != nil {
+			t.Fatal(err)
+		}
+		if n := renewals(end, 1000); n != 0 {
+			t.Errorf("FindRenewals after the period was renewed = %d; want 0", n)
+		}
+		if got := find(t, repo); got.Renewal == nil || got.Renewal.Stage != variable.RenewalStagePaid || got.Renewal.OrderId != "r1" {
+			t.Errorf("renewal = %+v", got.Renewal)
+		}
+	})
+
+	t.Run("RenewalOptOut", func(t *testing.T) {
+		repo, _ := factory(t)
+		grant(t, repo, "o1", 30, 1000)
+		end := int64(1000 + 30*day)
+
+		if found, err := repo.SetRenewalOptOut(ctx, "c1", "p1", true, 2000); !found || err != nil {
+			t.Fatalf("SetRenewalOptOut = %v, %v", found, err)
+		}
+		if found, err := repo.SetRenewalOptOut(ctx, "c2", "p1", true, 2000); found || err != nil {
+			t.Errorf("SetRenewalOptOut(c2) = %v, %v; want not found", found, err)
+		}
+
+		found, err := repo.FindRenewals(ctx, end, 1000)
+		if err != nil || len(found) != 0 {
+			t.Errorf("FindRenewals after opting out = %v, %v; want none", found, err)
+		}
+
+		renewal := domain.EntitlementRenewal{OrderId: "r1", PeriodEnd: end, Stage: variable.RenewalStageCreated}
+		if claimed, err := repo.ClaimRenewal(ctx, "c1", "p1", renewal); claimed || err != nil {
+			t.Errorf("ClaimRenewal after opting out = %v, %v; want not claimed", claimed, err)
+		}
+
+		if _, err := repo.SetRenewalOptOut(ctx, "c1", "p1", false, 3000); err != nil {
+			t.Fatal(err)
+		}
+		if claimed, err := repo.ClaimRenewal(ctx, "c1", "p1", renewal); !claimed || err != nil {
+			t.Fatalf("ClaimRenewal after opting back in = %v, %v", claimed, err)
+		}
+		if err := repo.ReleaseRenewal(ctx, "c1", "p1", "r1"); err != nil {
+			t.Fatal(err)
+		}
+		if got := find(t, repo); got.Renewal != nil || got.RenewalOptOut {
+			t.Errorf("entitlement after release = %+v", got)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order/domain"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// InvoiceFactory returns a new, empty invoice repository for a single
+// subtest.
+type InvoiceFactory func(t *testing.T) domain.InvoiceRepository
+
+// RunInvoices runs the conformance suite of domain.InvoiceRepository against
+// the repositories built by factory.
+func RunInvoices(t *testing.T, factory InvoiceFactory) {
+	ctx := context.Background()
+
+	builder := func(orderId string, year int64) func(sequence int64) (*domain.Invoice, error) {
+		return func(sequence int64) (*domain.Invoice, error) {
+			return &domain.Invoice{
+				OrderId:  orderId,
+				Number:   fmt.Sprintf("INV-%d-%06d", year, sequence),
+				Year:     year,
+				Sequence: sequence,
+				IssuedAt: 100,
+				HTML:     []byte("<html>" + orderId + "</html>"),
+				PDF:      []byte("%PDF-1.4 " + orderId),
+			}, nil
+		}
+	}
+
+	issue := func(t *testing.T, repo domain.InvoiceRepository, orderId string, year int64) *domain.Invoice {
+		t.Helper()
+
+		invoice, err := repo.Issue(ctx, orderId, year, builder(orderId, year))
+		if err != nil || invoice == nil {
+			t.Fatalf("Issue(%s) = %v, %v", orderId, invoice, err)
+		}
+
+		return invoice
+	}
+
+	t.Run("IssueOnce", func(t *testing.T) {
+		repo := factory(t)
+		first := issue(t, repo, "o1", 2024)
+		if first.Sequence != 1 || first.Number != "INV-2024-000001" {
+			t.Fatalf("first invoice = %+v", first)
+		}
+
+		again, err := repo.Issue(ctx, "o1", 2024, func(sequence int64) (*domain.Invoice, error) {
+			t.Error("built the invoice of an invoiced order")
+			return builder("o1", 2024)(sequence)
+		})
+		if err != nil || again.Number != first.Number || string(again.PDF) != string(first.PDF) {
+			t.Errorf("issuing again = %+v, %v; want the first invoice", again, err)
+		}
+
+		found, err := repo.FindOne(ctx, "o1")
+		if err != nil || found == nil || found.Number != first.Number || string(found.HTML) != "<html>o1</html>" {
+			t.Errorf("FindOne = %+v, %v", found, err)
+		}
+		if found, err := repo.FindOne(ctx, "o2"); found != nil || err != nil {
+			t.Errorf("FindOne(o2) = %v, %v; want nil, nil", found, err)
+		}
+	})
+
+	t.Run("SequencePerYear", func(t *testing.T) {
+		repo := factory(t)
+		issue(t, repo, "o1", 2024)
+		issue(t, repo, "o2", 2024)
+		if got := issue(t, repo, "o3", 2025); got.Sequence != 1 {
+			t.Errorf("first invoice of 2025 has sequence %d", got.Sequence)
+		}
+		if got := issue(t, repo, "o4", 2024); got.Sequence != 3 {
+			t.Errorf("third invoice of 2024 has sequence %d", got.Sequence)
+		}
+	})
+
+	t.Run("FailedBuildUsesNoNumber", func(t *testing.T) {
+		repo := factory(t)
+		issue(t, repo, "o1", 2024)
+
+		broken := errors.New("render failed")
+		_, err := repo.Issue(ctx, "o2", 2024, func(sequence int64) (*domain.Invoice, error) {
+			return nil, broken
+		})
+		if !errors.Is(err, broken) {
+			t.Fatalf("Issue = %v; want the build error", err)
+		}
+		if found, _ := repo.FindOne(ctx, "o2"); found != nil {
+			t.Errorf("saved a failed invoice: %+v", found)
+		}
+
+		if got := issue(t, repo, "o2", 2024); got.Sequence != 2 {
+			t.Errorf("invoice after a failure has sequence %d; want 2", got.Sequence)
+		}
+	})
+
+	t.Run("ConcurrentIssuesAreGapFree", func(t *testing.T) {
+		repo := factory(t)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		numbers := map[string]int64{}
+		for i := 0; i < 30; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				// every order is issued by two callers at once
+				orderId := fmt.Sprintf("o%d", i%15)
+				invoice, err := repo.Issue(ctx, orderId, 2024, builder(orderId, 2024))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				if sequence, ok := numbers[orderId]; ok && sequence != invoice.Sequence {
+					t.Errorf("order %s issued twice: %d and %d", orderId, sequence, invoice.Sequence)
+				}
+				numbers[orderId] = invoice.Sequence
+			}(i)
+		}
+		wg.Wait()
+
+		var sequences []int64
+		for _, sequence := range numbers {
+			sequences = append(sequences, sequence)
+		}
+		sort.Slice(sequences, func(i, j int) bool { return sequences[i] < sequences[j] })
+		for i, sequence := range sequences {
+			if sequence != int64(i+1) {
+				t.Fatalf("sequences = %v; want 1 to %d", sequences, len(sequences))
+			}
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"order/domain"
+	"reflect"
+	"testing"
+)
+
+// LedgerFactory returns a new, empty ledger repository for a single subtest.
+type LedgerFactory func(t *testing.T) domain.LedgerRepository
+
+// RunLedger runs the conformance suite of domain.LedgerRepository against the
+// repositories built by factory.
+func RunLedger(t *testing.T, factory LedgerFactory) {
+	ctx := context.Background()
+
+	journal := func(id string, currency string, postedAt int64, lines ...domain.JournalLine) *domain.Journal {
+		return &domain.Journal{JournalId: id, Kind: "receipt", OrderId: "1", Currency: currency, Lines: lines, PostedAt: postedAt, RecordedAt: postedAt}
+	}
+	debit := func(account string, amount int64) domain.JournalLine {
+		return domain.JournalLine{Account: account, Debit: amount}
+	}
+	credit := func(account string, amount int64) domain.JournalLine {
+		return domain.JournalLine{Account: account, Credit: amount}
+	}
+
+	t.Run("PostOnce", func(t *testing.T) {
+		repo := factory(t)
+
+		j := journal("receipt:1:t1", "IDR", 10, debit("assets:gateway_clearing", 1000), credit("liabilities:customer_deposits", 1000))
+		if posted, err := repo.Post(ctx, j); err != nil || !posted {
+			t.Fatalf("Post = %v, %v; want posted", posted, err)
+		}
+		if posted, err := repo.Post(ctx, j); err != nil || posted {
+			t.Errorf("Post of a posted journal = %v, %v; want not posted", posted, err)
+		}
+
+		for _, bad := range []*domain.Journal{
+			journal("bad:1", "IDR", 10, debit("assets:gateway_clearing", 1000), credit("revenue:sales", 900)),
+			journal("bad:2", "IDR", 10, debit("assets:gateway_clearing", 1000)),
+			journal("bad:3", "IDR", 10, debit("assets:gateway_clearing", 1000), debit("revenue:sales", -1000)),
+			journal("bad:4", "", 10, debit("assets:gateway_clearing", 1000), credit("revenue:sales", 1000)),
+		} {
+			if _, err := repo.Post(ctx, bad); !errors.Is(err, domain.ErrUnbalancedJournal) {
+				t.Errorf("Post(%s) = %v; want ErrUnbalancedJournal", bad.JournalId, err)
+			}
+		}
+
+		var ids []string
+		repo.Journals(ctx, 0, 0, func(j domain.Journal) error {
+			ids = append(ids, j.JournalId)
+			return nil
+		})
+		if !equal(ids, []string{"receipt:1:t1"}) {
+			t.Errorf("journals = %v; want the balanced one only", ids)
+		}
+	})
+
+	t.Run("Balances", func(t *testing.T) {
+		repo := factory(t)
+		for _, j := range []*domain.Journal{
+			journal("receipt:1:t1", "IDR", 10, debit("assets:gateway_clearing", 1100), credit("liabilities:customer_deposits", 1000), credit("liabilities:customer_credit", 100)),
+			journal("settlement:1", "IDR", 20, debit("liabilities:customer_deposits", 1000), credit("revenue:sales", 900), credit("liabilities:tax_payable", 100)),
+			journal("refund:1:r1", "IDR", 30, debit("revenue:refunds", 450), debit("liabilities:tax_payable", 50), credit("assets:gateway_clearing", 500)),
+			journal("receipt:2:t2", "USD", 20, debit("assets:gateway_clearing", 500), credit("liabilities:customer_deposits", 500)),
+		} {
+			if _, err := repo.Post(ctx, j); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		balances, err := repo.Balances(ctx, "liabilities", "IDR", 0, 0)
+		want := []domain.AccountBalance{
+			{Account: "liabilities:customer_credit", Currency: "IDR", Credit: 100, Balance: -100},
+			{Account: "liabilities:customer_deposits", Currency: "IDR", Debit: 1000, Credit: 1000},
+			{Account: "liabilities:tax_payable", Currency: "IDR", Debit: 50, Credit: 100, Balance: -50},
+		}
+		if err != nil || !reflect.DeepEqual(balances, want) {
+			t.Errorf("Balances(liabilities, IDR) = %+v, %v; want %+v", balances, err, want)
+		}
+
+		balances, err = repo.Balances(ctx, "assets:gateway_clearing", "", 10, 30)
+		want = []domain.AccountBalance{
+			{Account: "assets:gateway_clearing", Currency: "IDR", Debit: 1100, Balance: 1100},
+			{Account: "assets:gateway_clearing", Currency: "USD", Debit: 500, Balance: 500},
+		}
+		if err != nil || !reflect.DeepEqual(balances, want) {
+			t.Errorf("Balances(gateway clearing, from 10 to 30) = %+v, %v; want %+v", balances, err, want)
+		}
+
+		// an account does not cover the accounts it is only a prefix of
+		if balances, err := repo.Balances(ctx, "revenue:sale", "", 0, 0); err != nil || len(balances) != 0 {
+			t.Errorf("Balances(revenue:sale) = %+v, %v; want none", balances, err)
+		}
+
+		var ids []string
+		err = repo.Journals(ctx, 20, 0, func(j domain.Journal) error {
+			ids = append(ids, j.JournalId)
+			return nil
+		})
+		if err != nil || len(ids) != 3 || ids[2] != "refund:1:r1" {
+			t.Errorf("Journals(from 20) = %v, %v", ids, err)
+		}
+
+		stop := errors.New("stop")
+		calls := 0
+		if err := repo.Journals(ctx, 0, 0, func(domain.Journal) error { calls++; return stop }); err != stop || calls != 1 {
+			t.Errorf("Journals stopping at the first journal = %v after %d call(s)", err, calls)
+		}
+	})
+}
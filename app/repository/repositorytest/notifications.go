@@ -0,0 +1,125 @@
+package repositorytest
+
+import (
+	"context"
+	"order/domain"
+	"order/variable"
+	"sync"
+	"testing"
+)
+
+// NotificationFactory returns a new, empty notification repository for a
+// single subtest.
+type NotificationFactory func(t *testing.T) domain.NotificationRepository
+
+// RunNotifications runs the conformance suite of
+// domain.NotificationRepository against the repositories built by factory.
+func RunNotifications(t *testing.T, factory NotificationFactory) {
+	ctx := context.Background()
+
+	notification := func(updatedAt int64) *domain.Notification {
+		return &domain.Notification{
+			NotificationId: "e1:order_created:email",
+			EventId:        "e1",
+			OrderId:        "o1",
+			Template:       variable.NotificationOrderCreated,
+			Channel:        variable.NotificationChannelEmail,
+			Recipient:      "buyer@example.com",
+			CreatedAt:      updatedAt,
+			UpdatedAt:      updatedAt,
+		}
+	}
+
+	claim := func(t *testing.T, repo domain.NotificationRepository, updatedAt int64, staleBefore int64) bool {
+		t.Helper()
+
+		claimed, err := repo.Claim(ctx, notification(updatedAt), staleBefore)
+		if err != nil {
+			t.Fatalf("Claim: %v", err)
+		}
+
+		return claimed
+	}
+
+	complete := func(t *testing.T, repo domain.NotificationRepository, status string, at int64) {
+		t.Helper()
+
+		done := *notification(at)
+		done.Status = status
+		if status == variable.NotificationSent {
+			done.SentAt = at
+		}
+		if err := repo.Complete(ctx, done); err != nil {
+			t.Fatalf("Complete: %v", err)
+		}
+	}
+
+	t.Run("SentOnce", func(t *testing.T) {
+		repo := factory(t)
+		if !claim(t, repo, 100, 0) {
+			t.Fatal("first claim refused")
+		}
+		if claim(t, repo, 110, 0) {
+			t.Error("claimed a notification being sent")
+		}
+
+		complete(t, repo, variable.NotificationSent, 120)
+		if claim(t, repo, 1000, 900) {
+			t.Error("claimed a sent notification")
+		}
+	})
+
+	t.Run("FailedIsClaimedAgain", func(t *testing.T) {
+		repo := factory(t)
+		claim(t, repo, 100, 0)
+		complete(t, repo, variable.NotificationFailed, 110)
+
+		if !claim(t, repo, 120, 0) {
+			t.Fatal("failed notification not claimed again")
+		}
+		if claim(t, repo, 130, 0) {
+			t.Error("claimed the retry twice")
+		}
+	})
+
+	t.Run("StaleSendIsClaimedAgain", func(t *testing.T) {
+		repo := factory(t)
+		claim(t, repo, 100, 0)
+
+		if claim(t, repo, 150, 100) {
+			t.Error("claimed a send started at staleBefore")
+		}
+		if !claim(t, repo, 500, 101) {
+			t.Error("stale send not claimed again")
+		}
+	})
+
+	t.Run("ConcurrentClaims", func(t *testing.T) {
+		repo := factory(t)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		claimed := 0
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				ok, err := repo.Claim(ctx, notification(100), 0)
+				if err != nil {
+					t.Error(err)
+				}
+				if ok {
+					mu.Lock()
+					claimed++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if claimed != 1 {
+			t.Errorf("%d claims succeeded; want 1", claimed)
+		}
+	})
+}
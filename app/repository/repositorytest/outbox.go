@@ -0,0 +1,173 @@
+package repositorytest
+
+import (
+	"context"
+	"encoding/json"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"testing"
+	"time"
+)
+
+// OutboxFactory returns a new, empty order repository for a single subtest,
+// with the outbox it writes to.
+type OutboxFactory func(t *testing.T, clock helper.Clock) (domain.OrderRepository, domain.OutboxRepository)
+
+// RunOutbox runs the conformance suite of domain.OutboxRepository, and of
+// the events order repositories write to it, against the repositories built
+// by factory.
+func RunOutbox(t *testing.T, factory OutboxFactory) {
+	ctx := context.Background()
+	future := epoch.Add(time.Hour).Unix()
+	past := epoch.Add(-time.Hour).Unix()
+
+	pending := func(t *testing.T, outbox domain.OutboxRepository) []domain.OutboxEvent {
+		t.Helper()
+
+		events, err := outbox.Pending(ctx, 100)
+		if err != nil {
+			t.Fatalf("Pending: %v", err)
+		}
+
+		return events
+	}
+
+	t.Run("EventsFollowChanges", func(t *testing.T) {
+		clock := helper.NewFakeClock(epoch)
+		repo, outbox := factory(t, clock)
+		save(t, repo,
+			fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future},
+			fixture{id: "2", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future},
+		)
+
+		clock.Add(time.Minute)
+		settle := &pb.OrderChangeStatus{OrderId: "1", Status: variable.PaymentStatusSettlement, SettlementTime: 42}
+		if _, err := repo.ChangeStatus(ctx, settle, 20); err != nil {
+			t.Fatal(err)
+		}
+		// a change that changes nothing records nothing
+		if _, err := repo.ChangeStatus(ctx, settle, 20); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.Refund(ctx, "1", &domain.OrderRefund{RefundId: "r", Amount: 1000, CreatedAt: 50}, 0); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.Cancel(ctx, &pb.OrderCancelRequest{OrderId: "2", UserId: "c1"}); err != nil {
+			t.Fatal(err)
+		}
+
+		events := pending(t, outbox)
+		want := []struct {
+			eventType string
+			orderId   string
+			sequence  int64
+		}{
+			{variable.OrderEventCreated, "1", 1},
+			{variable.OrderEventCreated, "2", 1},
+			{variable.OrderEventSettled, "1", 2},
+			{variable.OrderEventRefunded, "1", 3},
+			{variable.OrderEventCancelled, "2", 2},
+		}
+		if len(events) != len(want) {
+			t.Fatalf("Pending = %+v; want %d events", events, len(want))
+		}
+		for i, w := range want {
+			got := events[i]
+			if got.Type != w.eventType || got.OrderId != w.orderId || got.Sequence != w.sequence || got.EventId == "" {
+				t.Errorf("event %d = %s %s #%d; want %s %s #%d", i, got.Type, got.OrderId, got.Sequence, w.eventType, w.orderId, w.sequence)
+			}
+		}
+		if events[2].CreatedAt != clock.Now().Unix() {
+			t.Errorf("event stamped %d; want the clock %d", events[2].CreatedAt, clock.Now().Unix())
+		}
+
+		var payload struct {
+			OrderId string `json:"order_id"`
+			Status  string `json:"status"`
+		}
+		if err := json.Unmarshal(events[3].Payload, &payload); err != nil || payload.OrderId != "1" || payload.Status != variable.PaymentStatusRefunded {
+			t.Errorf("refunded payload = %s, %v", events[3].Payload, err)
+		}
+	})
+
+	t.Run("Expire", func(t *testing.T) {
+		repo, outbox := factory(t, helper.NewFakeClock(epoch))
+		save(t, repo,
+			fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: past},
+			fixture{id: "2", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future},
+		)
+
+		if affected, err := repo.Expire(ctx, epoch.Unix()); affected != 1 || err != nil {
+			t.Fatalf("Expire = %d, %v; want 1", affected, err)
+		}
+
+		var expired []domain.OutboxEvent
+		for _, event := range pending(t, outbox) {
+			if event.Type == variable.OrderEventExpired {
+				expired = append(expired, event)
+			}
+		}
+		if len(expired) != 1 || expired[0].OrderId != "1" || expired[0].Sequence != 2 {
+			t.Errorf("expired events = %+v; want order 1 at version 2", expired)
+		}
+	})
+
+	t.Run("PublishAndRetry", func(t *testing.T) {
+		repo, outbox := factory(t, helper.NewFakeClock(epoch))
+		save(t, repo,
+			fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future},
+			fixture{id: "2", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future},
+		)
+
+		if events, err := outbox.Pending(ctx, 1); err != nil || len(events) != 1 || events[0].OrderId != "1" {
+			t.Fatalf("Pending(1) = %+v, %v", events, err)
+		}
+
+		events := pending(t, outbox)
+		failed := events[1]
+		failed.Attempts, failed.NextAttemptAt, failed.LastError = 1, 500, "broker down"
+		failed.Delivered = []string{"webhooks"}
+		if err := outbox.Failed(ctx, failed); err != nil {
+			t.Fatal(err)
+		}
+		if err := outbox.Published(ctx, events[0].EventId); err != nil {
+			t.Fatal(err)
+		}
+
+		events = pending(t, outbox)
+		if len(events) != 1 || events[0].EventId != failed.EventId {
+			t.Fatalf("Pending after publishing = %+v", events)
+		}
+		if got := events[0]; got.Attempts != 1 || got.NextAttemptAt != 500 || got.LastError != "broker down" ||
+			len(got.Delivered) != 1 || got.Delivered[0] != "webhooks" {
+			t.Errorf("failed event = %+v", got)
+		}
+	})
+
+	t.Run("DeadLetter", func(t *testing.T) {
+		repo, outbox := factory(t, helper.NewFakeClock(epoch))
+		save(t, repo, fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future})
+
+		event := pending(t, outbox)[0]
+		event.Attempts, event.LastError, event.DeadAt = 3, "rejected", 900
+		for i := 0; i < 2; i++ {
+			if err := outbox.DeadLetter(ctx, event); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if events := pending(t, outbox); len(events) != 0 {
+			t.Errorf("Pending after dead-lettering = %+v; want none", events)
+		}
+
+		dead, err := outbox.DeadLetters(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(dead) != 1 || dead[0].EventId != event.EventId || dead[0].DeadAt != 900 || dead[0].LastError != "rejected" {
+			t.Errorf("DeadLetters = %+v", dead)
+		}
+	})
+}
@@ -0,0 +1,1092 @@
+// Package repositorytest is the conformance suite shared by every
+// domain.OrderRepository implementation, so the in-memory and MongoDB
+// repositories cannot drift apart. The repository package wires it for both
+// in memory_test.go and mongo_test.go:
+//
+//	func TestMemoryOrders(t *testing.T) {
+//		repositorytest.Run(t, func(t *testing.T, clock helper.Clock) domain.OrderRepository {
+//			return repository.NewOrderMemoryRepository(clock, repository.NewOutboxMemoryRepository())
+//		})
+//	}
+//
+// Coupon, entitlement, outbox, webhook, notification, invoice and ledger
+// repositories are covered the same way by RunCoupons, RunEntitlements,
+// RunOutbox, RunWebhooks, RunNotifications, RunInvoices and RunLedger.
+//
+// Every subtest asks for a fresh, empty repository reading the time from the
+// given clock. MongoDB factories therefore hand out a dedicated database,
+// indexed like migrate does, and drop it with t.Cleanup; the MongoDB suites
+// only run when MONGO_TEST_URI names a replica set.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/proto"
+)
+
+// Factory returns a new, empty repository for a single subtest.
+type Factory func(t *testing.T, clock helper.Clock) domain.OrderRepository
+
+// epoch is the time every subtest starts at.
+var epoch = time.Date(2023, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+type fixture struct {
+	id       string
+	customer string
+	name     string
+	product  string
+	amount   int64
+	payExp   int64
+}
+
+func newOrder(f fixture) *domain.Order {
+	product := domain.OrderProduct{
+		ProductId:   "product-" + f.product,
+		Name:        f.product,
+		Price:       f.amount,
+		Duration:    30,
+		Description: f.product + " description",
+	}
+
+	return &domain.Order{
+		OrderId: f.id,
+		Buyer:   domain.OrderBuyer{CustomerId: f.customer, Name: f.name, User: f.name + "_user"},
+		Product: product,
+		Items: []domain.OrderItem{
+			{Product: product, Quantity: 1, UnitPrice: f.amount, LineTotal: f.amount},
+		},
+		Subtotal: f.amount,
+		Total:    f.amount,
+		Payment: domain.OrderPayment{
+			PaymentType: "bank_transfer",
+			OrderID:     f.id,
+			Bank:        "bri",
+			VaNumber:    "8800" + f.id,
+			GrossAmount: f.amount,
+		},
+		Status:    "pending",
+		CreatedAt: 10,
+		TrxTime:   100,
+		PayExp:    f.payExp,
+		Version:   1,
+		Currency:  "IDR",
+	}
+}
+
+func saveOrder(t *testing.T, repo domain.OrderRepository, order *domain.Order) {
+	t.Helper()
+
+	if err := repo.Save(context.Background(), order); err != nil {
+		t.Fatalf("Save(%s): %v", order.OrderId, err)
+	}
+}
+
+func save(t *testing.T, repo domain.OrderRepository, fixtures ...fixture) {
+	t.Helper()
+
+	for _, f := range fixtures {
+		saveOrder(t, repo, newOrder(f))
+	}
+}
+
+func findOne(t *testing.T, repo domain.OrderRepository, id string) *pb.Order {
+	t.Helper()
+
+	res, err := repo.FindOne(context.Background(), &pb.OrderFindOneRequest{OrderId: id})
+	if err != nil {
+		t.Fatalf("FindOne(%s): %v", id, err)
+	}
+
+	if res.IsEmpty {
+		t.Fatalf("FindOne(%s): order not found", id)
+	}
+
+	return res.Payload
+}
+
+func findAll(t *testing.T, repo domain.OrderRepository, req *pb.OrderFindAllRequest) *pb.OrderFindAllResponse {
+	t.Helper()
+
+	res, err := repo.FindAll(context.Background(), req)
+	if err != nil {
+		t.Fatalf("FindAll(%v): %v", req, err)
+	}
+
+	return res
+}
+
+func ids(res *pb.OrderFindAllResponse) (ids []string) {
+	for _, order := range res.GetPayload().GetOrders() {
+		ids = append(ids, order.OrderId)
+	}
+
+	return
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Run executes the whole conformance suite against repositories created by
+// newRepo.
+func Run(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	future := epoch.Add(time.Hour).Unix()
+	past := epoch.Add(-time.Hour).Unix()
+
+	start := func(t *testing.T) (domain.OrderRepository, *helper.FakeClock) {
+		clock := helper.NewFakeClock(epoch)
+
+		return newRepo(t, clock), clock
+	}
+
+	t.Run("SaveAndFindOne", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo, fixture{id: "1", customer: "c1", name: "Walisongo", product: "Lite", amount: 5000, payExp: future})
+
+		got := findOne(t, repo, "1")
+		if got.Status != "pending" || got.CreatedAt != 10 || got.TrxTime != 100 || got.PayExp != future {
+			t.Errorf("unexpected order state: %v", got)
+		}
+		if got.GetBuyer().GetCustomerId() != "c1" || got.GetBuyer().GetUser() != "Walisongo_user" {
+			t.Errorf("unexpected buyer: %v", got.Buyer)
+		}
+		if got.GetProduct().GetDescription() != "Lite description" || got.GetProduct().GetPrice() != 5000 {
+			t.Errorf("unexpected product: %v", got.Product)
+		}
+		if got.GetPayment().GetOrderId() != "1" || got.GetPayment().GetGrossAmount() != 5000 {
+			t.Errorf("unexpected payment: %v", got.Payment)
+		}
+	})
+
+	t.Run("LineItems", func(t *testing.T) {
+		repo, _ := start(t)
+
+		order := newOrder(fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 100, payExp: future})
+		addon := domain.OrderProduct{ProductId: "product-Storage", Name: "Extra Storage", Price: 25}
+		order.Items = append(order.Items, domain.OrderItem{Product: addon, Quantity: 2, UnitPrice: 25, LineTotal: 50})
+		order.Total = 150
+		saveOrder(t, repo, order)
+
+		// documents written before line items existed hold a single product
+		legacy := newOrder(fixture{id: "2", customer: "c1", name: "a", product: "Pro", amount: 300, payExp: future})
+		legacy.Items = nil
+		legacy.Total = 0
+		saveOrder(t, repo, legacy)
+
+		got := findOne(t, repo, "1")
+		if len(got.Items) != 2 || got.Items[1].GetProduct().GetName() != "Extra Storage" || got.Items[1].Quantity != 2 || got.Total != 150 {
+			t.Errorf("unexpected lines: items %v, total %d", got.Items, got.Total)
+		}
+		if got.GetProduct().GetName() != "Lite" {
+			t.Errorf("product = %v; want the first line", got.Product)
+		}
+
+		got = findOne(t, repo, "2")
+		if len(got.Items) != 1 || got.Items[0].Quantity != 1 || got.Items[0].LineTotal != 300 || got.Total != 300 {
+			t.Errorf("legacy order lines = %v, total %d; want one line of 300", got.Items, got.Total)
+		}
+
+		if got := ids(findAll(t, repo, &pb.OrderFindAllRequest{Search: "storage"})); !equal(got, []string{"1"}) {
+			t.Errorf("search on the second line = %v; want [1]", got)
+		}
+		if got := ids(findAll(t, repo, &pb.OrderFindAllRequest{ProductId: "product-Storage"})); !equal(got, []string{"1"}) {
+			t.Errorf("product filter on the second line = %v; want [1]", got)
+		}
+		if got := ids(findAll(t, repo, &pb.OrderFindAllRequest{ProductId: "product-Pro"})); !equal(got, []string{"2"}) {
+			t.Errorf("product filter on a legacy order = %v; want [2]", got)
+		}
+	})
+
+	t.Run("Discounts", func(t *testing.T) {
+		repo, _ := start(t)
+
+		order := newOrder(fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 100, payExp: future})
+		order.Discounts = []domain.OrderDiscount{{Code: "LAUNCH", Description: "launch week", Amount: 30}}
+		order.Total = 70
+		order.Payment.GrossAmount = 70
+		saveOrder(t, repo, order)
+
+		got := findOne(t, repo, "1")
+		if len(got.Discounts) != 1 || got.Discounts[0].Code != "LAUNCH" || got.Discounts[0].Amount != 30 {
+			t.Errorf("discounts = %v; want one LAUNCH discount of 30", got.Discounts)
+		}
+		if got.Subtotal != 100 || got.Total != 70 {
+			t.Errorf("subtotal %d, total %d; want 100 and 70", got.Subtotal, got.Total)
+		}
+	})
+
+	t.Run("FindOneMissing", func(t *testing.T) {
+		repo, _ := start(t)
+
+		res, err := repo.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: "missing"})
+		if err != nil || !res.IsEmpty {
+			t.Errorf("FindOne(missing) = %v, %v; want empty response", res, err)
+		}
+	})
+
+	t.Run("ChangeStatus", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo, fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 5000, payExp: future})
+
+		affected, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "1", Status: "settlement", SettlementTime: 42, Reason: "paid"}, 20)
+		if err != nil || !affected {
+			t.Fatalf("ChangeStatus = %v, %v; want affected", affected, err)
+		}
+
+		got := findOne(t, repo, "1")
+		if got.Status != "settlement" || got.SettlementTime != 42 || got.UpdatedAt != 20 || got.StatusReason != "paid" {
+			t.Errorf("unexpected order after settlement: %v", got)
+		}
+
+		affected, err = repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "1", Status: "settlement", SettlementTime: 42, Reason: "paid"}, 20)
+		if err != nil || affected {
+			t.Errorf("repeated ChangeStatus = %v, %v; want not affected", affected, err)
+		}
+
+		affected, err = repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "missing", Status: "settlement"}, 20)
+		if err != nil || affected {
+			t.Errorf("ChangeStatus(missing) = %v, %v; want not affected", affected, err)
+		}
+	})
+
+	t.Run("VANumberHeldByPendingOrders", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo, fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 5000, payExp: future})
+
+		// order 2 is given the virtual account of order 1
+		second := newOrder(fixture{id: "2", customer: "c2", name: "b", product: "Lite", amount: 5000, payExp: future})
+		second.Payment.VaNumber = "88001"
+		if err := repo.Save(ctx, second); !errors.Is(err, domain.ErrVANumberTaken) {
+			t.Fatalf("Save with a taken number = %v; want ErrVANumberTaken", err)
+		}
+		if res, err := repo.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: "2"}); err != nil || !res.IsEmpty {
+			t.Errorf("rejected order was saved: %v, %v", res, err)
+		}
+
+		// the same number at another bank is another account
+		other := newOrder(fixture{id: "3", customer: "c2", name: "b", product: "Lite", amount: 5000, payExp: future})
+		other.Payment.Bank, other.Payment.VaNumber = "bca", "88001"
+		saveOrder(t, repo, other)
+
+		if _, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "1", Status: "settlement", SettlementTime: 42}, 20); err != nil {
+			t.Fatal(err)
+		}
+		saveOrder(t, repo, second)
+	})
+
+	t.Run("RetryPayment", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo,
+			fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 5000, payExp: future},
+			fixture{id: "2", customer: "c2", name: "b", product: "Lite", amount: 5000, payExp: future},
+		)
+
+		retry := func(orderId string, attempt domain.PaymentAttempt, expectedVersion int64) (*pb.Order, error) {
+			return repo.RetryPayment(ctx, orderId, &attempt, expectedVersion)
+		}
+		attempts := func(order *pb.Order) (statuses []string) {
+			for _, a := range order.Attempts {
+				statuses = append(statuses, a.Reference+":"+a.Status)
+			}
+			return
+		}
+		attempt := domain.PaymentAttempt{Reference: "1-2", PaymentType: "bank_transfer", Bank: "bri", VaNumber: "88002", Amount: 5000, ExpiresAt: future + 60, CreatedAt: 30}
+
+		if _, err := retry("1", attempt, 0); !errors.Is(err, domain.ErrVANumberTaken) {
+			t.Fatalf("RetryPayment with the number of order 2 = %v; want ErrVANumberTaken", err)
+		}
+		var conflict *domain.VersionConflictError
+		if _, err := retry("1", attempt, 7); !errors.As(err, &conflict) || conflict.Current != 1 {
+			t.Fatalf("RetryPayment at a stale version = %v; want a conflict at version 1", err)
+		}
+
+		attempt.Bank, attempt.VaNumber = "bca", "99001"
+		order, err := retry("1", attempt, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := attempts(order); !equal(got, []string{"1:superseded", "1-2:active"}) {
+			t.Errorf("attempts after retry = %v", got)
+		}
+		if order.Payment.OrderId != "1-2" || order.Payment.Bank != "bca" || order.PayExp != future+60 || order.Version != 2 || order.Status != "pending" {
+			t.Errorf("order after retry = %v", order)
+		}
+		if !proto.Equal(order, findOne(t, repo, "1")) {
+			t.Errorf("RetryPayment returned %v, stored %v", order, findOne(t, repo, "1"))
+		}
+
+		// the number of the superseded attempt is free again
+		freed := newOrder(fixture{id: "3", customer: "c3", name: "c", product: "Lite", amount: 5000, payExp: future})
+		freed.Payment.VaNumber = "88001"
+		saveOrder(t, repo, freed)
+
+		if _, err := retry("1", attempt, 0); !errors.As(err, &conflict) || conflict.Current != 2 {
+			t.Errorf("RetryPayment opening the same attempt twice = %v; want a conflict at version 2", err)
+		}
+
+		// only settlements apply to superseded attempts
+		if affected, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "1", Status: "expire"}, 40); err != nil || affected {
+			t.Errorf("ChangeStatus(expire) of a superseded attempt = %v, %v; want not affected", affected, err)
+		}
+		if affected, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "1", Status: "settlement", SettlementTime: 42}, 40); err != nil || !affected {
+			t.Fatalf("ChangeStatus(settlement) of a superseded attempt = %v, %v; want affected", affected, err)
+		}
+
+		order, err = repo.FindByPaymentReference(ctx, "1-2")
+		if err != nil || order == nil {
+			t.Fatalf("FindByPaymentReference(1-2) = %v, %v", order, err)
+		}
+		if !equal(attempts(order), []string{"1:paid", "1-2:superseded"}) || order.Payment.OrderId != "1" || order.Status != "settlement" {
+			t.Errorf("order settled by its first attempt = %v", order)
+		}
+
+		if _, err := retry("1", domain.PaymentAttempt{Reference: "1-3", Amount: 5000, CreatedAt: 50}, 0); !errors.Is(err, domain.ErrNotRetryable) {
+			t.Errorf("RetryPayment of a settled order = %v; want ErrNotRetryable", err)
+		}
+		if order, err := retry("missing", attempt, 0); err != nil || order != nil {
+			t.Errorf("RetryPayment of a missing order = %v, %v; want nil", order, err)
+		}
+		if order, err := repo.FindByPaymentReference(ctx, "missing"); err != nil || order != nil {
+			t.Errorf("FindByPaymentReference(missing) = %v, %v; want nil", order, err)
+		}
+	})
+
+	t.Run("ExpireDoesNotOverrideCancel", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo, fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 5000, payExp: future})
+
+		if _, err := repo.Cancel(ctx, &pb.OrderCancelRequest{OrderId: "1", UserId: "c1"}); err != nil {
+			t.Fatal(err)
+		}
+
+		affected, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "1", Status: "expire"}, 20)
+		if err != nil || affected {
+			t.Errorf("ChangeStatus(expire) on cancelled order = %v, %v; want not affected", affected, err)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		repo, clock := start(t)
+		clock.Add(time.Minute)
+		save(t, repo, fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 5000, payExp: future})
+
+		res, err := repo.Cancel(ctx, &pb.OrderCancelRequest{OrderId: "1", UserId: "someone-else"})
+		if err != nil || res.IsAffected {
+			t.Errorf("Cancel by another customer = %v, %v; want not affected", res, err)
+		}
+
+		res, err = repo.Cancel(ctx, &pb.OrderCancelRequest{OrderId: "1", UserId: "c1"})
+		if err != nil || !res.IsAffected {
+			t.Errorf("Cancel by owner = %v, %v; want affected", res, err)
+		}
+
+		if got := findOne(t, repo, "1"); got.Status != "cancel" || got.UpdatedAt != clock.Now().Unix() {
+			t.Errorf("order after cancel = %v; want cancel stamped by the clock", got)
+		}
+
+		res, err = repo.Cancel(ctx, &pb.OrderCancelRequest{OrderId: "1", UserId: "c1"})
+		if err != nil || res.IsAffected {
+			t.Errorf("Cancel of cancelled order = %v, %v; want not affected", res, err)
+		}
+	})
+
+	t.Run("Versioning", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo, fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1, payExp: future})
+
+		if got := findOne(t, repo, "1"); got.Version != 1 {
+			t.Fatalf("version of a new order = %d; want 1", got.Version)
+		}
+
+		// unconditioned writes still bump the version
+		if _, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "1", Status: "pending", Reason: "touch"}, 20); err != nil {
+			t.Fatal(err)
+		}
+		if got := findOne(t, repo, "1"); got.Version != 2 {
+			t.Fatalf("version after unconditioned write = %d; want 2", got.Version)
+		}
+
+		var conflict *domain.VersionConflictError
+		_, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "1", Status: "settlement", ExpectedVersion: 1}, 30)
+		if !errors.As(err, &conflict) || conflict.Current != 2 {
+			t.Fatalf("stale ChangeStatus error = %v; want version conflict at 2", err)
+		}
+		if got := findOne(t, repo, "1"); got.Status != "pending" {
+			t.Errorf("stale write was applied: status %q", got.Status)
+		}
+
+		_, err = repo.Cancel(ctx, &pb.OrderCancelRequest{OrderId: "1", UserId: "c1", ExpectedVersion: 1})
+		if !errors.As(err, &conflict) || conflict.Current != 2 {
+			t.Fatalf("stale Cancel error = %v; want version conflict at 2", err)
+		}
+
+		res, err := repo.Cancel(ctx, &pb.OrderCancelRequest{OrderId: "1", UserId: "c1", ExpectedVersion: 2})
+		if err != nil || !res.IsAffected {
+			t.Fatalf("Cancel at current version = %v, %v; want affected", res, err)
+		}
+		if got := findOne(t, repo, "1"); got.Version != 3 {
+			t.Errorf("version after cancel = %d; want 3", got.Version)
+		}
+
+		affected, err := repo.Expire(ctx, epoch.Unix())
+		if err != nil || affected != 0 {
+			t.Errorf("Expire = %d, %v; want nothing to expire", affected, err)
+		}
+	})
+
+	t.Run("Expire", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo,
+			fixture{id: "overdue", customer: "c1", name: "a", product: "Lite", amount: 1, payExp: past},
+			fixture{id: "open", customer: "c1", name: "a", product: "Lite", amount: 1, payExp: future},
+			fixture{id: "no-expiry", customer: "c1", name: "a", product: "Lite", amount: 1},
+		)
+
+		affected, err := repo.Expire(ctx, epoch.Unix())
+		if err != nil || affected != 1 {
+			t.Fatalf("Expire = %d, %v; want 1", affected, err)
+		}
+
+		if got := findOne(t, repo, "overdue"); got.Status != "expire" {
+			t.Errorf("status of overdue order = %q; want expire", got.Status)
+		}
+		if got := findOne(t, repo, "open"); got.Status != "pending" {
+			t.Errorf("status of open order = %q; want pending", got.Status)
+		}
+		if got := findOne(t, repo, "no-expiry"); got.Status != "pending" {
+			t.Errorf("status of order without pay_exp = %q; want pending", got.Status)
+		}
+	})
+
+	t.Run("ExpirePartiallyPaid", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo, fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: past})
+		if _, _, err := repo.RecordPayment(ctx, &domain.OrderReceipt{TransactionId: "t1", Reference: "1", Amount: 400, ReceivedAt: 20}, domain.PaymentPolicy{}, 20, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		affected, err := repo.Expire(ctx, epoch.Unix())
+		if err != nil || affected != 1 {
+			t.Fatalf("Expire = %d, %v; want the partially paid order expired", affected, err)
+		}
+		if got := findOne(t, repo, "1"); got.Status != "expire" || got.PaidTotal != 400 {
+			t.Errorf("expired partially paid order = %v; want expire keeping the 400 received", got)
+		}
+
+		// its virtual account number is free again
+		reused := newOrder(fixture{id: "2", customer: "c2", name: "b", product: "Lite", amount: 500, payExp: future})
+		reused.Payment.VaNumber = "88001"
+		saveOrder(t, repo, reused)
+	})
+
+	t.Run("FindAllStatusFilters", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo,
+			fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1, payExp: future},
+			fixture{id: "2", customer: "c1", name: "a", product: "Lite", amount: 1, payExp: past},
+			fixture{id: "3", customer: "c2", name: "b", product: "Pro", amount: 1, payExp: future},
+			fixture{id: "4", customer: "c2", name: "b", product: "Pro", amount: 1, payExp: past},
+		)
+
+		if _, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "3", Status: "settlement"}, 20); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "4", Status: "settlement"}, 20); err != nil {
+			t.Fatal(err)
+		}
+
+		cases := []struct {
+			req  *pb.OrderFindAllRequest
+			want []string
+		}{
+			{&pb.OrderFindAllRequest{}, []string{"1", "2", "3", "4"}},
+			{&pb.OrderFindAllRequest{Status: "none"}, []string{"1", "2", "3", "4"}},
+			// overdue pending orders are no longer pending...
+			{&pb.OrderFindAllRequest{Status: "pending"}, []string{"1"}},
+			// ...but count as cancelled, unless they were settled
+			{&pb.OrderFindAllRequest{Status: "cancel"}, []string{"2"}},
+			{&pb.OrderFindAllRequest{Status: "settlement"}, []string{"3", "4"}},
+			{&pb.OrderFindAllRequest{UserId: "c2"}, []string{"3", "4"}},
+			{&pb.OrderFindAllRequest{Sort: "desc"}, []string{"4", "3", "2", "1"}},
+		}
+
+		for _, c := range cases {
+			if got := ids(findAll(t, repo, c.req)); !equal(got, c.want) {
+				t.Errorf("FindAll(%v) = %v; want %v", c.req, got, c.want)
+			}
+		}
+	})
+
+	t.Run("ClockDrivesExpiry", func(t *testing.T) {
+		repo, clock := start(t)
+		save(t, repo, fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1, payExp: future})
+
+		if got := ids(findAll(t, repo, &pb.OrderFindAllRequest{Status: "pending"})); !equal(got, []string{"1"}) {
+			t.Errorf("pending before pay_exp = %v; want [1]", got)
+		}
+
+		clock.Add(2 * time.Hour)
+
+		if got := ids(findAll(t, repo, &pb.OrderFindAllRequest{Status: "pending"})); got != nil {
+			t.Errorf("pending after pay_exp = %v; want none", got)
+		}
+		if got := ids(findAll(t, repo, &pb.OrderFindAllRequest{Status: "cancel"})); !equal(got, []string{"1"}) {
+			t.Errorf("cancel after pay_exp = %v; want [1]", got)
+		}
+	})
+
+	t.Run("FindAllSearch", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo,
+			fixture{id: "100", customer: "c1", name: "SMK Walisongo", product: "Paket Lite", amount: 1, payExp: future},
+			fixture{id: "200", customer: "c2", name: "SMA Harapan", product: "Paket Pro", amount: 1, payExp: future},
+		)
+
+		cases := map[string][]string{
+			"walisongo": {"100"},
+			"PRO":       {"200"},
+			"harapan_u": {"200"},
+			"20":        {"200"},
+			"pend":      {"100", "200"},
+			"nothing":   nil,
+		}
+
+		for search, want := range cases {
+			if got := ids(findAll(t, repo, &pb.OrderFindAllRequest{Search: search})); !equal(got, want) {
+				t.Errorf("FindAll(search=%q) = %v; want %v", search, got, want)
+			}
+		}
+	})
+
+	t.Run("FindAllPagination", func(t *testing.T) {
+		repo, _ := start(t)
+		for _, id := range []string{"1", "2", "3", "4", "5"} {
+			save(t, repo, fixture{id: id, customer: "c1", name: "a", product: "Lite", amount: 1, payExp: future})
+		}
+
+		res := findAll(t, repo, &pb.OrderFindAllRequest{Page: 1, PerPage: 2})
+		if got := ids(res); !equal(got, []string{"3", "4"}) {
+			t.Errorf("page 1 = %v; want [3 4]", got)
+		}
+
+		p := res.Payload
+		if p.Rows != 5 || p.Pages != 3 || p.PerPage != 2 || p.ActivePage != 2 || p.Total != 2 {
+			t.Errorf("unexpected pagination payload: %v", p)
+		}
+
+		res = findAll(t, repo, &pb.OrderFindAllRequest{})
+		if p := res.Payload; p.Rows != 5 || p.Pages != 1 || p.Total != 5 {
+			t.Errorf("unpaginated payload = %v; want 5 rows on 1 page", p)
+		}
+
+		res = findAll(t, repo, &pb.OrderFindAllRequest{Page: 5, PerPage: 2})
+		if !res.IsEmpty || res.Payload != nil {
+			t.Errorf("page past the end = %v; want empty response", res)
+		}
+
+		res = findAll(t, repo, &pb.OrderFindAllRequest{CountOnly: true})
+		if res.IsEmpty || res.Payload.Rows != 5 || len(res.Payload.Orders) != 0 {
+			t.Errorf("count only = %v; want 5 rows without orders", res)
+		}
+	})
+
+	t.Run("SumIncome", func(t *testing.T) {
+		repo, _ := start(t)
+
+		_, err := repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{})
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			t.Errorf("SumIncome on empty repository error = %v; want mongo.ErrNoDocuments", err)
+		}
+
+		save(t, repo,
+			fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future},
+			fixture{id: "2", customer: "c1", name: "a", product: "Lite", amount: 2500, payExp: future},
+			fixture{id: "3", customer: "c1", name: "a", product: "Lite", amount: 700, payExp: future},
+			fixture{id: "4", customer: "c2", name: "b", product: "Lite", amount: 400, payExp: future},
+		)
+		for _, id := range []string{"1", "2", "4"} {
+			if _, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: id, Status: "settlement"}, 20); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		totals, err := repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{})
+		if err != nil || !reflect.DeepEqual(totals, []domain.Money{{Currency: "IDR", Amount: 3900}}) {
+			t.Errorf("SumIncome(settlement) = %v, %v; want IDR 3900", totals, err)
+		}
+
+		totals, err = repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{UserId: "c1"})
+		if err != nil || !reflect.DeepEqual(totals, []domain.Money{{Currency: "IDR", Amount: 3500}}) {
+			t.Errorf("SumIncome(c1) = %v, %v; want IDR 3500", totals, err)
+		}
+		if _, err := repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{Status: "pending", UserId: "c2"}); !errors.Is(err, mongo.ErrNoDocuments) {
+			t.Errorf("SumIncome(pending, c2) error = %v; want mongo.ErrNoDocuments", err)
+		}
+
+		totals, err = repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{Status: "pending"})
+		if err != nil || !reflect.DeepEqual(totals, []domain.Money{{Currency: "IDR", Amount: 700}}) {
+			t.Errorf("SumIncome(pending) = %v, %v; want IDR 700", totals, err)
+		}
+	})
+
+	t.Run("SumIncomePartiallyPaid", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo,
+			fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future},
+			fixture{id: "2", customer: "c2", name: "b", product: "Lite", amount: 500, payExp: future},
+		)
+		receipts := []domain.OrderReceipt{
+			{TransactionId: "t1", Reference: "1", Amount: 300, ReceivedAt: 20},
+			{TransactionId: "t2", Reference: "2", Amount: 200, ReceivedAt: 20},
+			{TransactionId: "t3", Reference: "2", Amount: 300, ReceivedAt: 30},
+		}
+		for _, receipt := range receipts {
+			if _, _, err := repo.RecordPayment(ctx, &receipt, domain.PaymentPolicy{}, receipt.ReceivedAt, 0); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// every receipt counts under the status of its order only
+		tests := []struct {
+			status string
+			want   int64
+		}{
+			{"settlement", 500},
+			{"partially_paid", 300},
+		}
+		for _, tt := range tests {
+			totals, err := repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{Status: tt.status})
+			if err != nil || !reflect.DeepEqual(totals, []domain.Money{{Currency: "IDR", Amount: tt.want}}) {
+				t.Errorf("SumIncome(%s) = %v, %v; want IDR %d", tt.status, totals, err, tt.want)
+			}
+		}
+		if _, err := repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{Status: "pending"}); !errors.Is(err, mongo.ErrNoDocuments) {
+			t.Errorf("SumIncome(pending) error = %v; want mongo.ErrNoDocuments", err)
+		}
+	})
+
+	t.Run("RecordPayment", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo,
+			fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future},
+			fixture{id: "2", customer: "c2", name: "b", product: "Lite", amount: 500, payExp: future},
+		)
+		policy := domain.PaymentPolicy{Overpayment: "refund_due"}
+		pay := func(receipt domain.OrderReceipt, expectedVersion int64) (*pb.Order, bool, error) {
+			return repo.RecordPayment(ctx, &receipt, policy, receipt.ReceivedAt, expectedVersion)
+		}
+
+		order, affected, err := pay(domain.OrderReceipt{TransactionId: "t1", Reference: "1", Amount: 600, ReceivedAt: 20}, 1)
+		if err != nil || !affected {
+			t.Fatalf("RecordPayment(t1) = %v, %v", affected, err)
+		}
+		if order.Status != "partially_paid" || order.PaidTotal != 600 || order.SettlementTime != 0 || order.Version != 2 || len(order.Receipts) != 1 {
+			t.Errorf("order paid short = %v", order)
+		}
+		if !proto.Equal(order, findOne(t, repo, "1")) {
+			t.Errorf("RecordPayment returned %v, stored %v", order, findOne(t, repo, "1"))
+		}
+		totals, err := repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{Status: "partially_paid"})
+		if err != nil || !reflect.DeepEqual(totals, []domain.Money{{Currency: "IDR", Amount: 600}}) {
+			t.Errorf("SumIncome(partially_paid) = %v, %v; want the IDR 600 received", totals, err)
+		}
+
+		// a partially paid order keeps its virtual account number
+		taken := newOrder(fixture{id: "3", customer: "c3", name: "c", product: "Lite", amount: 500, payExp: future})
+		taken.Payment.VaNumber = "88001"
+		if err := repo.Save(ctx, taken); !errors.Is(err, domain.ErrVANumberTaken) {
+			t.Errorf("Save with the number of a partially paid order = %v; want ErrVANumberTaken", err)
+		}
+
+		if _, affected, err := pay(domain.OrderReceipt{TransactionId: "t1", Reference: "1", Amount: 600, ReceivedAt: 21}, 0); err != nil || affected {
+			t.Errorf("replayed transaction = %v, %v; want not affected", affected, err)
+		}
+		var conflict *domain.VersionConflictError
+		if _, _, err := pay(domain.OrderReceipt{TransactionId: "t2", Reference: "1", Amount: 600, ReceivedAt: 30}, 1); !errors.As(err, &conflict) || conflict.Current != 2 {
+			t.Errorf("RecordPayment at a stale version = %v; want a conflict at version 2", err)
+		}
+
+		order, _, err = pay(domain.OrderReceipt{TransactionId: "t2", Reference: "1", Amount: 600, ReceivedAt: 30, Fee: 4}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fee := order.Receipts[len(order.Receipts)-1].Fee; fee != 4 {
+			t.Errorf("fee of the receipt = %d; want 4", fee)
+		}
+		if order.Status != "settlement" || order.PaidTotal != 1200 || order.SettlementTime != 30 {
+			t.Errorf("order paid in full = %v", order)
+		}
+		if surplus := order.Overpayment; surplus == nil || surplus.Amount != 200 || surplus.Disposition != "refund_due" {
+			t.Errorf("overpayment = %v; want 200 refund_due", surplus)
+		}
+		if order.Attempts[0].Status != "paid" {
+			t.Errorf("attempt of a settled order = %v", order.Attempts[0])
+		}
+
+		// a settlement without an amount pays the rest, and then nothing
+		if order, _, err := pay(domain.OrderReceipt{TransactionId: "t3", Reference: "2", ReceivedAt: 40}, 0); err != nil || order.Status != "settlement" || order.PaidTotal != 500 {
+			t.Errorf("settlement without an amount = %v, %v", order, err)
+		}
+		if _, affected, err := pay(domain.OrderReceipt{TransactionId: "t4", Reference: "2", ReceivedAt: 41}, 0); err != nil || affected {
+			t.Errorf("settlement of a paid order without an amount = %v, %v; want not affected", affected, err)
+		}
+		if order, _, err := pay(domain.OrderReceipt{TransactionId: "t1", Reference: "missing", Amount: 1}, 0); err != nil || order != nil {
+			t.Errorf("RecordPayment of a missing order = %v, %v; want nil", order, err)
+		}
+
+		// income is what was received, in the period it was received in
+		totals, err = repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{From: 25})
+		if err != nil || !reflect.DeepEqual(totals, []domain.Money{{Currency: "IDR", Amount: 1100}}) {
+			t.Errorf("SumIncome(from 25) = %v, %v; want IDR 1100", totals, err)
+		}
+
+		// the surplus can be refunded along with the rest
+		if _, err := repo.Refund(ctx, "1", &domain.OrderRefund{RefundId: "r1", Amount: 1200, CreatedAt: 50}, 0); err != nil {
+			t.Errorf("refunding what was received = %v", err)
+		}
+		if _, affected, err := pay(domain.OrderReceipt{TransactionId: "t5", Reference: "1", Amount: 100, ReceivedAt: 60}, 0); err != nil || affected {
+			t.Errorf("payment of a refunded order = %v, %v; want not affected", affected, err)
+		}
+	})
+
+	t.Run("FindByVANumberAndFindPaid", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo,
+			fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future},
+			fixture{id: "2", customer: "c2", name: "b", product: "Lite", amount: 1000, payExp: future},
+			fixture{id: "3", customer: "c3", name: "c", product: "Lite", amount: 1000, payExp: future},
+			fixture{id: "5", customer: "c5", name: "e", product: "Free", amount: 0, payExp: future},
+		)
+		for _, id := range []string{"1", "5"} {
+			if _, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: id, Status: "settlement", SettlementTime: 50}, 50); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, _, err := repo.RecordPayment(ctx, &domain.OrderReceipt{TransactionId: "t1", Reference: "2", Amount: 400, ReceivedAt: 70}, domain.PaymentPolicy{}, 70, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		// the number of the settled order 1 is handed out again
+		reused := newOrder(fixture{id: "4", customer: "c4", name: "d", product: "Lite", amount: 1000, payExp: future})
+		reused.Payment.VaNumber, reused.CreatedAt = "88001", 60
+		saveOrder(t, repo, reused)
+
+		for _, tc := range []struct {
+			bank string
+			at   int64
+			want string
+		}{
+			{"bri", 55, "1"},
+			{"bri", 0, "4"},
+			{"", 60, "4"},
+			{"bri", 5, ""},
+			{"bca", 0, ""},
+		} {
+			order, err := repo.FindByVANumber(ctx, tc.bank, "88001", tc.at)
+			if err != nil || order.GetOrderId() != tc.want {
+				t.Errorf("FindByVANumber(%q, 88001, %d) = %v, %v; want %q", tc.bank, tc.at, order.GetOrderId(), err, tc.want)
+			}
+		}
+
+		paid := func(orders []*pb.Order) (ids []string) {
+			for _, order := range orders {
+				ids = append(ids, order.OrderId)
+			}
+			return
+		}
+		for _, tc := range []struct {
+			from, to int64
+			want     []string
+		}{
+			{40, 60, []string{"1"}},
+			{60, 0, []string{"2"}},
+			{0, 0, []string{"1", "2"}},
+			{80, 90, nil},
+		} {
+			orders, err := repo.FindPaid(ctx, tc.from, tc.to)
+			if err != nil || !equal(paid(orders), tc.want) {
+				t.Errorf("FindPaid(%d, %d) = %v, %v; want %v", tc.from, tc.to, paid(orders), err, tc.want)
+			}
+		}
+	})
+
+	t.Run("SumIncomePerCurrency", func(t *testing.T) {
+		repo, _ := start(t)
+
+		for i, currency := range []string{"USD", "IDR", "USD"} {
+			order := newOrder(fixture{id: string(rune('1' + i)), customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future})
+			order.Currency = currency
+			saveOrder(t, repo, order)
+		}
+
+		totals, err := repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{Status: "pending"})
+		want := []domain.Money{{Currency: "IDR", Amount: 1000}, {Currency: "USD", Amount: 2000}}
+		if err != nil || !reflect.DeepEqual(totals, want) {
+			t.Errorf("SumIncome = %v, %v; want %v", totals, err, want)
+		}
+
+		totals, err = repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{Status: "pending", Currency: "USD"})
+		if err != nil || !reflect.DeepEqual(totals, want[1:]) {
+			t.Errorf("SumIncome(USD) = %v, %v; want %v", totals, err, want[1:])
+		}
+
+		if _, err := repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{Status: "pending", Currency: "EUR"}); !errors.Is(err, mongo.ErrNoDocuments) {
+			t.Errorf("SumIncome(EUR) error = %v; want mongo.ErrNoDocuments", err)
+		}
+	})
+
+	t.Run("StampCurrency", func(t *testing.T) {
+		repo, _ := start(t)
+
+		legacy := newOrder(fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future})
+		legacy.Currency = ""
+		saveOrder(t, repo, legacy)
+		save(t, repo, fixture{id: "2", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future})
+
+		affected, err := repo.StampCurrency(ctx, "SGD")
+		if err != nil || affected != 1 {
+			t.Errorf("StampCurrency = %d, %v; want 1", affected, err)
+		}
+		if got := findOne(t, repo, "1").Currency; got != "SGD" {
+			t.Errorf("legacy order currency = %q; want SGD", got)
+		}
+		if got := findOne(t, repo, "2").Currency; got != "IDR" {
+			t.Errorf("stamping changed the currency of an order to %q", got)
+		}
+
+		if affected, _ := repo.StampCurrency(ctx, "SGD"); affected != 0 {
+			t.Errorf("second StampCurrency = %d; want 0", affected)
+		}
+	})
+	t.Run("TaxReport", func(t *testing.T) {
+		repo, _ := start(t)
+
+		march := epoch.Unix()
+		april := epoch.AddDate(0, 1, 0).Unix()
+		settle := func(id string, amount int64, settledAt int64, taxes ...domain.OrderTax) {
+			order := newOrder(fixture{id: id, customer: "c1", name: "a", product: "Lite", amount: amount, payExp: future})
+			order.Taxes = taxes
+			saveOrder(t, repo, order)
+
+			if settledAt == 0 {
+				return
+			}
+			if _, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: id, Status: "settlement", SettlementTime: settledAt}, 20); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		settle("1", 1000, march, domain.OrderTax{Name: "PPN", Rate: 1100, Taxable: 1000, Amount: 110})
+		settle("2", 2000, march+60, domain.OrderTax{Name: "PPN", Rate: 1100, Taxable: 2000, Amount: 220}, domain.OrderTax{Name: "Exempt", Rate: 0, Taxable: 500})
+		settle("3", 3000, april, domain.OrderTax{Name: "PPN", Rate: 1100, Taxable: 3000, Amount: 330})
+		// pending orders have not collected any tax yet
+		settle("4", 4000, 0, domain.OrderTax{Name: "PPN", Rate: 1100, Taxable: 4000, Amount: 440})
+
+		rows, err := repo.TaxReport(ctx, &pb.OrderTaxReportRequest{Period: "month"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []*pb.OrderTaxReportRow{
+			{Period: "2023-03", Currency: "IDR", Name: "Exempt", Rate: 0, Taxable: 500, Amount: 0, Orders: 1},
+			{Period: "2023-03", Currency: "IDR", Name: "PPN", Rate: 1100, Taxable: 3000, Amount: 330, Orders: 2},
+			{Period: "2023-04", Currency: "IDR", Name: "PPN", Rate: 1100, Taxable: 3000, Amount: 330, Orders: 1},
+		}
+		if len(rows) != len(want) {
+			t.Fatalf("TaxReport = %v; want %v", rows, want)
+		}
+		for i := range want {
+			if !proto.Equal(rows[i], want[i]) {
+				t.Errorf("row %d = %v; want %v", i, rows[i], want[i])
+			}
+		}
+
+		rows, err = repo.TaxReport(ctx, &pb.OrderTaxReportRequest{From: march, To: april, Period: "year"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 2 || rows[1].Period != "2023" || rows[1].Amount != 330 || rows[1].Orders != 2 {
+			t.Errorf("TaxReport(march, year) = %v; want PPN 330 over 2 orders in 2023", rows)
+		}
+
+		// refunded orders still collected their tax when they settled, and
+		// refunds give back their share of it when they are issued, the
+		// overpaid surplus first and without any
+		refund := func(id string, refundId string, amount int64, at int64) {
+			if _, err := repo.Refund(ctx, id, &domain.OrderRefund{RefundId: refundId, Amount: amount, CreatedAt: at}, 0); err != nil {
+				t.Fatalf("Refund(%s, %d): %v", id, amount, err)
+			}
+		}
+		order := newOrder(fixture{id: "6", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future})
+		order.Taxes = []domain.OrderTax{{Name: "PPN", Rate: 1100, Taxable: 1000, Amount: 100}}
+		saveOrder(t, repo, order)
+		if _, _, err := repo.RecordPayment(ctx, &domain.OrderReceipt{TransactionId: "t6", Reference: "6", Amount: 1200, ReceivedAt: april}, domain.PaymentPolicy{}, april, 0); err != nil {
+			t.Fatal(err)
+		}
+		refund("1", "r1", 1000, march+120)
+		refund("2", "r2", 1000, april+60)
+		refund("6", "r3", 200, april+60)
+		refund("6", "r4", 500, april+120)
+
+		rows, err = repo.TaxReport(ctx, &pb.OrderTaxReportRequest{Period: "month"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want = []*pb.OrderTaxReportRow{
+			{Period: "2023-03", Currency: "IDR", Name: "Exempt", Rate: 0, Taxable: 500, Amount: 0, Orders: 1},
+			{Period: "2023-03", Currency: "IDR", Name: "PPN", Rate: 1100, Taxable: 3000, Amount: 330, Orders: 2},
+			{Period: "2023-03", Currency: "IDR", Name: "PPN", Rate: 1100, Taxable: -1000, Amount: -110, Orders: 1, Refunds: true},
+			{Period: "2023-04", Currency: "IDR", Name: "Exempt", Rate: 0, Taxable: -250, Amount: 0, Orders: 1, Refunds: true},
+			{Period: "2023-04", Currency: "IDR", Name: "PPN", Rate: 1100, Taxable: 4000, Amount: 430, Orders: 2},
+			{Period: "2023-04", Currency: "IDR", Name: "PPN", Rate: 1100, Taxable: -1500, Amount: -160, Orders: 2, Refunds: true},
+		}
+		if len(rows) != len(want) {
+			t.Fatalf("TaxReport after refunds = %v; want %v", rows, want)
+		}
+		for i := range want {
+			if !proto.Equal(rows[i], want[i]) {
+				t.Errorf("row %d after refunds = %v; want %v", i, rows[i], want[i])
+			}
+		}
+	})
+	t.Run("Refund", func(t *testing.T) {
+		repo, _ := start(t)
+		save(t, repo,
+			fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: past},
+			fixture{id: "2", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future},
+		)
+
+		refund := func(id string, amount int64, reference string, expected int64) (*pb.Order, error) {
+			return repo.Refund(ctx, id, &domain.OrderRefund{
+				RefundId:  reference + "-id",
+				Amount:    amount,
+				Reason:    "customer request",
+				Actor:     "ops",
+				Reference: reference,
+				CreatedAt: 50,
+			}, expected)
+		}
+
+		if _, err := refund("2", 100, "r0", 0); err != domain.ErrNotRefundable {
+			t.Errorf("refunding a pending order = %v; want ErrNotRefundable", err)
+		}
+		if got, err := refund("missing", 100, "r0", 0); got != nil || err != nil {
+			t.Errorf("refunding a missing order = %v, %v; want nil, nil", got, err)
+		}
+
+		if _, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "1", Status: "settlement", SettlementTime: 30}, 30); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := refund("1", 400, "r1", 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != "partially_refunded" || got.RefundedTotal != 400 || got.Version != 3 || got.UpdatedAt != 50 {
+			t.Errorf("after a partial refund: status %q, refunded %d, version %d, updated_at %d", got.Status, got.RefundedTotal, got.Version, got.UpdatedAt)
+		}
+		if len(got.Refunds) != 1 || got.Refunds[0].Reference != "r1" || got.Refunds[0].Actor != "ops" || got.Refunds[0].CreatedAt != 50 {
+			t.Errorf("refunds = %v", got.Refunds)
+		}
+
+		var conflict *domain.VersionConflictError
+		if _, err := refund("1", 100, "r2", 2); !errors.As(err, &conflict) || conflict.Current != 3 {
+			t.Errorf("refund at a stale version = %v; want a conflict at version 3", err)
+		}
+		if _, err := refund("1", 100, "r1", 0); err != domain.ErrDuplicateRefund {
+			t.Errorf("reusing a reference = %v; want ErrDuplicateRefund", err)
+		}
+		if _, err := refund("1", 601, "r2", 0); err != domain.ErrRefundExceedsPaid {
+			t.Errorf("refunding more than paid = %v; want ErrRefundExceedsPaid", err)
+		}
+
+		// a replayed settlement notification must not undo the refund
+		if _, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "1", Status: "settlement", SettlementTime: 30}, 60); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err = refund("1", 600, "r2", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != "refunded" || got.RefundedTotal != 1000 || len(got.Refunds) != 2 {
+			t.Errorf("after the full refund: status %q, refunded %d, %d refund(s)", got.Status, got.RefundedTotal, len(got.Refunds))
+		}
+		if _, err := refund("1", 1, "r3", 0); err != domain.ErrNotRefundable {
+			t.Errorf("refunding a refunded order = %v; want ErrNotRefundable", err)
+		}
+
+		if got := ids(findAll(t, repo, &pb.OrderFindAllRequest{Status: "cancel"})); len(got) != 0 {
+			t.Errorf("a refunded order past pay_exp is listed as cancelled: %v", got)
+		}
+	})
+
+	t.Run("SumIncomeNetOfRefunds", func(t *testing.T) {
+		repo, _ := start(t)
+
+		march := epoch.Unix()
+		april := epoch.AddDate(0, 1, 0).Unix()
+		may := epoch.AddDate(0, 2, 0).Unix()
+
+		save(t, repo,
+			fixture{id: "1", customer: "c1", name: "a", product: "Lite", amount: 1000, payExp: future},
+			fixture{id: "2", customer: "c1", name: "a", product: "Lite", amount: 500, payExp: future},
+		)
+		for _, id := range []string{"1", "2"} {
+			if _, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: id, Status: "settlement", SettlementTime: march + 60}, 20); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// order 1 is refunded in full in April
+		_, err := repo.Refund(ctx, "1", &domain.OrderRefund{RefundId: "r1", Amount: 1000, Reason: "r", Actor: "ops", CreatedAt: april + 60}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sum := func(from, to int64) []domain.Money {
+			t.Helper()
+
+			totals, err := repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{From: from, To: to})
+			if err != nil {
+				t.Fatalf("SumIncome(%d, %d): %v", from, to, err)
+			}
+
+			return totals
+		}
+
+		if got := sum(march, april); !reflect.DeepEqual(got, []domain.Money{{Currency: "IDR", Amount: 1500}}) {
+			t.Errorf("March income = %v; want IDR 1500", got)
+		}
+		if got := sum(april, may); !reflect.DeepEqual(got, []domain.Money{{Currency: "IDR", Amount: -1000}}) {
+			t.Errorf("April income = %v; want IDR -1000", got)
+		}
+		if got := sum(0, 0); !reflect.DeepEqual(got, []domain.Money{{Currency: "IDR", Amount: 500}}) {
+			t.Errorf("all-time income = %v; want IDR 500", got)
+		}
+		if _, err := repo.SumIncome(ctx, &pb.OrderSumIncomeRequest{From: may}); !errors.Is(err, mongo.ErrNoDocuments) {
+			t.Errorf("SumIncome after May error = %v; want mongo.ErrNoDocuments", err)
+		}
+	})
+}
@@ -0,0 +1,230 @@
+package repositorytest
+
+import (
+	"context"
+	"order/domain"
+	"order/variable"
+	"testing"
+)
+
+// WebhookFactory returns a new, empty webhook repository for a single
+// subtest.
+type WebhookFactory func(t *testing.T) domain.WebhookRepository
+
+// RunWebhooks runs the conformance suite of domain.WebhookRepository against
+// the repositories built by factory.
+func RunWebhooks(t *testing.T, factory WebhookFactory) {
+	ctx := context.Background()
+
+	subscribe := func(t *testing.T, repo domain.WebhookRepository, subscription domain.WebhookSubscription) {
+		t.Helper()
+
+		if err := repo.SaveSubscription(ctx, &subscription); err != nil {
+			t.Fatalf("SaveSubscription(%s): %v", subscription.SubscriptionId, err)
+		}
+	}
+
+	enqueue := func(t *testing.T, repo domain.WebhookRepository, deliveries ...domain.WebhookDelivery) {
+		t.Helper()
+
+		for i := range deliveries {
+			if deliveries[i].Status == "" {
+				deliveries[i].Status = variable.WebhookDeliveryPending
+			}
+		}
+
+		if err := repo.Enqueue(ctx, deliveries); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+
+	find := func(t *testing.T, repo domain.WebhookRepository, deliveryId string) *domain.WebhookDelivery {
+		t.Helper()
+
+		delivery, err := repo.FindDelivery(ctx, deliveryId)
+		if err != nil || delivery == nil {
+			t.Fatalf("FindDelivery(%s) = %v, %v", deliveryId, delivery, err)
+		}
+
+		return delivery
+	}
+
+	t.Run("Subscriptions", func(t *testing.T) {
+		repo := factory(t)
+		subscribe(t, repo, domain.WebhookSubscription{SubscriptionId: "s2", MerchantId: "m1", URL: "https://a.test", CreatedAt: 20})
+		subscribe(t, repo, domain.WebhookSubscription{SubscriptionId: "s1", MerchantId: "m1", URL: "https://b.test", EventTypes: []string{variable.OrderEventSettled}, CreatedAt: 10})
+		subscribe(t, repo, domain.WebhookSubscription{SubscriptionId: "s3", MerchantId: "m2", URL: "https://c.test", CreatedAt: 5})
+
+		subscriptions, err := repo.FindSubscriptions(ctx, "m1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(subscriptions) != 2 || subscriptions[0].SubscriptionId != "s1" || subscriptions[1].SubscriptionId != "s2" {
+			t.Fatalf("FindSubscriptions(m1) = %+v; want s1, s2", subscriptions)
+		}
+		if len(subscriptions[0].EventTypes) != 1 {
+			t.Errorf("event types = %v", subscriptions[0].EventTypes)
+		}
+
+		updated := subscriptions[1]
+		updated.URL, updated.Disabled = "https://d.test", true
+		if found, err := repo.UpdateSubscription(ctx, &updated); !found || err != nil {
+			t.Fatalf("UpdateSubscription = %v, %v", found, err)
+		}
+		got, err := repo.FindSubscription(ctx, "m1", "s2")
+		if err != nil || got == nil || got.URL != "https://d.test" || !got.Disabled {
+			t.Errorf("FindSubscription after update = %+v, %v", got, err)
+		}
+
+		// subscriptions are scoped to their merchant
+		if got, err := repo.FindSubscription(ctx, "m2", "s2"); got != nil || err != nil {
+			t.Errorf("FindSubscription(m2, s2) = %v, %v; want nil, nil", got, err)
+		}
+		foreign := domain.WebhookSubscription{SubscriptionId: "s3", MerchantId: "m1"}
+		if found, err := repo.UpdateSubscription(ctx, &foreign); found || err != nil {
+			t.Errorf("UpdateSubscription of another merchant = %v, %v; want false", found, err)
+		}
+		if found, err := repo.DeleteSubscription(ctx, "m1", "s3"); found || err != nil {
+			t.Errorf("DeleteSubscription of another merchant = %v, %v; want false", found, err)
+		}
+
+		if found, err := repo.DeleteSubscription(ctx, "m1", "s1"); !found || err != nil {
+			t.Fatalf("DeleteSubscription = %v, %v", found, err)
+		}
+		if found, _ := repo.DeleteSubscription(ctx, "m1", "s1"); found {
+			t.Error("deleting twice reported found")
+		}
+		if subscriptions, _ := repo.FindSubscriptions(ctx, "m1"); len(subscriptions) != 1 {
+			t.Errorf("FindSubscriptions after delete = %+v", subscriptions)
+		}
+	})
+
+	t.Run("EnqueueSkipsDuplicates", func(t *testing.T) {
+		repo := factory(t)
+		enqueue(t, repo,
+			domain.WebhookDelivery{DeliveryId: "d1", SubscriptionId: "s1", MerchantId: "m1", EventId: "e1", CreatedAt: 10},
+			domain.WebhookDelivery{DeliveryId: "d2", SubscriptionId: "s2", MerchantId: "m1", EventId: "e1", CreatedAt: 10},
+		)
+		// a relay publishing e1 again after a crash
+		enqueue(t, repo,
+			domain.WebhookDelivery{DeliveryId: "d3", SubscriptionId: "s1", MerchantId: "m1", EventId: "e1", CreatedAt: 20},
+			domain.WebhookDelivery{DeliveryId: "d4", SubscriptionId: "s1", MerchantId: "m1", EventId: "e2", CreatedAt: 20},
+		)
+
+		deliveries, err := repo.FindDeliveries(ctx, domain.WebhookDeliveryFilter{MerchantId: "m1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(deliveries) != 3 || deliveries[0].DeliveryId != "d4" {
+			t.Errorf("FindDeliveries = %+v; want d4 first of 3", deliveries)
+		}
+		if got, err := repo.FindDelivery(ctx, "d3"); got != nil || err != nil {
+			t.Errorf("FindDelivery(d3) = %v, %v; want nil, nil", got, err)
+		}
+	})
+
+	t.Run("ClaimAndRecord", func(t *testing.T) {
+		repo := factory(t)
+		enqueue(t, repo,
+			domain.WebhookDelivery{DeliveryId: "d1", SubscriptionId: "s1", EventId: "e1", NextAttemptAt: 100, CreatedAt: 100},
+			domain.WebhookDelivery{DeliveryId: "d2", SubscriptionId: "s1", EventId: "e2", NextAttemptAt: 50, CreatedAt: 110},
+			domain.WebhookDelivery{DeliveryId: "d3", SubscriptionId: "s1", EventId: "e3", NextAttemptAt: 500, CreatedAt: 120},
+		)
+
+		due, err := repo.DueDeliveries(ctx, 100, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(due) != 2 || due[0].DeliveryId != "d2" || due[1].DeliveryId != "d1" {
+			t.Fatalf("DueDeliveries = %+v; want d2, d1", due)
+		}
+		if due, _ := repo.DueDeliveries(ctx, 100, 1); len(due) != 1 {
+			t.Errorf("DueDeliveries with limit 1 = %d deliveries", len(due))
+		}
+
+		if claimed, err := repo.ClaimDelivery(ctx, "d1", 100, 400); !claimed || err != nil {
+			t.Fatalf("ClaimDelivery = %v, %v", claimed, err)
+		}
+		if claimed, _ := repo.ClaimDelivery(ctx, "d1", 100, 400); claimed {
+			t.Error("claimed d1 twice")
+		}
+		if due, _ := repo.DueDeliveries(ctx, 100, 10); len(due) != 1 || due[0].DeliveryId != "d2" {
+			t.Errorf("DueDeliveries after the claim = %+v; want d2", due)
+		}
+
+		retry := find(t, repo, "d1")
+		retry.Failures, retry.NextAttemptAt, retry.UpdatedAt = 1, 160, 130
+		if err := repo.RecordAttempt(ctx, *retry, domain.WebhookAttempt{At: 130, StatusCode: 503, DurationMs: 12}); err != nil {
+			t.Fatal(err)
+		}
+		failed := find(t, repo, "d1")
+		failed.Status, failed.Failures, failed.UpdatedAt = variable.WebhookDeliveryFailed, 2, 170
+		if err := repo.RecordAttempt(ctx, *failed, domain.WebhookAttempt{At: 170, Error: "connection refused"}); err != nil {
+			t.Fatal(err)
+		}
+
+		got := find(t, repo, "d1")
+		if got.Status != variable.WebhookDeliveryFailed || got.Failures != 2 || got.UpdatedAt != 170 || len(got.Attempts) != 2 {
+			t.Fatalf("after two attempts = %+v", got)
+		}
+		if got.Attempts[0].StatusCode != 503 || got.Attempts[1].Error != "connection refused" {
+			t.Errorf("attempts = %+v", got.Attempts)
+		}
+		if claimed, _ := repo.ClaimDelivery(ctx, "d1", got.NextAttemptAt, 900); claimed {
+			t.Error("claimed a failed delivery")
+		}
+
+		if found, err := repo.Replay(ctx, "d1", 200); !found || err != nil {
+			t.Fatalf("Replay = %v, %v", found, err)
+		}
+		got = find(t, repo, "d1")
+		if got.Status != variable.WebhookDeliveryPending || got.Failures != 0 || got.NextAttemptAt != 200 || len(got.Attempts) != 2 {
+			t.Errorf("after replay = %+v", got)
+		}
+		if found, err := repo.Replay(ctx, "missing", 200); found || err != nil {
+			t.Errorf("Replay(missing) = %v, %v; want false", found, err)
+		}
+	})
+
+	t.Run("FindDeliveries", func(t *testing.T) {
+		repo := factory(t)
+		enqueue(t, repo,
+			domain.WebhookDelivery{DeliveryId: "d1", SubscriptionId: "s1", MerchantId: "m1", EventId: "e1", OrderId: "o1", CreatedAt: 10},
+			domain.WebhookDelivery{DeliveryId: "d2", SubscriptionId: "s2", MerchantId: "m1", EventId: "e1", OrderId: "o1", CreatedAt: 10, Status: variable.WebhookDeliverySucceeded},
+			domain.WebhookDelivery{DeliveryId: "d3", SubscriptionId: "s1", MerchantId: "m1", EventId: "e2", OrderId: "o2", CreatedAt: 20},
+			domain.WebhookDelivery{DeliveryId: "d4", SubscriptionId: "s3", MerchantId: "m2", EventId: "e3", OrderId: "o3", CreatedAt: 30},
+		)
+
+		for _, test := range []struct {
+			name   string
+			filter domain.WebhookDeliveryFilter
+			want   []string
+		}{
+			{"Merchant", domain.WebhookDeliveryFilter{MerchantId: "m1"}, []string{"d3", "d1", "d2"}},
+			{"Subscription", domain.WebhookDeliveryFilter{MerchantId: "m1", SubscriptionId: "s1"}, []string{"d3", "d1"}},
+			{"Status", domain.WebhookDeliveryFilter{MerchantId: "m1", Status: variable.WebhookDeliverySucceeded}, []string{"d2"}},
+			{"Order", domain.WebhookDeliveryFilter{OrderId: "o1"}, []string{"d1", "d2"}},
+			{"Limit", domain.WebhookDeliveryFilter{Limit: 2}, []string{"d4", "d3"}},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				deliveries, err := repo.FindDeliveries(ctx, test.filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var got []string
+				for _, delivery := range deliveries {
+					got = append(got, delivery.DeliveryId)
+				}
+				if len(got) != len(test.want) {
+					t.Fatalf("FindDeliveries = %v; want %v", got, test.want)
+				}
+				for i := range got {
+					if got[i] != test.want[i] {
+						t.Fatalf("FindDeliveries = %v; want %v", got, test.want)
+					}
+				}
+			})
+		}
+	})
+}
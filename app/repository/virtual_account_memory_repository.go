@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"sync"
+)
+
+// VirtualAccountMemoryRepository is an in-memory VirtualAccountRepository.
+type VirtualAccountMemoryRepository struct {
+	mu       sync.Mutex
+	counters map[string]int64
+}
+
+func NewVirtualAccountMemoryRepository() domain.VirtualAccountRepository {
+	return &VirtualAccountMemoryRepository{counters: map[string]int64{}}
+}
+
+func (v *VirtualAccountMemoryRepository) Next(ctx context.Context, bank string) (value int64, err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.counters[bank]++
+
+	return v.counters[bank], nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"order/domain"
+	"order/variable"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// VirtualAccountRepository counts the virtual account numbers handed out
+// with one document per bank in the "va_counters" collection.
+type VirtualAccountRepository struct {
+	counters *mongo.Collection
+}
+
+func NewVirtualAccountRepository(db *mongo.Database) domain.VirtualAccountRepository {
+	return &VirtualAccountRepository{counters: db.Collection("va_counters")}
+}
+
+// CreateVirtualAccountIndexes creates the unique index keeping a virtual
+// account number to a single unpaid order of a bank. Orders leave the
+// index once they settle, expire or are cancelled, which frees their
+// number. It is safe to call repeatedly, and replaces the index of older
+// releases, which only covered pending orders. The index needs MongoDB 6.0
+// or later.
+func CreateVirtualAccountIndexes(ctx context.Context, db *mongo.Database) (err error) {
+	model := mongo.IndexModel{
+		Keys: bson.D{{Key: "payment.bank", Value: 1}, {Key: "payment.va_number", Value: 1}},
+		Options: options.Index().
+			SetName("open_va_number").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{
+				"status":            bson.M{"$in": variable.UnpaidStatuses},
+				"payment.va_number": bson.M{"$gt": ""},
+			}),
+	}
+
+	indexes := db.Collection("orders").Indexes()
+	_, err = indexes.CreateOne(ctx, model)
+
+	var conflict mongo.CommandError
+	if errors.As(err, &conflict) && (conflict.Code == indexOptionsConflict || conflict.Code == indexKeySpecsConflict) {
+		if _, err = indexes.DropOne(ctx, "open_va_number"); err != nil {
+			return
+		}
+		_, err = indexes.CreateOne(ctx, model)
+	}
+
+	return
+}
+
+// MongoDB error codes of an index created again with other options.
+const (
+	indexOptionsConflict  = 85
+	indexKeySpecsConflict = 86
+)
+
+func (v *VirtualAccountRepository) Next(ctx context.Context, bank string) (value int64, err error) {
+	var counter struct {
+		Value int64 `bson:"value"`
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err = v.counters.FindOneAndUpdate(ctx, bson.M{"_id": bank}, bson.M{"$inc": bson.M{"value": 1}}, opts).Decode(&counter)
+
+	return counter.Value, err
+}
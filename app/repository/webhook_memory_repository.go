@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"order/domain"
+	"order/variable"
+	"sort"
+	"sync"
+)
+
+// WebhookMemoryRepository is an in-memory WebhookRepository keeping the
+// subscriptions and their deliveries in the order they were made.
+type WebhookMemoryRepository struct {
+	mu            sync.Mutex
+	subscriptions []domain.WebhookSubscription
+	deliveries    []domain.WebhookDelivery
+}
+
+func NewWebhookMemoryRepository() domain.WebhookRepository {
+	return &WebhookMemoryRepository{}
+}
+
+func copySubscription(subscription domain.WebhookSubscription) domain.WebhookSubscription {
+	subscription.EventTypes = append([]string(nil), subscription.EventTypes...)
+
+	return subscription
+}
+
+func copyDelivery(delivery domain.WebhookDelivery) domain.WebhookDelivery {
+	delivery.Attempts = append([]domain.WebhookAttempt(nil), delivery.Attempts...)
+
+	return delivery
+}
+
+func (w *WebhookMemoryRepository) SaveSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscriptions = append(w.subscriptions, copySubscription(*subscription))
+
+	return
+}
+
+func (w *WebhookMemoryRepository) UpdateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (found bool, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for i := range w.subscriptions {
+		stored := &w.subscriptions[i]
+		if stored.MerchantId == subscription.MerchantId && stored.SubscriptionId == subscription.SubscriptionId {
+			*stored = copySubscription(*subscription)
+			return true, nil
+		}
+	}
+
+	return
+}
+
+func (w *WebhookMemoryRepository) DeleteSubscription(ctx context.Context, merchantId string, subscriptionId string) (found bool, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for i, stored := range w.subscriptions {
+		if stored.MerchantId == merchantId && stored.SubscriptionId == subscriptionId {
+			w.subscriptions = append(w.subscriptions[:i], w.subscriptions[i+1:]...)
+			return true, nil
+		}
+	}
+
+	return
+}
+
+func (w *WebhookMemoryRepository) FindSubscription(ctx context.Context, merchantId string, subscriptionId string) (subscription *domain.WebhookSubscription, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, stored := range w.subscriptions {
+		if stored.MerchantId == merchantId && stored.SubscriptionId == subscriptionId {
+			found := copySubscription(stored)
+			return &found, nil
+		}
+	}
+
+	return
+}
+
+func (w *WebhookMemoryRepository) FindSubscriptions(ctx context.Context, merchantId string) (subscriptions []domain.WebhookSubscription, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	subscriptions = []domain.WebhookSubscription{}
+	for _, stored := range w.subscriptions {
+		if stored.MerchantId == merchantId {
+			subscriptions = append(subscriptions, copySubscription(stored))
+		}
+	}
+
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		if subscriptions[i].CreatedAt != subscriptions[j].CreatedAt {
+			return subscriptions[i].CreatedAt < subscriptions[j].CreatedAt
+		}
+
+		return subscriptions[i].SubscriptionId < subscriptions[j].SubscriptionId
+	})
+
+	return
+}
+
+func (w *WebhookMemoryRepository) Enqueue(ctx context.Context, deliveries []domain.WebhookDelivery) (err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, delivery := range deliveries {
+		queued := false
+		for _, stored := range w.deliveries {
+			if stored.EventId == delivery.EventId && stored.SubscriptionId == delivery.SubscriptionId {
+				queued = true
+				break
+			}
+		}
+
+		if !queued {
+			w.deliveries = append(w.deliveries, copyDelivery(delivery))
+		}
+	}
+
+	return
+}
+
+// find returns copies of the deliveries for which match is true. The caller
+// holds w.mu.
+func (w *WebhookMemoryRepository) find(match func(delivery *domain.WebhookDelivery) bool) []domain.WebhookDelivery {
+	deliveries := []domain.WebhookDelivery{}
+	for i := range w.deliveries {
+		if match(&w.deliveries[i]) {
+			deliveries = append(deliveries, copyDelivery(w.deliveries[i]))
+		}
+	}
+
+	return deliveries
+}
+
+// delivery returns the stored delivery, nil when there is none. The caller
+// holds w.mu.
+func (w *WebhookMemoryRepository) delivery(deliveryId string) *domain.WebhookDelivery {
+	for i := range w.deliveries {
+		if w.deliveries[i].DeliveryId == deliveryId {
+			return &w.deliveries[i]
+		}
+	}
+
+	return nil
+}
+
+func (w *WebhookMemoryRepository) DueDeliveries(ctx context.Context, now int64, limit int64) (deliveries []domain.WebhookDelivery, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	deliveries = w.find(func(delivery *domain.WebhookDelivery) bool {
+		return delivery.Status == variable.WebhookDeliveryPending && delivery.NextAttemptAt <= now
+	})
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if deliveries[i].NextAttemptAt != deliveries[j].NextAttemptAt {
+			return deliveries[i].NextAttemptAt < deliveries[j].NextAttemptAt
+		}
+
+		return deliveries[i].CreatedAt < deliveries[j].CreatedAt
+	})
+
+	if limit > 0 && int64(len(deliveries)) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return
+}
+
+func (w *WebhookMemoryRepository) ClaimDelivery(ctx context.Context, deliveryId string, dueAt int64, leaseUntil int64) (claimed bool, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delivery := w.delivery(deliveryId)
+	if delivery == nil || delivery.Status != variable.WebhookDeliveryPending || delivery.NextAttemptAt != dueAt {
+		return
+	}
+
+	// like MongoDB, a write that changes nothing is not a modification
+	if leaseUntil == dueAt {
+		return
+	}
+
+	delivery.NextAttemptAt = leaseUntil
+
+	return true, nil
+}
+
+func (w *WebhookMemoryRepository) RecordAttempt(ctx context.Context, delivery domain.WebhookDelivery, attempt domain.WebhookAttempt) (err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	stored := w.delivery(delivery.DeliveryId)
+	if stored == nil {
+		return
+	}
+
+	stored.Attempts = append(stored.Attempts, attempt)
+	stored.Status = delivery.Status
+	stored.Failures = delivery.Failures
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	stored.UpdatedAt = delivery.UpdatedAt
+
+	return
+}
+
+func (w *WebhookMemoryRepository) FindDelivery(ctx context.Context, deliveryId string) (delivery *domain.WebhookDelivery, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	stored := w.delivery(deliveryId)
+	if stored == nil {
+		return
+	}
+
+	found := copyDelivery(*stored)
+
+	return &found, nil
+}
+
+func (w *WebhookMemoryRepository) FindDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) (deliveries []domain.WebhookDelivery, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	deliveries = w.find(func(delivery *domain.WebhookDelivery) bool {
+		return (filter.MerchantId == "" || delivery.MerchantId == filter.MerchantId) &&
+			(filter.SubscriptionId == "" || delivery.SubscriptionId == filter.SubscriptionId) &&
+			(filter.Status == "" || delivery.Status == filter.Status) &&
+			(filter.OrderId == "" || delivery.OrderId == filter.OrderId)
+	})
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if deliveries[i].CreatedAt != deliveries[j].CreatedAt {
+			return deliveries[i].CreatedAt > deliveries[j].CreatedAt
+		}
+
+		return deliveries[i].DeliveryId < deliveries[j].DeliveryId
+	})
+
+	if filter.Limit > 0 && int64(len(deliveries)) > filter.Limit {
+		deliveries = deliveries[:filter.Limit]
+	}
+
+	return
+}
+
+func (w *WebhookMemoryRepository) Replay(ctx context.Context, deliveryId string, now int64) (found bool, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delivery := w.delivery(deliveryId)
+	if delivery == nil {
+		return
+	}
+
+	delivery.Status = variable.WebhookDeliveryPending
+	delivery.Failures = 0
+	delivery.NextAttemptAt = now
+	delivery.UpdatedAt = now
+
+	return true, nil
+}
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"order/domain"
+	"order/variable"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookRepository struct {
+	subscriptions *mongo.Collection
+	deliveries    *mongo.Collection
+}
+
+func NewWebhookRepository(db *mongo.Database) domain.WebhookRepository {
+	return &WebhookRepository{
+		subscriptions: db.Collection("webhooks"),
+		deliveries:    db.Collection("webhook_deliveries"),
+	}
+}
+
+// CreateWebhookIndexes creates the unique index making a repeated enqueue
+// of an event a no-op, and the indexes finding subscriptions and due
+// deliveries. It is safe to call repeatedly.
+func CreateWebhookIndexes(ctx context.Context, db *mongo.Database) (err error) {
+	_, err = db.Collection("webhooks").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "subscription_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "merchant_id", Value: 1}, {Key: "created_at", Value: 1}}},
+	})
+	if err != nil {
+		return
+	}
+
+	_, err = db.Collection("webhook_deliveries").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "delivery_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "event_id", Value: 1}, {Key: "subscription_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		{Keys: bson.D{{Key: "merchant_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+
+	return
+}
+
+func (w *WebhookRepository) SaveSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (err error) {
+	_, err = w.subscriptions.InsertOne(ctx, subscription)
+
+	return
+}
+
+func (w *WebhookRepository) UpdateSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (found bool, err error) {
+	filter := bson.M{"merchant_id": subscription.MerchantId, "subscription_id": subscription.SubscriptionId}
+	resp, err := w.subscriptions.ReplaceOne(ctx, filter, subscription)
+	if err != nil {
+		return
+	}
+
+	found = resp.MatchedCount > 0
+
+	return
+}
+
+func (w *WebhookRepository) DeleteSubscription(ctx context.Context, merchantId string, subscriptionId string) (found bool, err error) {
+	resp, err := w.subscriptions.DeleteOne(ctx, bson.M{"merchant_id": merchantId, "subscription_id": subscriptionId})
+	if err != nil {
+		return
+	}
+
+	found = resp.DeletedCount > 0
+
+	return
+}
+
+func (w *WebhookRepository) FindSubscription(ctx context.Context, merchantId string, subscriptionId string) (subscription *domain.WebhookSubscription, err error) {
+	subscription = &domain.WebhookSubscription{}
+	err = w.subscriptions.FindOne(ctx, bson.M{"merchant_id": merchantId, "subscription_id": subscriptionId}).Decode(subscription)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (w *WebhookRepository) FindSubscriptions(ctx context.Context, merchantId string) (subscriptions []domain.WebhookSubscription, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "subscription_id", Value: 1}})
+	cur, err := w.subscriptions.Find(ctx, bson.M{"merchant_id": merchantId}, opts)
+	if err != nil {
+		return
+	}
+
+	defer cur.Close(ctx)
+
+	subscriptions = []domain.WebhookSubscription{}
+	err = cur.All(ctx, &subscriptions)
+
+	return
+}
+
+// onlyDuplicates reports whether every write of an unordered insert failed
+// on a unique index.
+func onlyDuplicates(err error) bool {
+	var bulk mongo.BulkWriteException
+	if !errors.As(err, &bulk) || bulk.WriteConcernError != nil {
+		return false
+	}
+
+	for _, write := range bulk.WriteErrors {
+		if write.Code != 11000 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Enqueue relies on the unique index on event and subscription to skip the
+// deliveries queued already.
+func (w *WebhookRepository) Enqueue(ctx context.Context, deliveries []domain.WebhookDelivery) (err error) {
+	if len(deliveries) == 0 {
+		return
+	}
+
+	documents := make([]interface{}, len(deliveries))
+	for i := range deliveries {
+		documents[i] = deliveries[i]
+	}
+
+	_, err = w.deliveries.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err != nil && onlyDuplicates(err) {
+		err = nil
+	}
+
+	return
+}
+
+func (w *WebhookRepository) findDeliveries(ctx context.Context, filter bson.M, opts *options.FindOptions) (deliveries []domain.WebhookDelivery, err error) {
+	cur, err := w.deliveries.Find(ctx, filter, opts)
+	if err != nil {
+		return
+	}
+
+	defer cur.Close(ctx)
+
+	deliveries = []domain.WebhookDelivery{}
+	err = cur.All(ctx, &deliveries)
+
+	return
+}
+
+func (w *WebhookRepository) DueDeliveries(ctx context.Context, now int64, limit int64) (deliveries []domain.WebhookDelivery, err error) {
+	filter := bson.M{
+		"status":          variable.WebhookDeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "created_at", Value: 1}}).
+		SetLimit(limit)
+
+	return w.findDeliveries(ctx, filter, opts)
+}
+
+func (w *WebhookRepository) ClaimDelivery(ctx context.Context, deliveryId string, dueAt int64, leaseUntil int64) (claimed bool, err error) {
+	filter := bson.M{
+		"delivery_id":     deliveryId,
+		"status":          variable.WebhookDeliveryPending,
+		"next_attempt_at": dueAt,
+	}
+	resp, err := w.deliveries.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"next_attempt_at": leaseUntil}})
+	if err != nil {
+		return
+	}
+
+	claimed = resp.ModifiedCount > 0
+
+	return
+}
+
+func (w *WebhookRepository) RecordAttempt(ctx context.Context, delivery domain.WebhookDelivery, attempt domain.WebhookAttempt) (err error) {
+	update := bson.M{
+		"$push": bson.M{"attempts": attempt},
+		"$set": bson.M{
+			"status":          delivery.Status,
+			"failures":        delivery.Failures,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"updated_at":      delivery.UpdatedAt,
+		},
+	}
+	_, err = w.deliveries.UpdateOne(ctx, bson.M{"delivery_id": delivery.DeliveryId}, update)
+
+	return
+}
+
+func (w *WebhookRepository) FindDelivery(ctx context.Context, deliveryId string) (delivery *domain.WebhookDelivery, err error) {
+	delivery = &domain.WebhookDelivery{}
+	err = w.deliveries.FindOne(ctx, bson.M{"delivery_id": deliveryId}).Decode(delivery)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (w *WebhookRepository) FindDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) (deliveries []domain.WebhookDelivery, err error) {
+	match := bson.M{}
+	if filter.MerchantId != "" {
+		match["merchant_id"] = filter.MerchantId
+	}
+	if filter.SubscriptionId != "" {
+		match["subscription_id"] = filter.SubscriptionId
+	}
+	if filter.Status != "" {
+		match["status"] = filter.Status
+	}
+	if filter.OrderId != "" {
+		match["order_id"] = filter.OrderId
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "delivery_id", Value: 1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+
+	return w.findDeliveries(ctx, match, opts)
+}
+
+func (w *WebhookRepository) Replay(ctx context.Context, deliveryId string, now int64) (found bool, err error) {
+	update := bson.M{"$set": bson.M{
+		"status":          variable.WebhookDeliveryPending,
+		"failures":        0,
+		"next_attempt_at": now,
+		"updated_at":      now,
+	}}
+	resp, err := w.deliveries.UpdateOne(ctx, bson.M{"delivery_id": deliveryId}, update)
+	if err != nil {
+		return
+	}
+
+	found = resp.MatchedCount > 0
+
+	return
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CouponUsecase defines the use case for managing Coupons.
+type CouponUsecase struct {
+	repository domain.CouponRepository
+	// currency is the currency of coupons created without one.
+	currency string
+	clock    helper.Clock
+}
+
+// NewCouponUsecase creates a new CouponUsecase with the given repository,
+// default currency and clock.
+func NewCouponUsecase(repo domain.CouponRepository, currency string, clock helper.Clock) domain.CouponUsecase {
+	if currency == "" {
+		currency = variable.DefaultCurrency
+	}
+
+	return &CouponUsecase{
+		repository: repo,
+		currency:   currency,
+		clock:      clock,
+	}
+}
+
+// normalizeCode makes coupon codes case-insensitive.
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func validateCoupon(req *pb.Coupon) error {
+	switch {
+	case normalizeCode(req.Code) == "":
+		return status.Error(codes.InvalidArgument, "code is required")
+	case req.Type != variable.CouponTypePercentage && req.Type != variable.CouponTypeFixed:
+		return status.Errorf(codes.InvalidArgument, "type must be %s or %s", variable.CouponTypePercentage, variable.CouponTypeFixed)
+	case req.Amount <= 0:
+		return status.Error(codes.InvalidArgument, "amount must be positive")
+	case req.Type == variable.CouponTypePercentage && req.Amount > 100:
+		return status.Error(codes.InvalidArgument, "a percentage must not exceed 100")
+	case req.EndsAt > 0 && req.EndsAt <= req.StartsAt:
+		return status.Error(codes.InvalidArgument, "ends_at must be after starts_at")
+	case req.Currency != "" && !domain.ValidCurrency(req.Currency):
+		return status.Errorf(codes.InvalidArgument, "unsupported currency %q", req.Currency)
+	case req.MaxUses < 0 || req.MaxUsesPerCustomer < 0 || req.MinOrderAmount < 0:
+		return status.Error(codes.InvalidArgument, "caps and minimum order amount must not be negative")
+	}
+
+	return nil
+}
+
+func (c *CouponUsecase) Create(ctx context.Context, req *pb.Coupon) (err error) {
+	if err = validateCoupon(req); err != nil {
+		return
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = c.currency
+	}
+
+	coupon := &domain.Coupon{
+		Code:               normalizeCode(req.Code),
+		Description:        req.Description,
+		Type:               req.Type,
+		Amount:             req.Amount,
+		Currency:           currency,
+		StartsAt:           req.StartsAt,
+		EndsAt:             req.EndsAt,
+		MaxUses:            req.MaxUses,
+		MaxUsesPerCustomer: req.MaxUsesPerCustomer,
+		MinOrderAmount:     req.MinOrderAmount,
+		ProductIds:         req.ProductIds,
+		CreatedAt:          helper.Unix(c.clock),
+	}
+
+	err = c.repository.Save(ctx, coupon)
+	if err == domain.ErrCouponExists {
+		err = status.Error(codes.AlreadyExists, err.Error())
+	}
+
+	return
+}
+
+func (c *CouponUsecase) FindOne(ctx context.Context, req *pb.CouponFindOneRequest) (res *pb.CouponFindOneResponse, err error) {
+	coupon, err := c.repository.FindOne(ctx, normalizeCode(req.Code))
+	if err != nil {
+		return
+	}
+
+	if coupon == nil {
+		return &pb.CouponFindOneResponse{IsEmpty: true}, nil
+	}
+
+	res = &pb.CouponFindOneResponse{
+		Payload: &pb.Coupon{
+			Code:               coupon.Code,
+			Description:        coupon.Description,
+			Type:               coupon.Type,
+			Amount:             coupon.Amount,
+			Currency:           coupon.Currency,
+			StartsAt:           coupon.StartsAt,
+			EndsAt:             coupon.EndsAt,
+			MaxUses:            coupon.MaxUses,
+			MaxUsesPerCustomer: coupon.MaxUsesPerCustomer,
+			MinOrderAmount:     coupon.MinOrderAmount,
+			ProductIds:         coupon.ProductIds,
+			Used:               coupon.Used,
+			CreatedAt:          coupon.CreatedAt,
+		},
+	}
+
+	return
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EntitlementUsecase answers which products customers have access to.
+type EntitlementUsecase struct {
+	repository domain.EntitlementRepository
+	clock      helper.Clock
+}
+
+// NewEntitlementUsecase creates a new EntitlementUsecase with the given repository and clock.
+func NewEntitlementUsecase(repo domain.EntitlementRepository, clock helper.Clock) domain.EntitlementUsecase {
+	return &EntitlementUsecase{
+		repository: repo,
+		clock:      clock,
+	}
+}
+
+func parseEntitlementResponse(entitlement domain.Entitlement, now int64) *pb.Entitlement {
+	var grants []*pb.EntitlementGrant
+	for _, grant := range entitlement.Grants {
+		grants = append(grants, &pb.EntitlementGrant{
+			OrderId:   grant.OrderId,
+			Days:      grant.Days,
+			SettledAt: grant.SettledAt,
+			StartsAt:  grant.StartsAt,
+			EndsAt:    grant.EndsAt,
+		})
+	}
+
+	var renewal *pb.EntitlementRenewal
+	if entitlement.Renewal != nil {
+		renewal = &pb.EntitlementRenewal{
+			OrderId:   entitlement.Renewal.OrderId,
+			PeriodEnd: entitlement.Renewal.PeriodEnd,
+			PayExp:    entitlement.Renewal.PayExp,
+			Stage:     entitlement.Renewal.Stage,
+			Reminders: entitlement.Renewal.Reminders,
+			UpdatedAt: entitlement.Renewal.UpdatedAt,
+		}
+	}
+
+	return &pb.Entitlement{
+		CustomerId:  entitlement.CustomerId,
+		ProductId:   entitlement.ProductId,
+		ProductName: entitlement.ProductName,
+		StartsAt:    entitlement.StartsAt,
+		EndsAt:      entitlement.EndsAt,
+		Active:      entitlement.Active(now),
+		Grants:      grants,
+		AutoRenew:   !entitlement.RenewalOptOut,
+		Renewal:     renewal,
+	}
+}
+
+func (e *EntitlementUsecase) Check(ctx context.Context, req *pb.EntitlementCheckRequest) (res *pb.EntitlementCheckResponse, err error) {
+	if req.CustomerId == "" || req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "customer_id and product_id are required")
+	}
+
+	entitlement, err := e.repository.FindOne(ctx, req.CustomerId, req.ProductId)
+	if err != nil {
+		return
+	}
+
+	res = &pb.EntitlementCheckResponse{}
+	if entitlement == nil {
+		return
+	}
+
+	res.Payload = parseEntitlementResponse(*entitlement, helper.Unix(e.clock))
+	res.Active = res.Payload.Active
+	if res.Active {
+		res.EndsAt = entitlement.EndsAt
+	}
+
+	return
+}
+
+func (e *EntitlementUsecase) List(ctx context.Context, req *pb.EntitlementListRequest) (res *pb.EntitlementListResponse, err error) {
+	if req.CustomerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	entitlements, err := e.repository.FindAll(ctx, req.CustomerId)
+	if err != nil {
+		return
+	}
+
+	now := helper.Unix(e.clock)
+	res = &pb.EntitlementListResponse{}
+	for _, entitlement := range entitlements {
+		if req.ActiveOnly && !entitlement.Active(now) {
+			continue
+		}
+
+		res.Entitlements = append(res.Entitlements, parseEntitlementResponse(entitlement, now))
+	}
+
+	return
+}
+
+// SetAutoRenew opts the customer in or out of the renewal of the product. An
+// unpaid renewal is cancelled by the next renewal run after opting out.
+func (e *EntitlementUsecase) SetAutoRenew(ctx context.Context, req *pb.EntitlementAutoRenewRequest) (res *pb.OperationResponse, err error) {
+	if req.CustomerId == "" || req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "customer_id and product_id are required")
+	}
+
+	found, err := e.repository.SetRenewalOptOut(ctx, req.CustomerId, req.ProductId, !req.AutoRenew, helper.Unix(e.clock))
+	if err != nil {
+		return
+	}
+
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "customer %s has no entitlement to product %s", req.CustomerId, req.ProductId)
+	}
+
+	res = &pb.OperationResponse{IsAffected: true}
+
+	return
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InvoiceUsecase issues the invoices of settled orders. An invoice is
+// rendered and numbered the first time it is asked for, and never changes
+// afterwards.
+type InvoiceUsecase struct {
+	orders   domain.OrderRepository
+	invoices domain.InvoiceRepository
+	renderer domain.InvoiceRenderer
+	policy   domain.InvoicePolicy
+	clock    helper.Clock
+}
+
+// NewInvoiceUsecase creates a new InvoiceUsecase with the given
+// repositories, renderer and clock, filling the settings missing from
+// policy with defaults.
+func NewInvoiceUsecase(orders domain.OrderRepository, invoices domain.InvoiceRepository, renderer domain.InvoiceRenderer, policy domain.InvoicePolicy, clock helper.Clock) domain.InvoiceUsecase {
+	if policy.Prefix == "" {
+		policy.Prefix = "INV"
+	}
+	if policy.Location == nil {
+		policy.Location = time.UTC
+	}
+
+	return &InvoiceUsecase{
+		orders:   orders,
+		invoices: invoices,
+		renderer: renderer,
+		policy:   policy,
+		clock:    clock,
+	}
+}
+
+// Get returns the invoice of the order in req.Format, "pdf" by default,
+// issuing it when the order has none yet. With a user ID, only the invoices
+// of that customer are returned.
+func (i *InvoiceUsecase) Get(ctx context.Context, req *pb.InvoiceRequest) (res *pb.Invoice, err error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	format := req.Format
+	switch format {
+	case "":
+		format = variable.InvoiceFormatPDF
+	case variable.InvoiceFormatPDF, variable.InvoiceFormatHTML:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown invoice format %q", req.Format)
+	}
+
+	found, err := i.orders.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: req.OrderId})
+	if err != nil {
+		return
+	}
+	if found.IsEmpty || (req.UserId != "" && found.Payload.GetBuyer().GetCustomerId() != req.UserId) {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.OrderId)
+	}
+
+	invoice, err := i.invoices.FindOne(ctx, req.OrderId)
+	if err != nil {
+		return
+	}
+	if invoice == nil {
+		invoice, err = i.issue(ctx, found.Payload)
+		if err != nil {
+			return
+		}
+	}
+
+	res = &pb.Invoice{
+		InvoiceNumber: invoice.Number,
+		OrderId:       invoice.OrderId,
+		IssuedAt:      invoice.IssuedAt,
+		Format:        format,
+	}
+	if format == variable.InvoiceFormatHTML {
+		res.ContentType, res.Content = "text/html; charset=utf-8", invoice.HTML
+	} else {
+		res.ContentType, res.Content = "application/pdf", invoice.PDF
+	}
+
+	return
+}
+
+// issue numbers and renders the invoice of order, which must have been
+// paid.
+func (i *InvoiceUsecase) issue(ctx context.Context, order *pb.Order) (*domain.Invoice, error) {
+	if !isSettled(order.GetStatus()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s is %s, only paid orders have an invoice", order.GetOrderId(), order.GetStatus())
+	}
+
+	now := helper.Unix(i.clock)
+	year := int64(time.Unix(now, 0).In(i.policy.Location).Year())
+
+	return i.invoices.Issue(ctx, order.GetOrderId(), year, func(sequence int64) (*domain.Invoice, error) {
+		number := fmt.Sprintf("%s-%d-%06d", i.policy.Prefix, year, sequence)
+		html, pdf, err := i.renderer.Render(domain.InvoiceData{Number: number, IssuedAt: now, Order: order})
+		if err != nil {
+			return nil, fmt.Errorf("render invoice %s: %w", number, err)
+		}
+
+		return &domain.Invoice{
+			OrderId:  order.GetOrderId(),
+			Number:   number,
+			Year:     year,
+			Sequence: sequence,
+			IssuedAt: now,
+			HTML:     html,
+			PDF:      pdf,
+		}, nil
+	})
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"order/app/repository"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testRenderer renders invoices as their number, failing once for each
+// order in failOnce.
+type testRenderer struct {
+	failOnce map[string]bool
+}
+
+func (r *testRenderer) Render(data domain.InvoiceData) (html []byte, pdf []byte, err error) {
+	if orderId := data.Order.GetOrderId(); r.failOnce[orderId] {
+		delete(r.failOnce, orderId)
+		return nil, nil, errors.New("renderer down")
+	}
+
+	return []byte("<p>" + data.Number + "</p>"), []byte("%PDF " + data.Number), nil
+}
+
+func TestInvoiceNumbersWithoutGaps(t *testing.T) {
+	ctx := context.Background()
+	o, clock := newTestUsecase(t, OrderOptions{})
+	for _, id := range []string{"o1", "o2", "o3", "o4", "o5"} {
+		_, err := o.Save(ctx, &pb.OrderCreateRequest{
+			Buyer:   &pb.OrderBuyer{CustomerId: "c1", Name: "Customer"},
+			Product: &pb.OrderProduct{ProductId: "p1", Name: "Premium", Price: 10000},
+			Payment: &pb.OrderPayment{PaymentType: "bank_transfer", OrderId: id},
+			TrxTime: helper.Unix(clock),
+			PayExp:  helper.Unix(clock) + 3600,
+		})
+		if err != nil {
+			t.Fatalf("Save(%s): %v", id, err)
+		}
+		if id == "o4" {
+			continue
+		}
+		if _, err := o.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: id, Status: variable.PaymentStatusSettlement, SettlementTime: helper.Unix(clock)}); err != nil {
+			t.Fatalf("settle %s: %v", id, err)
+		}
+	}
+
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	renderer := &testRenderer{failOnce: map[string]bool{"o2": true}}
+	invoices := NewInvoiceUsecase(o.(*OrderUsecase).repository, repository.NewInvoiceMemoryRepository(), renderer, domain.InvoicePolicy{Location: jakarta}, clock)
+
+	steps := []struct {
+		name    string
+		orderId string
+		// at is when the invoice is asked for, unchanged when zero
+		at   time.Time
+		code codes.Code
+		want string
+	}{
+		{"first invoice", "o1", time.Time{}, codes.OK, "INV-2023-000001"},
+		{"renderer failing", "o2", time.Time{}, codes.Unknown, ""},
+		{"unpaid order", "o4", time.Time{}, codes.FailedPrecondition, ""},
+		{"missing order", "o9", time.Time{}, codes.NotFound, ""},
+		{"next invoice takes the number not used", "o3", time.Time{}, codes.OK, "INV-2023-000002"},
+		{"retried invoice", "o2", time.Time{}, codes.OK, "INV-2023-000003"},
+		{"invoice asked for again", "o1", time.Time{}, codes.OK, "INV-2023-000001"},
+		// the new year has begun in Jakarta, not in UTC
+		{"first invoice of the year", "o5", time.Date(2023, 12, 31, 17, 30, 0, 0, time.UTC), codes.OK, "INV-2024-000001"},
+	}
+
+	for _, step := range steps {
+		if !step.at.IsZero() {
+			clock.Set(step.at)
+		}
+
+		invoice, err := invoices.Get(ctx, &pb.InvoiceRequest{OrderId: step.orderId, Format: variable.InvoiceFormatHTML})
+		if code := status.Code(err); code != step.code {
+			t.Fatalf("%s: Get(%s) = %v; want %s", step.name, step.orderId, err, step.code)
+		}
+		if err != nil {
+			continue
+		}
+		if invoice.InvoiceNumber != step.want || string(invoice.Content) != "<p>"+step.want+"</p>" {
+			t.Errorf("%s: invoice of %s = %s %q; want %s", step.name, step.orderId, invoice.InvoiceNumber, invoice.Content, step.want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// LedgerBroker posts to the ledger the money movements of the orders of the
+// events published to it. The journals are derived from the order the event
+// carries and identified by the movement they record, so that an event
+// published again, or late, posts nothing twice.
+type LedgerBroker struct {
+	ledger domain.LedgerRepository
+	clock  helper.Clock
+}
+
+func NewLedgerBroker(ledger domain.LedgerRepository, clock helper.Clock) domain.Broker {
+	return &LedgerBroker{
+		ledger: ledger,
+		clock:  clock,
+	}
+}
+
+func (l *LedgerBroker) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	order := &pb.Order{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(event.Payload, order); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrEventRejected, err)
+	}
+
+	now := helper.Unix(l.clock)
+	for _, journal := range orderJournals(order) {
+		journal.RecordedAt = now
+		if _, err := l.ledger.Post(ctx, &journal); err != nil {
+			return fmt.Errorf("post %s: %w", journal.JournalId, err)
+		}
+	}
+
+	return nil
+}
+
+func (l *LedgerBroker) Close() error {
+	return nil
+}
+
+// orderJournals returns the journals of the money movements of order so
+// far. Money received is deposited until the order settles, when its total
+// becomes revenue and tax; what exceeds the total is owed to the customer,
+// and refunds give that back first. A movement yields the same journal
+// whichever later state of the order it is derived from.
+func orderJournals(order *pb.Order) (journals []domain.Journal) {
+	post := func(kind string, movement string, postedAt int64, memo string, lines ...domain.JournalLine) {
+		journal := domain.Journal{
+			JournalId: kind + ":" + order.OrderId,
+			Kind:      kind,
+			OrderId:   order.OrderId,
+			Currency:  order.Currency,
+			Memo:      memo,
+			PostedAt:  postedAt,
+		}
+		if movement != "" {
+			journal.JournalId += ":" + movement
+		}
+		for _, line := range lines {
+			if line.Debit != 0 || line.Credit != 0 {
+				journal.Lines = append(journal.Lines, line)
+			}
+		}
+
+		journals = append(journals, journal)
+	}
+
+	surplusAccount := variable.AccountCustomerCredit
+	if order.GetOverpayment().GetDisposition() == variable.OverpaymentRefundDue {
+		surplusAccount = variable.AccountRefundsPayable
+	}
+
+	// orders settled before receipts existed were paid in one go, as
+	// applyReceipt records them
+	receipts := order.Receipts
+	if len(receipts) == 0 && isSettled(order.Status) && order.PaidTotal > 0 {
+		receipts = []*pb.OrderReceipt{{TransactionId: order.GetPayment().GetOrderId(), Amount: order.PaidTotal, ReceivedAt: order.SettlementTime}}
+	}
+
+	total, paid := order.Total, int64(0)
+	for _, receipt := range receipts {
+		deposit := clamp(total-paid, 0, receipt.Amount)
+		paid += receipt.Amount
+		post(variable.JournalReceipt, receipt.TransactionId, receipt.ReceivedAt, fmt.Sprintf("received %d under %s", receipt.Amount, receipt.Reference),
+			debit(variable.AccountGatewayClearing, receipt.Amount),
+			credit(variable.AccountCustomerDeposits, deposit),
+			credit(surplusAccount, receipt.Amount-deposit),
+		)
+
+		if receipt.Fee > 0 {
+			post(variable.JournalFee, receipt.TransactionId, receipt.ReceivedAt, fmt.Sprintf("gateway fee of %s", receipt.TransactionId),
+				debit(variable.AccountGatewayFees, receipt.Fee),
+				credit(variable.AccountGatewayClearing, receipt.Fee),
+			)
+		}
+	}
+
+	if !isSettled(order.Status) {
+		return
+	}
+
+	if total > 0 {
+		post(variable.JournalSettlement, "", order.SettlementTime, "order settled",
+			debit(variable.AccountCustomerDeposits, total),
+			credit(variable.AccountSales, total-order.TaxTotal),
+			credit(variable.AccountTaxPayable, order.TaxTotal),
+		)
+	}
+	if amount := order.GetPlanChange().GetCredit(); amount > 0 {
+		post(variable.JournalCredit, "", order.SettlementTime, "unused value of the previous plan credited",
+			debit(variable.AccountCreditsGranted, amount),
+			credit(variable.AccountCustomerCredit, amount),
+		)
+	}
+
+	surplus, refunded, taxRefunded := clamp(paid-total, 0, paid), int64(0), int64(0)
+	for _, refund := range order.Refunds {
+		owed := clamp(surplus-refunded, 0, refund.Amount)
+		refunded += refund.Amount
+		// the tax charged on what is given back is no longer due, rounded
+		// over the refunds so far so that refunding the total in parts
+		// gives all of it back
+		var tax int64
+		if total > 0 {
+			tax = clamp(refunded-surplus, 0, total)*order.TaxTotal/total - taxRefunded
+			taxRefunded += tax
+		}
+		post(variable.JournalRefund, refund.RefundId, refund.CreatedAt, refund.Reason,
+			debit(surplusAccount, owed),
+			debit(variable.AccountTaxPayable, tax),
+			debit(variable.AccountRefunds, refund.Amount-owed-tax),
+			credit(variable.AccountGatewayClearing, refund.Amount),
+		)
+	}
+
+	return
+}
+
+func debit(account string, amount int64) domain.JournalLine {
+	return domain.JournalLine{Account: account, Debit: amount}
+}
+
+func credit(account string, amount int64) domain.JournalLine {
+	return domain.JournalLine{Account: account, Credit: amount}
+}
+
+// clamp returns n bounded by low and high.
+func clamp(n int64, low int64, high int64) int64 {
+	if n < low {
+		return low
+	}
+	if n > high {
+		return high
+	}
+
+	return n
+}
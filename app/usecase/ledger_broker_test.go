@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"order/app/repository"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestRefundJournals(t *testing.T) {
+	receipt := func(transactionId string, amount int64, fee int64) *pb.OrderReceipt {
+		return &pb.OrderReceipt{TransactionId: transactionId, Reference: "o1", Amount: amount, Fee: fee, ReceivedAt: 100}
+	}
+	refund := func(refundId string, amount int64) *pb.OrderRefund {
+		return &pb.OrderRefund{RefundId: refundId, Amount: amount, Reason: "refunded", CreatedAt: 200}
+	}
+
+	tests := []struct {
+		name     string
+		order    *pb.Order
+		balances map[string]int64
+	}{
+		{
+			name: "full refund",
+			order: &pb.Order{Total: 11000, TaxTotal: 1000, PaidTotal: 11000, Status: variable.PaymentStatusRefunded,
+				Receipts: []*pb.OrderReceipt{receipt("t1", 11000, 300)},
+				Refunds:  []*pb.OrderRefund{refund("r1", 11000)}},
+			balances: map[string]int64{
+				variable.AccountGatewayClearing: -300, variable.AccountGatewayFees: 300,
+				variable.AccountSales: -10000, variable.AccountRefunds: 10000, variable.AccountTaxPayable: 0,
+			},
+		},
+		{
+			name: "tax rounded over partial refunds",
+			order: &pb.Order{Total: 10000, TaxTotal: 909, PaidTotal: 10000, Status: variable.PaymentStatusRefunded,
+				Receipts: []*pb.OrderReceipt{receipt("t1", 10000, 0)},
+				Refunds:  []*pb.OrderRefund{refund("r1", 3333), refund("r2", 3333), refund("r3", 3334)}},
+			balances: map[string]int64{
+				variable.AccountGatewayClearing: 0, variable.AccountSales: -9091, variable.AccountRefunds: 9091, variable.AccountTaxPayable: 0,
+			},
+		},
+		{
+			name: "refund of an overpayment",
+			order: &pb.Order{Total: 10000, TaxTotal: 1000, PaidTotal: 12000, Status: variable.PaymentStatusPartiallyRefunded,
+				Overpayment: &pb.OrderOverpayment{Amount: 2000, Disposition: variable.OverpaymentRefundDue},
+				Receipts:    []*pb.OrderReceipt{receipt("t1", 12000, 0)},
+				Refunds:     []*pb.OrderRefund{refund("r1", 2000)}},
+			balances: map[string]int64{
+				variable.AccountGatewayClearing: 10000, variable.AccountRefundsPayable: 0,
+				variable.AccountSales: -9000, variable.AccountRefunds: 0, variable.AccountTaxPayable: -1000,
+			},
+		},
+		{
+			name: "refund beyond the overpayment",
+			order: &pb.Order{Total: 10000, TaxTotal: 1000, PaidTotal: 12000, Status: variable.PaymentStatusPartiallyRefunded,
+				Overpayment: &pb.OrderOverpayment{Amount: 2000, Disposition: variable.OverpaymentCredit},
+				Receipts:    []*pb.OrderReceipt{receipt("t1", 7000, 0), receipt("t2", 5000, 0)},
+				Refunds:     []*pb.OrderRefund{refund("r1", 7000)}},
+			balances: map[string]int64{
+				variable.AccountGatewayClearing: 5000, variable.AccountCustomerCredit: 0, variable.AccountCustomerDeposits: 0,
+				variable.AccountSales: -9000, variable.AccountRefunds: 4500, variable.AccountTaxPayable: -500,
+			},
+		},
+		{
+			name: "refund of a plan change",
+			order: &pb.Order{Total: 6000, PaidTotal: 6000, Status: variable.PaymentStatusPartiallyRefunded,
+				PlanChange: &pb.OrderPlanChange{Credit: 4000},
+				Receipts:   []*pb.OrderReceipt{receipt("t1", 6000, 0)},
+				Refunds:    []*pb.OrderRefund{refund("r1", 1000)}},
+			balances: map[string]int64{
+				variable.AccountGatewayClearing: 5000, variable.AccountCreditsGranted: 4000, variable.AccountCustomerCredit: -4000,
+				variable.AccountSales: -6000, variable.AccountRefunds: 1000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clock := helper.NewFakeClock(time.Unix(300, 0))
+			ledger := repository.NewLedgerMemoryRepository()
+			broker := NewLedgerBroker(ledger, clock)
+
+			tt.order.OrderId, tt.order.Currency, tt.order.SettlementTime = "o1", "IDR", 100
+			payload, err := protojson.Marshal(tt.order)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// published again, the event posts nothing twice
+			for i := 0; i < 2; i++ {
+				if err := broker.Publish(ctx, domain.OutboxEvent{Payload: payload}); err != nil {
+					t.Fatalf("Publish: %v", err)
+				}
+			}
+
+			check, err := NewLedgerUsecase(ledger).Check(ctx, &pb.LedgerCheckRequest{})
+			if err != nil || !check.Balanced {
+				t.Fatalf("Check = %v, %v; want a balanced ledger", check, err)
+			}
+
+			balances, err := ledger.Balances(ctx, "", "IDR", 0, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]int64{}
+			for _, balance := range balances {
+				got[balance.Account] = balance.Balance
+			}
+			for account, want := range tt.balances {
+				if got[account] != want {
+					t.Errorf("%s has a balance of %d; want %d", account, got[account], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"order/domain"
+	"order/pb"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LedgerUsecase reads the double-entry ledger the LedgerBroker posts the
+// money movements of orders to.
+type LedgerUsecase struct {
+	ledger domain.LedgerRepository
+}
+
+func NewLedgerUsecase(ledger domain.LedgerRepository) domain.LedgerUsecase {
+	return &LedgerUsecase{ledger: ledger}
+}
+
+func checkPeriod(from int64, to int64) error {
+	if from < 0 || to < 0 || (to > 0 && to <= from) {
+		return status.Error(codes.InvalidArgument, "to must be after from")
+	}
+
+	return nil
+}
+
+// Balances returns the balances of the accounts in req.Account, every
+// account without one, over the journals posted in the period from req.From
+// to req.To.
+func (l *LedgerUsecase) Balances(ctx context.Context, req *pb.LedgerBalanceRequest) (res *pb.LedgerBalanceResponse, err error) {
+	if err = checkPeriod(req.From, req.To); err != nil {
+		return
+	}
+
+	balances, err := l.ledger.Balances(ctx, req.Account, req.Currency, req.From, req.To)
+	if err != nil {
+		return
+	}
+
+	res = &pb.LedgerBalanceResponse{}
+	for _, balance := range balances {
+		res.Balances = append(res.Balances, &pb.LedgerBalance{
+			Account:  balance.Account,
+			Currency: balance.Currency,
+			Debit:    balance.Debit,
+			Credit:   balance.Credit,
+			Balance:  balance.Balance,
+		})
+	}
+
+	return
+}
+
+// Check proves the journals posted in the period from req.From to req.To
+// balance: each of them on its own, and all of them together in each
+// currency. The ledger is balanced when no journal has a problem.
+func (l *LedgerUsecase) Check(ctx context.Context, req *pb.LedgerCheckRequest) (res *pb.LedgerCheckResponse, err error) {
+	if err = checkPeriod(req.From, req.To); err != nil {
+		return
+	}
+
+	res = &pb.LedgerCheckResponse{}
+	totals := map[string]*pb.LedgerBalance{}
+	seen := map[string]bool{}
+	err = l.ledger.Journals(ctx, req.From, req.To, func(journal domain.Journal) error {
+		res.Journals++
+		res.Lines += int64(len(journal.Lines))
+
+		if seen[journal.JournalId] {
+			res.Problems = append(res.Problems, &pb.LedgerProblem{JournalId: journal.JournalId, Detail: "posted more than once"})
+		}
+		seen[journal.JournalId] = true
+		if err := journal.Check(); err != nil {
+			res.Problems = append(res.Problems, &pb.LedgerProblem{JournalId: journal.JournalId, Detail: err.Error()})
+		}
+
+		total := totals[journal.Currency]
+		if total == nil {
+			total = &pb.LedgerBalance{Currency: journal.Currency}
+			totals[journal.Currency] = total
+		}
+		for _, line := range journal.Lines {
+			total.Debit += line.Debit
+			total.Credit += line.Credit
+			total.Balance += line.Debit - line.Credit
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, total := range totals {
+		res.Totals = append(res.Totals, total)
+	}
+	sort.Slice(res.Totals, func(i, j int) bool { return res.Totals[i].Currency < res.Totals[j].Currency })
+
+	// balanced journals add up to balanced totals, checked anyway as a
+	// second proof
+	res.Balanced = len(res.Problems) == 0
+	for _, total := range res.Totals {
+		res.Balanced = res.Balanced && total.Balance == 0
+	}
+
+	return
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// notificationLease is how long, in seconds, a notification being sent is
+// left to its sender before the event published again sends it anew.
+const notificationLease = 5 * 60
+
+// NotificationBroker notifies buyers of the order events published to it,
+// rendering a template per event and sending it through every channel the
+// buyer has a contact for. Each notification is claimed before it is sent,
+// so an event published again only sends the notifications that failed.
+type NotificationBroker struct {
+	repository domain.NotificationRepository
+	templates  domain.NotificationTemplates
+	// channels are the notification channels by name, see
+	// variable.NotificationChannels.
+	channels map[string]domain.NotificationChannel
+	clock    helper.Clock
+}
+
+func NewNotificationBroker(repo domain.NotificationRepository, templates domain.NotificationTemplates, channels map[string]domain.NotificationChannel, clock helper.Clock) domain.Broker {
+	return &NotificationBroker{
+		repository: repo,
+		templates:  templates,
+		channels:   channels,
+		clock:      clock,
+	}
+}
+
+// notificationTemplates returns the templates notified on the event of
+// eventType about order.
+func notificationTemplates(eventType string, order *pb.Order) []string {
+	switch eventType {
+	case variable.OrderEventCreated:
+		if order.GetPayment().GetVaNumber() != "" {
+			return []string{variable.NotificationOrderCreated, variable.NotificationVAIssued}
+		}
+
+		return []string{variable.NotificationOrderCreated}
+	case variable.OrderEventPaymentRetried:
+		if order.GetPayment().GetVaNumber() != "" {
+			return []string{variable.NotificationVAIssued}
+		}
+	case variable.OrderEventSettled:
+		return []string{variable.NotificationPaymentSettled}
+	case variable.OrderEventExpired:
+		return []string{variable.NotificationOrderExpired}
+	case variable.OrderEventRenewalReminder:
+		return []string{variable.NotificationRenewalReminder}
+	case variable.OrderEventRenewalFinalNotice:
+		return []string{variable.NotificationRenewalFinalNotice}
+	case variable.OrderEventRenewalLapsed:
+		return []string{variable.NotificationRenewalLapsed}
+	}
+
+	return nil
+}
+
+// recipient returns the contact of buyer on channel, empty when there is
+// none.
+func recipient(channel string, buyer *pb.OrderBuyer) string {
+	switch channel {
+	case variable.NotificationChannelEmail:
+		return buyer.GetEmail()
+	case variable.NotificationChannelSMS:
+		return buyer.GetPhone()
+	}
+
+	return ""
+}
+
+// Publish returns the last error sending a notification, so that the event
+// is published again and the failed notifications retried.
+func (n *NotificationBroker) Publish(ctx context.Context, event domain.OutboxEvent) (err error) {
+	order := &pb.Order{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(event.Payload, order); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrEventRejected, err)
+	}
+
+	data := domain.NotificationData{
+		Order:   order,
+		Buyer:   order.GetBuyer(),
+		Product: order.GetProduct(),
+		Payment: order.GetPayment(),
+	}
+	for _, template := range notificationTemplates(event.Type, order) {
+		for _, channel := range variable.NotificationChannels {
+			if notifyErr := n.notify(ctx, event, template, channel, data); notifyErr != nil {
+				err = notifyErr
+			}
+		}
+	}
+
+	return
+}
+
+// notify sends the notification of template through channel, unless it was
+// already sent.
+func (n *NotificationBroker) notify(ctx context.Context, event domain.OutboxEvent, template string, channel string, data domain.NotificationData) error {
+	sender, ok := n.channels[channel]
+	to := recipient(channel, data.Buyer)
+	if !ok || to == "" {
+		return nil
+	}
+
+	message, found, renderErr := n.templates.Render(template, channel, data.Buyer.GetLocale(), data)
+	if !found && renderErr == nil {
+		return nil
+	}
+
+	now := helper.Unix(n.clock)
+	notification := domain.Notification{
+		NotificationId: event.EventId + ":" + template + ":" + channel,
+		EventId:        event.EventId,
+		OrderId:        event.OrderId,
+		CustomerId:     data.Buyer.GetCustomerId(),
+		Template:       template,
+		Channel:        channel,
+		Recipient:      to,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	claimed, err := n.repository.Claim(ctx, &notification, now-notificationLease)
+	if err != nil || !claimed {
+		return err
+	}
+
+	var sendErr error
+	if renderErr == nil {
+		message.To = to
+		sendErr = sender.Send(ctx, message)
+	}
+
+	notification.UpdatedAt = helper.Unix(n.clock)
+	switch {
+	case renderErr != nil:
+		// sending again renders the same template, failing the same way
+		log.Printf("notification %s of order %s: %v", notification.NotificationId, event.OrderId, renderErr)
+		notification.Status = variable.NotificationFailed
+		notification.Error = renderErr.Error()
+	case sendErr != nil:
+		notification.Status = variable.NotificationFailed
+		notification.Error = sendErr.Error()
+	default:
+		notification.Status = variable.NotificationSent
+		notification.SentAt = notification.UpdatedAt
+	}
+
+	if err := n.repository.Complete(ctx, notification); err != nil {
+		return err
+	}
+
+	return sendErr
+}
+
+func (n *NotificationBroker) Close() (err error) {
+	for _, channel := range n.channels {
+		if closeErr := channel.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"order/domain"
+	"order/variable"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// couponDiscount checks that coupon applies to an order of items in currency
+// placed at now and returns the discount it grants, spreading it over the
+// eligible items pro rata.
+func couponDiscount(coupon *domain.Coupon, items []domain.OrderItem, currency string, now int64) (discount domain.OrderDiscount, err error) {
+	if coupon.StartsAt > 0 && now < coupon.StartsAt {
+		return discount, status.Errorf(codes.FailedPrecondition, "coupon %s is not valid yet", coupon.Code)
+	}
+	if coupon.EndsAt > 0 && now >= coupon.EndsAt {
+		return discount, status.Errorf(codes.FailedPrecondition, "coupon %s has expired", coupon.Code)
+	}
+
+	var subtotal, eligible int64
+	for _, line := range items {
+		subtotal += line.LineTotal
+		if couponCovers(coupon, line.Product.ProductId) {
+			eligible += line.LineTotal
+		}
+	}
+
+	minimum := domain.NewMoney(coupon.Currency, coupon.MinOrderAmount)
+	cmp, err := domain.NewMoney(currency, subtotal).Cmp(minimum)
+	if err != nil {
+		return discount, status.Errorf(codes.FailedPrecondition, "coupon %s only applies to orders in %s", coupon.Code, coupon.Currency)
+	}
+	if cmp < 0 {
+		return discount, status.Errorf(codes.FailedPrecondition, "coupon %s requires an order of at least %s", coupon.Code, minimum)
+	}
+	if eligible == 0 {
+		return discount, status.Errorf(codes.FailedPrecondition, "coupon %s does not apply to any item of the order", coupon.Code)
+	}
+
+	amount := coupon.Amount
+	if coupon.Type == variable.CouponTypePercentage {
+		amount = eligible * coupon.Amount / 100
+	}
+	if amount > eligible {
+		amount = eligible
+	}
+
+	allocated := int64(0)
+	last := -1
+	for i := range items {
+		if !couponCovers(coupon, items[i].Product.ProductId) {
+			continue
+		}
+
+		share := amount * items[i].LineTotal / eligible
+		items[i].Discount += share
+		allocated += share
+		last = i
+	}
+
+	// the rounding remainder goes to the last eligible line
+	items[last].Discount += amount - allocated
+
+	discount = domain.OrderDiscount{
+		Code:        coupon.Code,
+		Description: coupon.Description,
+		Amount:      amount,
+	}
+
+	return
+}
+
+func couponCovers(coupon *domain.Coupon, productId string) bool {
+	if len(coupon.ProductIds) == 0 {
+		return true
+	}
+
+	for _, id := range coupon.ProductIds {
+		if id == productId {
+			return true
+		}
+	}
+
+	return false
+}
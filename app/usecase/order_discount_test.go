@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// saveCoupon stores a fixed 1000 IDR coupon SAVE with the usage caps given.
+func saveCoupon(t *testing.T, o domain.OrderUsecase, maxUses, maxUsesPerCustomer int64) domain.CouponRepository {
+	t.Helper()
+
+	coupons := o.(*OrderUsecase).coupons
+	coupon := &domain.Coupon{Code: "SAVE", Type: variable.CouponTypeFixed, Amount: 1000, Currency: "IDR", MaxUses: maxUses, MaxUsesPerCustomer: maxUsesPerCustomer}
+	if err := coupons.Save(context.Background(), coupon); err != nil {
+		t.Fatal(err)
+	}
+
+	return coupons
+}
+
+// couponOrder is the order of customer paid with payment orderId into the
+// virtual account va, redeeming the coupon SAVE.
+func couponOrder(clock *helper.FakeClock, customer, orderId, va string) *pb.OrderCreateRequest {
+	return &pb.OrderCreateRequest{
+		Buyer:      &pb.OrderBuyer{CustomerId: customer, Name: "Customer"},
+		Product:    &pb.OrderProduct{ProductId: "p1", Name: "Premium", Price: 10000},
+		Payment:    &pb.OrderPayment{PaymentType: "bank_transfer", OrderId: orderId, Bank: "bca", VaNumber: va},
+		TrxTime:    helper.Unix(clock),
+		PayExp:     helper.Unix(clock) + 3600,
+		CouponCode: "save",
+	}
+}
+
+func TestCouponCapsUnderConcurrency(t *testing.T) {
+	tests := []struct {
+		name               string
+		maxUses            int64
+		maxUsesPerCustomer int64
+		customers          []string
+		want               int
+	}{
+		{"global cap", 3, 0, []string{"c1", "c2", "c3", "c4", "c5", "c6", "c7", "c8"}, 3},
+		{"per customer cap", 0, 2, []string{"c1", "c1", "c1", "c1", "c2", "c2", "c2"}, 4},
+		{"both caps", 3, 1, []string{"c1", "c1", "c2", "c2", "c3", "c4", "c5"}, 3},
+		{"uncapped", 0, 0, []string{"c1", "c1", "c2", "c3"}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			o, clock := newTestUsecase(t, OrderOptions{})
+			coupons := saveCoupon(t, o, tt.maxUses, tt.maxUsesPerCustomer)
+
+			var wg sync.WaitGroup
+			errs := make([]error, len(tt.customers))
+			for i, customer := range tt.customers {
+				wg.Add(1)
+				go func(i int, customer string) {
+					defer wg.Done()
+					_, errs[i] = o.Save(ctx, couponOrder(clock, customer, fmt.Sprintf("o%d", i), ""))
+				}(i, customer)
+			}
+			wg.Wait()
+
+			saved := 0
+			for _, err := range errs {
+				switch {
+				case err == nil:
+					saved++
+				case status.Code(err) != codes.FailedPrecondition:
+					t.Errorf("Save = %v; want the coupon exhausted", err)
+				}
+			}
+			if saved != tt.want {
+				t.Errorf("%d orders redeemed the coupon; want %d", saved, tt.want)
+			}
+
+			coupon, err := coupons.FindOne(ctx, "SAVE")
+			if err != nil || coupon.Used != int64(tt.want) {
+				t.Errorf("coupon = %+v, %v; want %d uses", coupon, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCouponReleasedOnFailedSave(t *testing.T) {
+	ctx := context.Background()
+	o, clock := newTestUsecase(t, OrderOptions{})
+	coupons := saveCoupon(t, o, 1, 1)
+
+	// the virtual account of a pending order cannot be handed out again
+	first := couponOrder(clock, "c0", "o0", "8800001")
+	first.CouponCode = ""
+	if _, err := o.Save(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := o.Save(ctx, couponOrder(clock, "c1", "o1", "8800001")); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("Save into a taken virtual account = %v; want AlreadyExists", err)
+	}
+
+	if coupon, err := coupons.FindOne(ctx, "SAVE"); err != nil || coupon.Used != 0 {
+		t.Errorf("coupon after the failed save = %+v, %v; want its use given back", coupon, err)
+	}
+
+	// so that the customer can redeem it after all
+	order, err := o.Save(ctx, couponOrder(clock, "c1", "o2", "8800002"))
+	if err != nil {
+		t.Fatalf("Save after the failed save: %v", err)
+	}
+	if order.Total != 9000 {
+		t.Errorf("order total = %d; want 9000 after the coupon", order.Total)
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"order/domain"
+	"order/pb"
+)
+
+// grantable sums the days granted by the lines of order per product, in the
+// order the products first appear.
+func grantable(order *pb.Order) (products []domain.OrderProduct, days map[string]int64) {
+	days = map[string]int64{}
+	for _, line := range order.Items {
+		product := line.GetProduct()
+		if product.GetDuration() <= 0 || product.GetProductId() == "" {
+			continue
+		}
+
+		if _, ok := days[product.ProductId]; !ok {
+			products = append(products, domain.OrderProduct{ProductId: product.ProductId, Name: product.Name})
+		}
+		days[product.ProductId] += product.Duration * line.Quantity
+	}
+
+	return
+}
+
+// grantEntitlements grants the access bought by the settled order. Grants
+// are idempotent, so it is safe to call on every settlement notification.
+func (o *OrderUsecase) grantEntitlements(ctx context.Context, order *pb.Order, now int64) error {
+	customerId := order.GetBuyer().GetCustomerId()
+	if customerId == "" {
+		return nil
+	}
+
+	settledAt := order.SettlementTime
+	if settledAt == 0 {
+		settledAt = now
+	}
+
+	// a plan change ends the old plan as the new one starts
+	if change := order.GetPlanChange(); change != nil {
+		if err := o.entitlements.Terminate(ctx, customerId, change.FromProductId, order.OrderId, settledAt, now); err != nil {
+			return err
+		}
+	}
+
+	products, days := grantable(order)
+	for _, product := range products {
+		grant := domain.EntitlementGrant{
+			OrderId:   order.OrderId,
+			Days:      days[product.ProductId],
+			SettledAt: settledAt,
+		}
+		if err := o.entitlements.Grant(ctx, customerId, product, grant, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revokeEntitlements takes back the access granted by a refunded order.
+func (o *OrderUsecase) revokeEntitlements(ctx context.Context, order *pb.Order, now int64) error {
+	customerId := order.GetBuyer().GetCustomerId()
+	if customerId == "" {
+		return nil
+	}
+
+	products, _ := grantable(order)
+	for _, product := range products {
+		if err := o.entitlements.Revoke(ctx, customerId, product.ProductId, order.OrderId, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
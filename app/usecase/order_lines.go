@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"order/domain"
+	"order/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// lineTotal returns the total of quantity units at price, refusing the line
+// of item i when it does not fit in an amount.
+func lineTotal(i int, price int64, quantity int64) (int64, error) {
+	total, err := domain.NewMoney("", price).Mul(quantity)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "item %d: %d x %d is too large", i, quantity, price)
+	}
+
+	return total.Amount, nil
+}
+
+// buildLines validates the line items of a create request and prices them.
+// Requests from clients predating line items carry a single product, which
+// becomes one line with quantity 1.
+func buildLines(req *pb.OrderCreateRequest) (items []domain.OrderItem, err error) {
+	lines := req.Items
+	if len(lines) == 0 && req.Product != nil {
+		lines = []*pb.OrderItem{{Product: req.Product, Quantity: 1}}
+	}
+
+	if len(lines) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order has no items")
+	}
+
+	for i, line := range lines {
+		if line.GetProduct().GetProductId() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "item %d: product_id is required", i)
+		}
+
+		quantity := line.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		if quantity < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "item %d: quantity must be positive", i)
+		}
+
+		product := domain.OrderProduct{
+			ProductId:   line.Product.ProductId,
+			Name:        line.Product.Name,
+			Price:       line.Product.Price,
+			Duration:    line.Product.Duration,
+			Description: line.Product.Description,
+			Category:    line.Product.Category,
+		}
+		if product.Price < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "item %d: price must not be negative", i)
+		}
+
+		total, err := lineTotal(i, product.Price, quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, domain.OrderItem{
+			Product:   product,
+			Quantity:  quantity,
+			UnitPrice: product.Price,
+			LineTotal: total,
+		})
+	}
+
+	return
+}
+
+// catalogLines replaces the products of items with the products of the
+// catalog, and reprices the lines. A price, duration or category sent by the
+// client must match the catalog, the name and description are taken from it.
+func (o *OrderUsecase) catalogLines(ctx context.Context, items []domain.OrderItem, currency string) error {
+	for i := range items {
+		line := &items[i]
+		sent := line.Product
+
+		product, err := o.options.Catalog.FindProduct(ctx, sent.ProductId)
+		if err != nil {
+			return err
+		}
+		if product == nil {
+			return status.Errorf(codes.InvalidArgument, "item %d: product %s is not in the catalog", i, sent.ProductId)
+		}
+
+		sold := product.Currency
+		if sold == "" {
+			sold = o.options.Currency
+		}
+		if sold != currency {
+			return status.Errorf(codes.InvalidArgument, "item %d: product %s is sold in %s, not %s", i, sent.ProductId, sold, currency)
+		}
+		if sent.Price != 0 && sent.Price != product.Price {
+			return status.Errorf(codes.InvalidArgument, "item %d: product %s costs %d, not %d", i, sent.ProductId, product.Price, sent.Price)
+		}
+		if sent.Duration != 0 && sent.Duration != product.Duration {
+			return status.Errorf(codes.InvalidArgument, "item %d: product %s lasts %d days, not %d", i, sent.ProductId, product.Duration, sent.Duration)
+		}
+		if sent.Category != "" && sent.Category != product.Category {
+			return status.Errorf(codes.InvalidArgument, "item %d: product %s is in category %q, not %q", i, sent.ProductId, product.Category, sent.Category)
+		}
+
+		line.Product = domain.OrderProduct{
+			ProductId:   product.ProductId,
+			Name:        product.Name,
+			Price:       product.Price,
+			Duration:    product.Duration,
+			Description: product.Description,
+			Category:    product.Category,
+		}
+		line.UnitPrice = product.Price
+		if line.LineTotal, err = lineTotal(i, product.Price, line.Quantity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
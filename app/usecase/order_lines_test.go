@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testCatalog is a ProductCatalog selling the products it maps.
+type testCatalog map[string]*domain.CatalogProduct
+
+func (c testCatalog) FindProduct(ctx context.Context, productId string) (*domain.CatalogProduct, error) {
+	return c[productId], nil
+}
+
+func TestCatalogCurrency(t *testing.T) {
+	catalog := testCatalog{
+		"default": {ProductId: "default", Name: "Premium", Price: 150000, Duration: 30},
+		"usd":     {ProductId: "usd", Name: "Premium", Price: 1000, Duration: 30, Currency: "USD"},
+	}
+
+	tests := []struct {
+		name     string
+		product  string
+		currency string
+		price    int64
+	}{
+		{"default currency product in the default currency", "default", "", 150000},
+		{"default currency product in another currency", "default", "USD", 0},
+		{"product in its currency", "usd", "USD", 1000},
+		{"product in the default currency", "usd", "IDR", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, clock := newTestUsecase(t, OrderOptions{Catalog: catalog})
+			quote, err := o.Quote(context.Background(), &pb.OrderCreateRequest{
+				Buyer:    &pb.OrderBuyer{CustomerId: "c1", Name: "Customer"},
+				Product:  &pb.OrderProduct{ProductId: tt.product},
+				Currency: tt.currency,
+				TrxTime:  helper.Unix(clock),
+			})
+
+			if tt.price == 0 {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("Quote = %v, %v; want InvalidArgument", quote, err)
+				}
+				return
+			}
+			if err != nil || quote.GetPayload().GetTotal() != tt.price {
+				t.Errorf("Quote = %v, %v; want a total of %d", quote, err, tt.price)
+			}
+		})
+	}
+}
+
+func TestCatalogPricing(t *testing.T) {
+	catalog := testCatalog{
+		"premium": {ProductId: "premium", Name: "Premium", Price: 150000, Duration: 30, Category: "digital", Description: "Monthly"},
+	}
+
+	tests := []struct {
+		name    string
+		product *pb.OrderProduct
+		gross   int64
+		code    codes.Code
+	}{
+		{"product id only", &pb.OrderProduct{ProductId: "premium"}, 0, codes.OK},
+		{"the catalog name replaces the one sent", &pb.OrderProduct{ProductId: "premium", Name: "Cheap"}, 0, codes.OK},
+		{"matching price, duration and category", &pb.OrderProduct{ProductId: "premium", Price: 150000, Duration: 30, Category: "digital"}, 0, codes.OK},
+		{"matching gross amount", &pb.OrderProduct{ProductId: "premium"}, 300000, codes.OK},
+		{"lowered price", &pb.OrderProduct{ProductId: "premium", Price: 1000}, 0, codes.InvalidArgument},
+		{"longer duration", &pb.OrderProduct{ProductId: "premium", Duration: 365}, 0, codes.InvalidArgument},
+		{"other category", &pb.OrderProduct{ProductId: "premium", Category: "books"}, 0, codes.InvalidArgument},
+		{"unknown product", &pb.OrderProduct{ProductId: "missing", Price: 1000}, 0, codes.InvalidArgument},
+		{"gross amount of a lower price", &pb.OrderProduct{ProductId: "premium"}, 150000, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, clock := newTestUsecase(t, OrderOptions{Catalog: catalog})
+			order, err := o.Save(context.Background(), &pb.OrderCreateRequest{
+				Buyer:   &pb.OrderBuyer{CustomerId: "c1", Name: "Customer"},
+				Items:   []*pb.OrderItem{{Product: tt.product, Quantity: 2}},
+				Payment: &pb.OrderPayment{PaymentType: "bank_transfer", OrderId: "o1", GrossAmount: tt.gross},
+				TrxTime: helper.Unix(clock),
+				PayExp:  helper.Unix(clock) + 3600,
+			})
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("Save = %v; want %s", err, tt.code)
+			}
+			if err != nil {
+				return
+			}
+
+			line := order.Items[0]
+			if product := line.Product; product.Name != "Premium" || product.Price != 150000 || product.Duration != 30 ||
+				product.Category != "digital" || product.Description != "Monthly" {
+				t.Errorf("line product = %v; want the catalog product", product)
+			}
+			if line.UnitPrice != 150000 || line.LineTotal != 300000 || order.Total != 300000 {
+				t.Errorf("order = %v; want 2 x 150000", order)
+			}
+		})
+	}
+}
+
+func TestBuildLines(t *testing.T) {
+	product := func(id string, price int64) *pb.OrderProduct {
+		return &pb.OrderProduct{ProductId: id, Name: id, Price: price}
+	}
+
+	tests := []struct {
+		name   string
+		req    *pb.OrderCreateRequest
+		totals []int64
+	}{
+		{"single product of older clients", &pb.OrderCreateRequest{Product: product("p1", 10000)}, []int64{10000}},
+		{"items win over the product", &pb.OrderCreateRequest{Product: product("p1", 10000), Items: []*pb.OrderItem{{Product: product("p2", 500), Quantity: 3}}}, []int64{1500}},
+		{"quantity defaults to one", &pb.OrderCreateRequest{Items: []*pb.OrderItem{{Product: product("p1", 700)}}}, []int64{700}},
+		{"several lines", &pb.OrderCreateRequest{Items: []*pb.OrderItem{{Product: product("p1", 700), Quantity: 2}, {Product: product("p2", 0), Quantity: 5}}}, []int64{1400, 0}},
+		{"no items", &pb.OrderCreateRequest{}, nil},
+		{"missing product id", &pb.OrderCreateRequest{Items: []*pb.OrderItem{{Product: product("", 700)}}}, nil},
+		{"negative quantity", &pb.OrderCreateRequest{Items: []*pb.OrderItem{{Product: product("p1", 700), Quantity: -1}}}, nil},
+		{"negative price", &pb.OrderCreateRequest{Items: []*pb.OrderItem{{Product: product("p1", -700)}}}, nil},
+		{"line total overflowing", &pb.OrderCreateRequest{Items: []*pb.OrderItem{{Product: product("p1", 1<<40), Quantity: 1 << 30}}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := buildLines(tt.req)
+			if tt.totals == nil {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("buildLines = %+v, %v; want InvalidArgument", items, err)
+				}
+				return
+			}
+			if err != nil || len(items) != len(tt.totals) {
+				t.Fatalf("buildLines = %+v, %v; want %d lines", items, err, len(tt.totals))
+			}
+			for i, line := range items {
+				if line.LineTotal != tt.totals[i] || line.UnitPrice*line.Quantity != line.LineTotal {
+					t.Errorf("line %d = %+v; want a total of %d", i, line, tt.totals[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOrderTotalOverflow(t *testing.T) {
+	o, clock := newTestUsecase(t, OrderOptions{})
+	huge := &pb.OrderProduct{ProductId: "p1", Name: "Premium", Price: 1 << 62}
+
+	// each line fits, their sum does not
+	_, err := o.Quote(context.Background(), &pb.OrderCreateRequest{
+		Buyer:   &pb.OrderBuyer{CustomerId: "c1", Name: "Customer"},
+		Items:   []*pb.OrderItem{{Product: huge, Quantity: 1}, {Product: huge, Quantity: 1}},
+		TrxTime: helper.Unix(clock),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Quote of an order beyond the largest amount = %v; want InvalidArgument", err)
+	}
+}
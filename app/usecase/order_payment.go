@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"order/domain"
+	"order/pb"
+	"order/variable"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// settle records the money notified by the settlement req, which pays
+// req.GrossAmount, or what is left to pay without one, to the attempt
+// req.OrderId, the gateway keeping req.Fee of it. Notifications without a
+// transaction ID are told apart by their settlement time, so payments of an
+// amount need one or the other. The order stays partially paid until its
+// total is covered.
+func (o *OrderUsecase) settle(ctx context.Context, req *pb.OrderChangeStatus, updatedTime int64) (affected bool, err error) {
+	if req.GrossAmount < 0 {
+		return false, status.Error(codes.InvalidArgument, "gross_amount must not be negative")
+	}
+	if req.Fee < 0 || (req.GrossAmount > 0 && req.Fee > req.GrossAmount) {
+		return false, status.Error(codes.InvalidArgument, "fee must be between 0 and gross_amount")
+	}
+	// two of them would be taken for the same payment
+	if req.GrossAmount > 0 && req.TransactionId == "" && req.SettlementTime == 0 {
+		return false, status.Error(codes.InvalidArgument, "a payment of gross_amount needs a transaction_id or a settlement_time")
+	}
+
+	receipt := &domain.OrderReceipt{
+		TransactionId: req.TransactionId,
+		Reference:     req.OrderId,
+		Amount:        req.GrossAmount,
+		Reason:        req.Reason,
+		ReceivedAt:    req.SettlementTime,
+		Fee:           req.Fee,
+	}
+	if receipt.TransactionId == "" {
+		receipt.TransactionId = fmt.Sprintf("%s@%d", req.OrderId, req.SettlementTime)
+	}
+	if receipt.ReceivedAt == 0 {
+		receipt.ReceivedAt = updatedTime
+	}
+
+	order, affected, err := o.repository.RecordPayment(ctx, receipt, o.options.Payments, updatedTime, req.ExpectedVersion)
+	if err != nil {
+		return affected, abortOnConflict(err)
+	}
+
+	// granting on repeated notifications too lets them retry a failed grant
+	if order != nil && order.Status == variable.PaymentStatusSettlement {
+		err = o.grantEntitlements(ctx, order, updatedTime)
+	}
+
+	return
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPayment opens a new payment attempt on a pending or expired order,
+// e.g. with another bank once its virtual account expired, for what is left
+// to pay. Without a payment type or bank the last attempt is repeated, and
+// without pay_exp the new attempt is open as long as the last one was. With
+// a user ID, only the orders of that customer are retried.
+func (o *OrderUsecase) RetryPayment(ctx context.Context, req *pb.RetryPaymentRequest) (res *pb.Order, err error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	found, err := o.repository.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: req.OrderId})
+	if err != nil {
+		return
+	}
+	if found.IsEmpty || (req.UserId != "" && found.Payload.GetBuyer().GetCustomerId() != req.UserId) {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.OrderId)
+	}
+
+	order := found.Payload
+	if order.Status != variable.PaymentStatusPending && order.Status != variable.PayementStatusExpire {
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s is %s, only pending or expired orders can be paid again", req.OrderId, order.Status)
+	}
+
+	now := helper.Unix(o.clock)
+	attempt := &domain.PaymentAttempt{
+		Reference:   fmt.Sprintf("%s-%d", order.OrderId, len(order.Attempts)+1),
+		PaymentType: req.PaymentType,
+		Bank:        req.Bank,
+		VaNumber:    req.VaNumber,
+		Amount:      order.Total - order.PaidTotal,
+		ExpiresAt:   req.PayExp,
+		Status:      variable.PaymentAttemptActive,
+		CreatedAt:   now,
+	}
+
+	if last := lastAttempt(order); last != nil {
+		if attempt.PaymentType == "" && attempt.Bank == "" {
+			attempt.PaymentType, attempt.Bank = last.PaymentType, last.Bank
+		}
+		if window := last.ExpiresAt - last.CreatedAt; attempt.ExpiresAt == 0 && last.ExpiresAt > 0 && window > 0 {
+			attempt.ExpiresAt = now + window
+		}
+	}
+	if attempt.ExpiresAt != 0 && attempt.ExpiresAt <= now {
+		return nil, status.Error(codes.InvalidArgument, "pay_exp must be in the future")
+	}
+
+	err = o.openAttempt(ctx, attempt, order.Currency, func() (err error) {
+		res, err = o.repository.RetryPayment(ctx, order.OrderId, attempt, req.ExpectedVersion)
+
+		return
+	})
+	switch {
+	case errors.Is(err, domain.ErrNotRetryable):
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s: %v", req.OrderId, err)
+	case err != nil:
+		return nil, abortOnConflict(err)
+	case res == nil:
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.OrderId)
+	}
+
+	return
+}
+
+// lastAttempt returns the latest payment attempt of order, nil when it has
+// none.
+func lastAttempt(order *pb.Order) *pb.PaymentAttempt {
+	if len(order.Attempts) == 0 {
+		return nil
+	}
+
+	return order.Attempts[len(order.Attempts)-1]
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPartialPayments(t *testing.T) {
+	payment := func(transactionId string, settlementTime int64, amount int64) *pb.OrderChangeStatus {
+		return &pb.OrderChangeStatus{
+			OrderId:        "o1",
+			Status:         variable.PaymentStatusSettlement,
+			TransactionId:  transactionId,
+			SettlementTime: settlementTime,
+			GrossAmount:    amount,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		payments []*pb.OrderChangeStatus
+		paid     int64
+		status   string
+	}{
+		{"told apart by transaction", []*pb.OrderChangeStatus{payment("t1", 0, 4000), payment("t2", 0, 6000)}, 10000, variable.PaymentStatusSettlement},
+		{"told apart by settlement time", []*pb.OrderChangeStatus{payment("", 100, 4000), payment("", 200, 3000)}, 7000, variable.PaymentStatusPartiallyPaid},
+		{"replayed transaction", []*pb.OrderChangeStatus{payment("t1", 100, 4000), payment("t1", 200, 4000)}, 4000, variable.PaymentStatusPartiallyPaid},
+		{"replayed settlement time", []*pb.OrderChangeStatus{payment("", 100, 4000), payment("", 100, 4000)}, 4000, variable.PaymentStatusPartiallyPaid},
+		{"rest of the total", []*pb.OrderChangeStatus{payment("t1", 0, 4000), payment("", 0, 0)}, 10000, variable.PaymentStatusSettlement},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			o, clock := newTestUsecase(t, OrderOptions{})
+			saveTestOrder(t, o, clock)
+
+			for _, req := range tt.payments {
+				if _, err := o.ChangeStatus(ctx, req); err != nil {
+					t.Fatalf("ChangeStatus(%v): %v", req, err)
+				}
+			}
+
+			if order := findOrder(t, o, "o1"); order.PaidTotal != tt.paid || order.Status != tt.status {
+				t.Errorf("order paid %d and %s; want %d and %s", order.PaidTotal, order.Status, tt.paid, tt.status)
+			}
+		})
+	}
+}
+
+func TestPaymentWithoutTransactionOrTime(t *testing.T) {
+	ctx := context.Background()
+	o, clock := newTestUsecase(t, OrderOptions{})
+	saveTestOrder(t, o, clock)
+
+	req := &pb.OrderChangeStatus{OrderId: "o1", Status: variable.PaymentStatusSettlement, GrossAmount: 4000}
+	if _, err := o.ChangeStatus(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("payment without a transaction or a time = %v; want InvalidArgument", err)
+	}
+	if order := findOrder(t, o, "o1"); order.PaidTotal != 0 || order.Status != variable.PaymentStatusPending {
+		t.Errorf("order after a rejected payment = %v", order)
+	}
+}
+
+// saveTestOrder saves the pending order o1 of customer c1, a 10000 product
+// paid by bank transfer.
+func saveTestOrder(t *testing.T, o domain.OrderUsecase, clock *helper.FakeClock) {
+	t.Helper()
+
+	_, err := o.Save(context.Background(), &pb.OrderCreateRequest{
+		Buyer:   &pb.OrderBuyer{CustomerId: "c1", Name: "Customer"},
+		Product: &pb.OrderProduct{ProductId: "p1", Name: "Premium", Price: 10000, Duration: 30},
+		Payment: &pb.OrderPayment{PaymentType: "bank_transfer", OrderId: "o1", Bank: "bca", VaNumber: "8800001"},
+		TrxTime: helper.Unix(clock),
+		PayExp:  helper.Unix(clock) + 3600,
+	})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+}
+
+func TestRetryExpiredPartialPayment(t *testing.T) {
+	ctx := context.Background()
+	o, clock := newTestUsecase(t, OrderOptions{})
+	saveTestOrder(t, o, clock)
+
+	if _, err := o.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "o1", Status: variable.PaymentStatusSettlement, TransactionId: "t1", GrossAmount: 4000}); err != nil {
+		t.Fatal(err)
+	}
+	clock.Add(2 * time.Hour)
+	if expired, err := o.Expire(ctx, &pb.OrderExpireRequest{}); err != nil || expired != 1 {
+		t.Fatalf("Expire = %d, %v; want the partially paid order expired", expired, err)
+	}
+
+	// the new attempt asks for the rest, which settles the order
+	retried, err := o.RetryPayment(ctx, &pb.RetryPaymentRequest{OrderId: "o1", Bank: "bni", VaNumber: "8800002", PayExp: helper.Unix(clock) + 3600})
+	if err != nil || retried.GetPayment().GetGrossAmount() != 6000 {
+		t.Fatalf("RetryPayment = %v, %v; want 6000 left to pay", retried, err)
+	}
+	if _, err := o.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: retried.GetPayment().GetOrderId(), Status: variable.PaymentStatusSettlement, TransactionId: "t2", GrossAmount: 6000}); err != nil {
+		t.Fatal(err)
+	}
+	if order := findOrder(t, o, "o1"); order.Status != variable.PaymentStatusSettlement || order.PaidTotal != 10000 {
+		t.Errorf("order after paying the rest = %s, paid %d; want settled with 10000", order.Status, order.PaidTotal)
+	}
+}
@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// prorate returns the share part/whole of amount, rounded down. It computes
+// with big integers as amounts times seconds overflow int64.
+func prorate(amount int64, part int64, whole int64) int64 {
+	share := new(big.Int).Mul(big.NewInt(amount), big.NewInt(part))
+
+	return share.Quo(share, big.NewInt(whole)).Int64()
+}
+
+// unusedValue is the value of the access the entitlement still grants at
+// now: for each grant, what was kept of its order line pro rata of the time
+// left. It also returns the latest order granting the access.
+func (o *OrderUsecase) unusedValue(ctx context.Context, entitlement *domain.Entitlement, now int64) (value int64, latest *pb.Order, err error) {
+	// grants end early when a later plan change terminated them
+	ends := make([]int64, len(entitlement.Grants))
+	cut := entitlement.EndsAt
+	for i := len(entitlement.Grants) - 1; i >= 0; i-- {
+		grant := entitlement.Grants[i]
+		if grant.Days == 0 && grant.EndsAt < cut {
+			cut = grant.EndsAt
+		}
+
+		ends[i] = grant.EndsAt
+		if ends[i] > cut {
+			ends[i] = cut
+		}
+	}
+
+	for i, grant := range entitlement.Grants {
+		start := grant.StartsAt
+		if start < now {
+			start = now
+		}
+		if grant.Days <= 0 || ends[i] <= start {
+			continue
+		}
+
+		found, err := o.repository.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: grant.OrderId})
+		if err != nil {
+			return 0, nil, err
+		}
+		if found.IsEmpty {
+			continue
+		}
+
+		order := found.Payload
+		if latest != nil && order.Currency != latest.Currency {
+			return 0, nil, status.Errorf(codes.FailedPrecondition, "%s was bought in both %s and %s", entitlement.ProductId, latest.Currency, order.Currency)
+		}
+		latest = order
+
+		var paid int64
+		for _, line := range order.Items {
+			if line.GetProduct().GetProductId() == entitlement.ProductId {
+				paid += line.LineTotal - line.Discount
+			}
+		}
+
+		// refunds give back part of what was paid
+		if order.Total > 0 {
+			paid = prorate(paid, order.Total-order.RefundedTotal, order.Total)
+		}
+
+		value += prorate(paid, ends[i]-start, grant.EndsAt-grant.StartsAt)
+	}
+
+	return
+}
+
+// planChange builds the order moving the customer of req from one plan to
+// another at now, with the unused value of the current plan taken off the
+// new one, and the quote explaining its price. The order is not saved.
+func (o *OrderUsecase) planChange(ctx context.Context, req *pb.PlanChangeRequest, now int64) (order *domain.Order, quote *pb.PlanChangeQuote, err error) {
+	to := req.GetToProduct()
+	if req.CustomerId == "" || req.FromProductId == "" || to.GetProductId() == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "customer_id, from_product_id and to_product are required")
+	}
+	if to.ProductId == req.FromProductId {
+		return nil, nil, status.Error(codes.InvalidArgument, "the customer is already on this plan")
+	}
+	if to.Duration <= 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "to_product needs a duration to be a plan")
+	}
+
+	entitlement, err := o.entitlements.FindOne(ctx, req.CustomerId, req.FromProductId)
+	if err != nil {
+		return
+	}
+	if entitlement == nil || !entitlement.Active(now) {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "customer %s has no active %s plan", req.CustomerId, req.FromProductId)
+	}
+
+	unused, latest, err := o.unusedValue(ctx, entitlement, now)
+	if err != nil {
+		return
+	}
+
+	order, err = o.newOrder(ctx, &pb.OrderCreateRequest{
+		Buyer:      latest.GetBuyer(),
+		Items:      []*pb.OrderItem{{Product: to, Quantity: 1}},
+		Payment:    req.Payment,
+		TrxTime:    now,
+		PayExp:     req.PayExp,
+		Currency:   latest.GetCurrency(),
+		MerchantId: latest.GetMerchantId(),
+	}, now)
+	if err != nil {
+		return
+	}
+
+	// the unused value pays for the new plan up to its price
+	applied := unused
+	if applied > order.Subtotal {
+		applied = order.Subtotal
+	}
+	if applied > 0 {
+		order.Items[0].Discount = applied
+		order.Discounts = append(order.Discounts, domain.OrderDiscount{
+			Code:        variable.PlanChangeDiscountCode,
+			Description: fmt.Sprintf("unused %s", entitlement.ProductName),
+			Amount:      applied,
+		})
+		order.Total -= applied
+	}
+	order.PlanChange = &domain.OrderPlanChange{
+		FromProductId: req.FromProductId,
+		UnusedValue:   unused,
+		Credit:        unused - applied,
+	}
+
+	payment := req.Payment
+	if payment == nil {
+		payment = &pb.OrderPayment{}
+	}
+	o.priceOrder(order, payment)
+
+	quote = &pb.PlanChangeQuote{
+		CustomerId:       req.CustomerId,
+		FromProductId:    req.FromProductId,
+		ToProduct:        to,
+		Currency:         order.Currency,
+		RemainingSeconds: entitlement.EndsAt - now,
+		UnusedValue:      unused,
+		Price:            order.Subtotal,
+		Discount:         applied,
+		TaxTotal:         order.TaxTotal,
+		Total:            order.Total,
+		Credit:           order.PlanChange.Credit,
+		QuotedAt:         now,
+	}
+
+	return
+}
+
+// QuotePlanChange prices a plan change without committing to it, and
+// returns a token ChangePlan accepts to charge the quoted price.
+func (o *OrderUsecase) QuotePlanChange(ctx context.Context, req *pb.PlanChangeRequest) (res *pb.PlanChangeQuote, err error) {
+	now := helper.Unix(o.clock)
+	order, res, err := o.planChange(ctx, req, now)
+	if err != nil {
+		return
+	}
+
+	token, claims := o.signQuote(quoteKindPlanChange, order, now)
+	res.Token = token
+	res.ExpiresAt = claims.ExpiresAt
+
+	return
+}
+
+// ChangePlan creates the order moving a customer to another plan. The plans
+// are swapped when the order settles, right away when there is nothing to
+// pay.
+func (o *OrderUsecase) ChangePlan(ctx context.Context, req *pb.PlanChangeRequest) (res *pb.PlanChangeResponse, err error) {
+	now := helper.Unix(o.clock)
+
+	// a quoted change is prorated as of the quote
+	pricedAt := now
+	var claims *quoteClaims
+	if req.QuoteToken != "" {
+		claims, err = o.verifyQuote(req.QuoteToken, quoteKindPlanChange, now)
+		if err != nil {
+			return
+		}
+		pricedAt = claims.IssuedAt
+	}
+
+	order, quote, err := o.planChange(ctx, req, pricedAt)
+	if err != nil {
+		return
+	}
+	if claims != nil {
+		if err = claims.check(order); err != nil {
+			return
+		}
+		quote.Token, quote.ExpiresAt = req.QuoteToken, claims.ExpiresAt
+	}
+	order.CreatedAt, order.TrxTime = now, now
+
+	if order.OrderId == "" {
+		order.OrderId = helper.NewID()
+		order.Payment.OrderID = order.OrderId
+	}
+
+	if err = o.saveOrder(ctx, order); err != nil {
+		return
+	}
+
+	if order.Total == 0 {
+		settle := &pb.OrderChangeStatus{
+			OrderId:        order.OrderId,
+			Status:         variable.PaymentStatusSettlement,
+			SettlementTime: now,
+			Reason:         "covered by the unused plan",
+		}
+		if _, err = o.ChangeStatus(ctx, settle); err != nil {
+			return
+		}
+	}
+
+	found, err := o.repository.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: order.OrderId})
+	if err != nil {
+		return
+	}
+
+	res = &pb.PlanChangeResponse{Quote: quote, Payload: found.Payload}
+
+	return
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"order/pb"
+	"testing"
+	"time"
+)
+
+func TestProrate(t *testing.T) {
+	tests := []struct {
+		name                string
+		amount, part, whole int64
+		want                int64
+	}{
+		{"half", 10000, 15, 30, 5000},
+		{"rounded down", 10000, 1, 3, 3333},
+		{"just under a unit", 2, 1, 3, 0},
+		{"nothing left", 10000, 0, 30, 0},
+		{"everything left", 10000, 30, 30, 10000},
+		// amount times part overflows int64, the share does not
+		{"large amount over a year", 1e15, 365 * secondsPerDay, 365 * secondsPerDay, 1e15},
+		{"large amount over part of a year", 1e15, 100 * secondsPerDay, 365 * secondsPerDay, 273972602739726},
+		{"largest amount", math.MaxInt64, math.MaxInt64 - 1, math.MaxInt64, math.MaxInt64 - 1},
+	}
+	for _, tt := range tests {
+		if got := prorate(tt.amount, tt.part, tt.whole); got != tt.want {
+			t.Errorf("%s: prorate(%d, %d, %d) = %d; want %d", tt.name, tt.amount, tt.part, tt.whole, got, tt.want)
+		}
+	}
+}
+
+func TestQuotePlanChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		price  int64
+		unused int64
+		total  int64
+		credit int64
+	}{
+		{"upgrade", 100000, 5000, 95000, 0},
+		{"downgrade", 3000, 5000, 0, 2000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, clock := newTestUsecase(t, OrderOptions{})
+			ends := subscribe(t, o, clock, "bank_transfer")
+
+			// half of the 30 days paid 10000 for are left
+			clock.Set(time.Unix(ends-15*secondsPerDay, 0))
+			quote, err := o.QuotePlanChange(context.Background(), &pb.PlanChangeRequest{
+				CustomerId:    "c1",
+				FromProductId: "p1",
+				ToProduct:     &pb.OrderProduct{ProductId: "p2", Name: "Plan", Price: tt.price, Duration: 365},
+			})
+			if err != nil {
+				t.Fatalf("QuotePlanChange: %v", err)
+			}
+			if quote.UnusedValue != tt.unused || quote.Total != tt.total || quote.Credit != tt.credit || quote.RemainingSeconds != 15*secondsPerDay {
+				t.Errorf("quote = %v; want unused %d, total %d, credit %d", quote, tt.unused, tt.total, tt.credit)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Kinds of quotes, so that a token is only accepted by the call it was
+// issued for.
+const (
+	quoteKindOrder      = "order"
+	quoteKindPlanChange = "plan_change"
+)
+
+// quoteClaims are the signed contents of a quote token.
+type quoteClaims struct {
+	Kind      string `json:"kind"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	Currency  string `json:"currency"`
+	Total     int64  `json:"total"`
+	// Digest identifies the priced order, see quoteDigest.
+	Digest string `json:"digest"`
+}
+
+// quoteDigest hashes everything that makes the price of order, so that an
+// order priced differently from its quote is detected.
+func quoteDigest(order *domain.Order) string {
+	priced := struct {
+		CustomerId   string
+		Jurisdiction string
+		Currency     string
+		Items        []domain.OrderItem
+		Discounts    []domain.OrderDiscount
+		Taxes        []domain.OrderTax
+		TaxInclusive bool
+		Total        int64
+		PlanChange   *domain.OrderPlanChange
+	}{
+		order.Buyer.CustomerId,
+		order.Buyer.Jurisdiction,
+		order.Currency,
+		order.Items,
+		order.Discounts,
+		order.Taxes,
+		order.TaxInclusive,
+		order.Total,
+		order.PlanChange,
+	}
+
+	data, _ := json.Marshal(priced)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// check reports whether order is priced exactly as quoted.
+func (c *quoteClaims) check(order *domain.Order) error {
+	if quoteDigest(order) != c.Digest {
+		return status.Error(codes.FailedPrecondition, "the order no longer matches its quote, ask for a new quote")
+	}
+
+	return nil
+}
+
+func (o *OrderUsecase) sign(payload string) string {
+	mac := hmac.New(sha256.New, o.options.QuoteSecret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// signQuote issues the token guaranteeing the price of order for the quote
+// TTL. Tokens are the base64 of the claims followed by their HMAC.
+func (o *OrderUsecase) signQuote(kind string, order *domain.Order, now int64) (token string, claims quoteClaims) {
+	claims = quoteClaims{
+		Kind:      kind,
+		IssuedAt:  now,
+		ExpiresAt: now + int64(o.options.QuoteTTL.Seconds()),
+		Currency:  order.Currency,
+		Total:     order.Total,
+		Digest:    quoteDigest(order),
+	}
+
+	data, _ := json.Marshal(claims)
+	payload := base64.RawURLEncoding.EncodeToString(data)
+
+	return payload + "." + o.sign(payload), claims
+}
+
+// verifyQuote returns the claims of a token of kind still valid at now.
+func (o *OrderUsecase) verifyQuote(token string, kind string, now int64) (*quoteClaims, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(o.sign(payload))) {
+		return nil, status.Error(codes.InvalidArgument, "invalid quote token")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid quote token")
+	}
+
+	claims := &quoteClaims{}
+	if err := json.Unmarshal(data, claims); err != nil || claims.Kind != kind {
+		return nil, status.Error(codes.InvalidArgument, "invalid quote token")
+	}
+
+	if now >= claims.ExpiresAt {
+		return nil, status.Error(codes.FailedPrecondition, "the quote has expired, ask for a new quote")
+	}
+
+	return claims, nil
+}
+
+// previewOrder returns the price breakdown of an order that is not saved.
+func previewOrder(order *domain.Order) *pb.Order {
+	preview := &pb.Order{
+		OrderId: order.OrderId,
+		Buyer: &pb.OrderBuyer{
+			CustomerId:   order.Buyer.CustomerId,
+			Name:         order.Buyer.Name,
+			User:         order.Buyer.User,
+			Jurisdiction: order.Buyer.Jurisdiction,
+			Email:        order.Buyer.Email,
+			Phone:        order.Buyer.Phone,
+			Locale:       order.Buyer.Locale,
+		},
+		Subtotal:     order.Subtotal,
+		TaxInclusive: order.TaxInclusive,
+		TaxTotal:     order.TaxTotal,
+		Total:        order.Total,
+		Status:       order.Status,
+		PayExp:       order.PayExp,
+		Currency:     order.Currency,
+		MerchantId:   order.MerchantId,
+	}
+
+	for _, line := range order.Items {
+		preview.Items = append(preview.Items, &pb.OrderItem{
+			Product: &pb.OrderProduct{
+				ProductId:   line.Product.ProductId,
+				Name:        line.Product.Name,
+				Price:       line.Product.Price,
+				Duration:    line.Product.Duration,
+				Description: line.Product.Description,
+				Category:    line.Product.Category,
+			},
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+			LineTotal: line.LineTotal,
+			Discount:  line.Discount,
+		})
+	}
+	if len(preview.Items) > 0 {
+		preview.Product = preview.Items[0].Product
+	}
+
+	for _, discount := range order.Discounts {
+		preview.Discounts = append(preview.Discounts, &pb.OrderDiscount{
+			Code:        discount.Code,
+			Description: discount.Description,
+			Amount:      discount.Amount,
+		})
+	}
+
+	for _, tax := range order.Taxes {
+		preview.Taxes = append(preview.Taxes, &pb.OrderTax{
+			Name:    tax.Name,
+			Rate:    tax.Rate,
+			Taxable: tax.Taxable,
+			Amount:  tax.Amount,
+		})
+	}
+
+	if change := order.PlanChange; change != nil {
+		preview.PlanChange = &pb.OrderPlanChange{
+			FromProductId: change.FromProductId,
+			UnusedValue:   change.UnusedValue,
+			Credit:        change.Credit,
+		}
+	}
+
+	return preview
+}
+
+// Quote prices req exactly as Create would, without saving the order or
+// counting its coupon, and returns a token Create accepts to charge the
+// quoted price.
+func (o *OrderUsecase) Quote(ctx context.Context, req *pb.OrderCreateRequest) (res *pb.OrderQuote, err error) {
+	now := helper.Unix(o.clock)
+	order, _, err := o.priceRequest(ctx, req, now)
+	if err != nil {
+		return
+	}
+
+	token, claims := o.signQuote(quoteKindOrder, order, now)
+	res = &pb.OrderQuote{
+		Token:     token,
+		ExpiresAt: claims.ExpiresAt,
+		Payload:   previewOrder(order),
+	}
+
+	return
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"order/helper"
+	"order/pb"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// quoteRequest is the order of two 10000 units quoted and then created.
+func quoteRequest(clock *helper.FakeClock) *pb.OrderCreateRequest {
+	return &pb.OrderCreateRequest{
+		Buyer:   &pb.OrderBuyer{CustomerId: "c1", Name: "Customer"},
+		Items:   []*pb.OrderItem{{Product: &pb.OrderProduct{ProductId: "p1", Name: "Premium", Price: 10000}, Quantity: 2}},
+		Payment: &pb.OrderPayment{PaymentType: "bank_transfer", OrderId: "o1"},
+		TrxTime: helper.Unix(clock),
+		PayExp:  helper.Unix(clock) + 3600,
+	}
+}
+
+// tamper rewrites the claims of token with edit, keeping its signature.
+func tamper(t *testing.T, token string, edit func(claims *quoteClaims)) string {
+	t.Helper()
+
+	payload, signature, _ := strings.Cut(token, ".")
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := &quoteClaims{}
+	if err := json.Unmarshal(data, claims); err != nil {
+		t.Fatal(err)
+	}
+
+	edit(claims)
+	data, _ = json.Marshal(claims)
+
+	return base64.RawURLEncoding.EncodeToString(data) + "." + signature
+}
+
+func TestQuoteToken(t *testing.T) {
+	tests := []struct {
+		name string
+		// token returns the token Save is called with, given the quoted one
+		token func(t *testing.T, o *OrderUsecase, clock *helper.FakeClock, quoted string) string
+		// change alters the request after it was quoted
+		change func(req *pb.OrderCreateRequest)
+		code   codes.Code
+	}{
+		{
+			name:  "valid",
+			token: func(t *testing.T, o *OrderUsecase, clock *helper.FakeClock, quoted string) string { return quoted },
+			code:  codes.OK,
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T, o *OrderUsecase, clock *helper.FakeClock, quoted string) string {
+				clock.Add(15 * time.Minute)
+				return quoted
+			},
+			code: codes.FailedPrecondition,
+		},
+		{
+			name: "expiry pushed back",
+			token: func(t *testing.T, o *OrderUsecase, clock *helper.FakeClock, quoted string) string {
+				clock.Add(time.Hour)
+				return tamper(t, quoted, func(claims *quoteClaims) { claims.ExpiresAt += 7200 })
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "total lowered",
+			token: func(t *testing.T, o *OrderUsecase, clock *helper.FakeClock, quoted string) string {
+				return tamper(t, quoted, func(claims *quoteClaims) { claims.Total = 1 })
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "signature stripped",
+			token: func(t *testing.T, o *OrderUsecase, clock *helper.FakeClock, quoted string) string {
+				payload, _, _ := strings.Cut(quoted, ".")
+				return payload
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "garbage",
+			token: func(t *testing.T, o *OrderUsecase, clock *helper.FakeClock, quoted string) string {
+				return "not.a-token"
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "plan change token",
+			token: func(t *testing.T, o *OrderUsecase, clock *helper.FakeClock, quoted string) string {
+				order, _, err := o.priceRequest(context.Background(), quoteRequest(clock), helper.Unix(clock))
+				if err != nil {
+					t.Fatal(err)
+				}
+				token, _ := o.signQuote(quoteKindPlanChange, order, helper.Unix(clock))
+				return token
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name:   "order changed since the quote",
+			token:  func(t *testing.T, o *OrderUsecase, clock *helper.FakeClock, quoted string) string { return quoted },
+			change: func(req *pb.OrderCreateRequest) { req.Items[0].Quantity = 1 },
+			code:   codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			o, clock := newTestUsecase(t, OrderOptions{QuoteSecret: []byte("secret"), QuoteTTL: 15 * time.Minute})
+
+			quote, err := o.Quote(ctx, quoteRequest(clock))
+			if err != nil {
+				t.Fatalf("Quote: %v", err)
+			}
+			if quote.GetPayload().GetTotal() != 20000 || quote.ExpiresAt != helper.Unix(clock)+15*60 {
+				t.Fatalf("quote = %v; want 20000 for 15 minutes", quote)
+			}
+
+			req := quoteRequest(clock)
+			req.QuoteToken = tt.token(t, o.(*OrderUsecase), clock, quote.Token)
+			if tt.change != nil {
+				tt.change(req)
+			}
+
+			order, err := o.Save(ctx, req)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("Save = %v; want %s", err, tt.code)
+			}
+			if err == nil && order.Total != quote.GetPayload().GetTotal() {
+				t.Errorf("order total = %d; want the quoted %d", order.Total, quote.GetPayload().GetTotal())
+			}
+		})
+	}
+}
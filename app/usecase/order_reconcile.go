@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"order/domain"
+	"order/pb"
+	"order/variable"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reconciliation is an order and the rows of the settlement report it was
+// paid by, as they compare.
+type reconciliation struct {
+	order *pb.Order
+	rows  []domain.SettlementRow
+	item  *pb.ReconciliationItem
+}
+
+// Reconcile matches the rows of the settlement report req.Content to the
+// orders they paid, by payment reference and then by virtual account
+// number, and reports how each order compares with the report. The orders
+// that received money in the period from req.From to req.To, by default the
+// period the report spans, and that the report does not list are missing in
+// the gateway. With req.AutoSettle, the unpaid orders the report settled are
+// settled with its transactions.
+func (o *OrderUsecase) Reconcile(ctx context.Context, req *pb.ReconcileRequest) (res *pb.ReconciliationReport, err error) {
+	if o.options.SettlementReports == nil {
+		return nil, status.Error(codes.FailedPrecondition, "reconciliation is not enabled")
+	}
+	if len(req.Content) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "content is required")
+	}
+	if req.To > 0 && req.To <= req.From {
+		return nil, status.Error(codes.InvalidArgument, "to must be after from")
+	}
+
+	rows, err := o.options.SettlementReports.Parse(req.Content, o.options.Currency)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "settlement report: %v", err)
+	}
+
+	res = &pb.ReconciliationReport{From: req.From, To: req.To, Rows: int64(len(rows))}
+	if res.From == 0 && res.To == 0 {
+		res.From, res.To = reportPeriod(rows)
+	}
+
+	// items are listed in the order of the report, each order where its
+	// first row is
+	var reconciliations []*reconciliation
+	byOrder := map[string]*reconciliation{}
+	for _, row := range rows {
+		order, err := o.matchRow(ctx, &row)
+		if err != nil {
+			return nil, err
+		}
+		if order == nil {
+			res.Items = append(res.Items, &pb.ReconciliationItem{
+				Outcome:       variable.ReconcileMissingInOurs,
+				Lines:         []int64{row.Line},
+				Reference:     row.Reference,
+				VaNumber:      row.VaNumber,
+				Currency:      row.Amount.Currency,
+				GatewayAmount: row.Amount.Amount,
+				GatewayStatus: row.Status,
+			})
+			continue
+		}
+
+		r, ok := byOrder[order.OrderId]
+		if !ok {
+			r = &reconciliation{order: order, item: &pb.ReconciliationItem{}}
+			byOrder[order.OrderId] = r
+			reconciliations = append(reconciliations, r)
+			res.Items = append(res.Items, r.item)
+		}
+		r.rows = append(r.rows, row)
+	}
+
+	for _, r := range reconciliations {
+		r.compare()
+		if req.AutoSettle && r.item.Outcome == variable.ReconcileStatusMismatch && r.item.GatewayStatus == variable.PaymentStatusSettlement && isUnpaid(r.order.Status) {
+			o.settleReported(ctx, r)
+		}
+	}
+
+	if res.From != 0 || res.To != 0 {
+		paid, err := o.repository.FindPaid(ctx, res.From, res.To)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, order := range paid {
+			if byOrder[order.OrderId] != nil {
+				continue
+			}
+
+			res.Items = append(res.Items, &pb.ReconciliationItem{
+				Outcome:     variable.ReconcileMissingInGateway,
+				OrderId:     order.OrderId,
+				Reference:   order.GetPayment().GetOrderId(),
+				VaNumber:    order.GetPayment().GetVaNumber(),
+				Currency:    order.Currency,
+				OrderAmount: order.PaidTotal,
+				OrderStatus: order.Status,
+			})
+		}
+	}
+
+	for _, item := range res.Items {
+		switch item.Outcome {
+		case variable.ReconcileMatched:
+			res.Matched++
+		case variable.ReconcileAmountMismatch:
+			res.AmountMismatches++
+		case variable.ReconcileStatusMismatch:
+			res.StatusMismatches++
+		case variable.ReconcileMissingInOurs:
+			res.MissingInOurs++
+		case variable.ReconcileMissingInGateway:
+			res.MissingInGateway++
+		}
+		if item.Action == variable.ReconcileActionSettled {
+			res.Settled++
+		}
+	}
+
+	return
+}
+
+// reportPeriod returns the period rows were settled in, from the first
+// settlement time to the second after the last one, or zeros when no row
+// has a time.
+func reportPeriod(rows []domain.SettlementRow) (from int64, to int64) {
+	for _, row := range rows {
+		if row.SettledAt == 0 {
+			continue
+		}
+		if from == 0 || row.SettledAt < from {
+			from = row.SettledAt
+		}
+		if row.SettledAt >= to {
+			to = row.SettledAt + 1
+		}
+	}
+
+	return
+}
+
+// matchRow returns the order row paid, or nil when there is none. A row
+// matched by its virtual account number gets the reference of the attempt
+// that number was issued for.
+func (o *OrderUsecase) matchRow(ctx context.Context, row *domain.SettlementRow) (order *pb.Order, err error) {
+	if row.Reference != "" {
+		order, err = o.repository.FindByPaymentReference(ctx, row.Reference)
+		if order != nil || err != nil {
+			return
+		}
+	}
+	if row.VaNumber == "" {
+		return nil, nil
+	}
+
+	order, err = o.repository.FindByVANumber(ctx, row.Bank, row.VaNumber, row.SettledAt)
+	if order == nil || err != nil {
+		return
+	}
+
+	row.Reference = order.GetPayment().GetOrderId()
+	for _, attempt := range order.Attempts {
+		if attempt.VaNumber == row.VaNumber && (row.Bank == "" || attempt.Bank == row.Bank) {
+			row.Reference = attempt.Reference
+		}
+	}
+
+	return
+}
+
+// compare fills the item of r: the order is matched when it is paid as
+// much as the report says it was, and a transaction listed twice counts
+// once.
+func (r *reconciliation) compare() {
+	order, item := r.order, r.item
+	item.OrderId, item.Currency = order.OrderId, order.Currency
+	item.OrderAmount, item.OrderStatus = order.PaidTotal, order.Status
+	item.Reference, item.VaNumber = r.rows[0].Reference, r.rows[0].VaNumber
+	if item.VaNumber == "" {
+		item.VaNumber = order.GetPayment().GetVaNumber()
+	}
+	item.GatewayStatus = r.rows[len(r.rows)-1].Status
+
+	counted := map[string]bool{}
+	currencies := ""
+	for _, row := range r.rows {
+		item.Lines = append(item.Lines, row.Line)
+		if row.Status != variable.PaymentStatusSettlement {
+			continue
+		}
+		item.GatewayStatus = variable.PaymentStatusSettlement
+
+		if row.TransactionId != "" {
+			if counted[row.TransactionId] {
+				continue
+			}
+			counted[row.TransactionId] = true
+		}
+		if row.Amount.Currency != order.Currency {
+			currencies = row.Amount.Currency
+			continue
+		}
+		item.GatewayAmount += row.Amount.Amount
+	}
+
+	gatewayPaid := item.GatewayStatus == variable.PaymentStatusSettlement
+	orderPaid := isSettled(order.Status) || order.Status == variable.PaymentStatusPartiallyPaid
+	switch {
+	case gatewayPaid != orderPaid:
+		item.Outcome = variable.ReconcileStatusMismatch
+		item.Detail = fmt.Sprintf("the gateway reports %s, the order is %s", item.GatewayStatus, order.Status)
+	case gatewayPaid && currencies != "":
+		item.Outcome = variable.ReconcileAmountMismatch
+		item.Detail = fmt.Sprintf("paid in %s, the order is in %s", currencies, order.Currency)
+	case gatewayPaid && item.GatewayAmount != item.OrderAmount:
+		item.Outcome = variable.ReconcileAmountMismatch
+		item.Detail = fmt.Sprintf("the gateway settled %d, the order received %d", item.GatewayAmount, item.OrderAmount)
+	default:
+		item.Outcome = variable.ReconcileMatched
+	}
+}
+
+// settleReported records the settled transactions of r on its order, and
+// tells on the item of r how that went.
+func (o *OrderUsecase) settleReported(ctx context.Context, r *reconciliation) {
+	for _, row := range r.rows {
+		// a transaction without an amount would pay the whole order
+		if row.Status != variable.PaymentStatusSettlement || row.Amount.Currency != r.order.Currency || row.Amount.Amount <= 0 {
+			continue
+		}
+
+		_, err := o.ChangeStatus(ctx, &pb.OrderChangeStatus{
+			OrderId:        row.Reference,
+			Status:         variable.PaymentStatusSettlement,
+			SettlementTime: row.SettledAt,
+			Reason:         "reconciled with the settlement report",
+			GrossAmount:    row.Amount.Amount,
+			TransactionId:  row.TransactionId,
+			Fee:            row.Fee.Amount,
+		})
+		if err != nil {
+			r.item.Detail = fmt.Sprintf("settling line %d failed: %v", row.Line, err)
+			return
+		}
+	}
+
+	found, err := o.repository.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: r.order.OrderId})
+	if err != nil {
+		r.item.Detail = fmt.Sprintf("the order settled, but could not be read again: %v", err)
+		return
+	}
+
+	r.item.Action = variable.ReconcileActionSettled
+	r.item.Detail = fmt.Sprintf("settled from the report, the order is now %s having received %d", found.GetPayload().GetStatus(), found.GetPayload().GetPaidTotal())
+}
+
+// isUnpaid reports whether an order in status waits for its payment, or the
+// rest of it.
+func isUnpaid(status string) bool {
+	for _, unpaid := range variable.UnpaidStatuses {
+		if status == unpaid {
+			return true
+		}
+	}
+
+	return false
+}
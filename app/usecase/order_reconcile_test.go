@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"order/app/reconcile"
+	"order/pb"
+	"order/variable"
+	"reflect"
+	"testing"
+)
+
+func TestReconcile(t *testing.T) {
+	const header = "order_id,va_number,bank,transaction_id,gross_amount,transaction_status,settlement_time\n"
+	// 2023-03-01 00:30:00 UTC, after o1 was created
+	const settledAt = 1677630600
+
+	tests := []struct {
+		name       string
+		paid       int64
+		report     string
+		autoSettle bool
+		outcomes   []string
+		lines      [][]int64
+		paidAfter  int64
+	}{
+		{
+			name:     "matched by reference",
+			paid:     10000,
+			report:   "o1,,,t1,10000,settlement,2023-03-01 00:30:00\n",
+			outcomes: []string{variable.ReconcileMatched},
+			lines:    [][]int64{{2}},
+		},
+		{
+			name:     "matched by virtual account",
+			paid:     10000,
+			report:   ",8800001,BCA,t1,10000,settlement,2023-03-01 00:30:00\n",
+			outcomes: []string{variable.ReconcileMatched},
+			lines:    [][]int64{{2}},
+		},
+		{
+			name:     "unmatched rows",
+			report:   "o9,,,t1,10000,settlement,2023-03-01 00:30:00\n,8899999,bca,t2,5000,settlement,2023-03-01 00:30:00\n",
+			outcomes: []string{variable.ReconcileMissingInOurs, variable.ReconcileMissingInOurs},
+			lines:    [][]int64{{2}, {3}},
+		},
+		{
+			name:     "paid order missing in the report",
+			paid:     10000,
+			report:   "o9,,,t1,10000,settlement,2023-03-01 00:30:00\n",
+			outcomes: []string{variable.ReconcileMissingInOurs, variable.ReconcileMissingInGateway},
+			lines:    [][]int64{{2}, nil},
+		},
+		{
+			name:     "duplicate transaction counts once",
+			paid:     10000,
+			report:   "o1,,,t1,10000,settlement,2023-03-01 00:30:00\no1,,,t1,10000,settlement,2023-03-01 00:30:00\n",
+			outcomes: []string{variable.ReconcileMatched},
+			lines:    [][]int64{{2, 3}},
+		},
+		{
+			name:     "rows of one order add up",
+			paid:     10000,
+			report:   "o1,,,t1,4000,settlement,2023-03-01 00:30:00\n,8800001,bca,t2,6000,settlement,2023-03-01 00:30:00\n",
+			outcomes: []string{variable.ReconcileMatched},
+			lines:    [][]int64{{2, 3}},
+		},
+		{
+			name:     "amount mismatch",
+			paid:     4000,
+			report:   "o1,,,t1,10000,settlement,2023-03-01 00:30:00\n",
+			outcomes: []string{variable.ReconcileAmountMismatch},
+			lines:    [][]int64{{2}},
+		},
+		{
+			name:     "unpaid order settled by the gateway",
+			report:   "o1,,,t1,10000,settlement,2023-03-01 00:30:00\n",
+			outcomes: []string{variable.ReconcileStatusMismatch},
+			lines:    [][]int64{{2}},
+		},
+		{
+			name:     "failed transaction of a paid order",
+			paid:     10000,
+			report:   "o1,,,t1,10000,deny,2023-03-01 00:30:00\n",
+			outcomes: []string{variable.ReconcileStatusMismatch},
+			lines:    [][]int64{{2}},
+		},
+		{
+			name:       "duplicate rows settle once",
+			report:     "o1,,,t1,10000,settlement,2023-03-01 00:30:00\no1,,,t1,10000,settlement,2023-03-01 00:30:00\n",
+			autoSettle: true,
+			outcomes:   []string{variable.ReconcileStatusMismatch},
+			lines:      [][]int64{{2, 3}},
+			paidAfter:  10000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			o, clock := newTestUsecase(t, OrderOptions{SettlementReports: reconcile.NewParser(nil)})
+			saveTestOrder(t, o, clock)
+			if tt.paid > 0 {
+				_, err := o.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: "o1", Status: variable.PaymentStatusSettlement, TransactionId: "t0", SettlementTime: settledAt, GrossAmount: tt.paid})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			res, err := o.Reconcile(ctx, &pb.ReconcileRequest{Content: []byte(header + tt.report), AutoSettle: tt.autoSettle})
+			if err != nil {
+				t.Fatalf("Reconcile: %v", err)
+			}
+			if res.From != settledAt || res.To != settledAt+1 {
+				t.Errorf("report period %d to %d; want %d to %d", res.From, res.To, settledAt, settledAt+1)
+			}
+
+			var outcomes []string
+			var lines [][]int64
+			for _, item := range res.Items {
+				outcomes, lines = append(outcomes, item.Outcome), append(lines, item.Lines)
+			}
+			if !reflect.DeepEqual(outcomes, tt.outcomes) || !reflect.DeepEqual(lines, tt.lines) {
+				t.Errorf("items %v on lines %v; want %v on lines %v", outcomes, lines, tt.outcomes, tt.lines)
+			}
+
+			var settled int64
+			if tt.paidAfter > 0 {
+				settled = 1
+			}
+			if res.Settled != settled {
+				t.Errorf("%d orders settled; want %d", res.Settled, settled)
+			}
+			paid := tt.paid
+			if tt.paidAfter > 0 {
+				paid = tt.paidAfter
+			}
+			if order := findOrder(t, o, "o1"); order.PaidTotal != paid {
+				t.Errorf("o1 paid %d; want %d", order.PaidTotal, paid)
+			}
+		})
+	}
+}
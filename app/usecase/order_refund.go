@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (o *OrderUsecase) Refund(ctx context.Context, req *pb.OrderRefundRequest) (res *pb.OrderRefundResponse, err error) {
+	switch {
+	case req.OrderId == "":
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	case req.Amount <= 0:
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	case req.Reason == "":
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	case req.Actor == "":
+		return nil, status.Error(codes.InvalidArgument, "actor is required")
+	}
+
+	refund := &domain.OrderRefund{
+		RefundId:  helper.NewID(),
+		Amount:    req.Amount,
+		Reason:    req.Reason,
+		Actor:     req.Actor,
+		Reference: req.Reference,
+		CreatedAt: helper.Unix(o.clock),
+	}
+
+	order, err := o.repository.Refund(ctx, req.OrderId, refund, req.ExpectedVersion)
+	switch {
+	case errors.Is(err, domain.ErrNotRefundable), errors.Is(err, domain.ErrRefundExceedsPaid):
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s: %v", req.OrderId, err)
+	case errors.Is(err, domain.ErrDuplicateRefund):
+		return nil, status.Errorf(codes.AlreadyExists, "order %s: refund %s already recorded", req.OrderId, req.Reference)
+	case err != nil:
+		return nil, abortOnConflict(err)
+	case order == nil:
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.OrderId)
+	}
+
+	if order.Status == variable.PaymentStatusRefunded {
+		if err = o.revokeEntitlements(ctx, order, refund.CreatedAt); err != nil {
+			return nil, status.Errorf(codes.Internal, "refund %s recorded, revoking access failed: %v", refund.RefundId, err)
+		}
+	}
+
+	res = &pb.OrderRefundResponse{
+		Refund:  order.Refunds[len(order.Refunds)-1],
+		Payload: order,
+	}
+
+	return
+}
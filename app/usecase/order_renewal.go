@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// secondsPerDay converts the days of a renewal policy into time.
+const secondsPerDay = 24 * 60 * 60
+
+// dunningEvents are the types of the outbox events recording the dunning
+// stages the buyer is notified of.
+var dunningEvents = map[string]string{
+	variable.RenewalStageReminder:    variable.OrderEventRenewalReminder,
+	variable.RenewalStageFinalNotice: variable.OrderEventRenewalFinalNotice,
+	variable.RenewalStageLapsed:      variable.OrderEventRenewalLapsed,
+}
+
+// dunningEvent returns the outbox event of type eventType about the renewal
+// order, rendered as the repositories render the order events.
+func dunningEvent(eventType string, order *pb.Order, now int64) (*domain.OutboxEvent, error) {
+	payload, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.OutboxEvent{
+		EventId:   helper.NewID(),
+		Type:      eventType,
+		OrderId:   order.OrderId,
+		Sequence:  order.Version,
+		CreatedAt: now,
+		Payload:   payload,
+	}, nil
+}
+
+func renewalEvent(entitlement *domain.Entitlement, renewal domain.EntitlementRenewal) *pb.RenewalEvent {
+	event := &pb.RenewalEvent{
+		CustomerId: entitlement.CustomerId,
+		ProductId:  entitlement.ProductId,
+		OrderId:    renewal.OrderId,
+		Stage:      renewal.Stage,
+		PayExp:     renewal.PayExp,
+		At:         renewal.UpdatedAt,
+	}
+	if renewal.Stage == variable.RenewalStageReminder {
+		event.Reminder = renewal.Reminders
+	}
+
+	return event
+}
+
+func inDunning(renewal *domain.EntitlementRenewal) bool {
+	if renewal == nil {
+		return false
+	}
+
+	for _, stage := range variable.DunningStages {
+		if renewal.Stage == stage {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSettled(status string) bool {
+	for _, settled := range variable.SettledStatuses {
+		if status == settled {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dunningStage returns the latest dunning step due at now for the unpaid
+// renewal. Steps missed while renewals did not run are skipped.
+func dunningStage(policy domain.RenewalPolicy, renewal domain.EntitlementRenewal, now int64) (stage string, reminders int64) {
+	stage, reminders = renewal.Stage, renewal.Reminders
+
+	overdue := now - renewal.PayExp
+	switch {
+	case overdue >= policy.LapseDays*secondsPerDay:
+		return variable.RenewalStageLapsed, reminders
+	case overdue >= policy.FinalNoticeDays*secondsPerDay:
+		return variable.RenewalStageFinalNotice, reminders
+	case stage == variable.RenewalStageFinalNotice:
+		return
+	}
+
+	var due int64
+	for _, days := range policy.ReminderDays {
+		if overdue >= days*secondsPerDay {
+			due++
+		}
+	}
+	if due > reminders {
+		return variable.RenewalStageReminder, due
+	}
+
+	return
+}
+
+// Renew creates the renewal orders of the entitlements about to end and
+// moves the unpaid ones along the dunning schedule, returning the events to
+// notify customers of. Each step is claimed in the repository before it is
+// taken, so concurrent runs do not repeat it.
+func (o *OrderUsecase) Renew(ctx context.Context, req *pb.OrderRenewRequest) (res *pb.OrderRenewResponse, err error) {
+	res = &pb.OrderRenewResponse{}
+
+	policy := o.options.Renewal
+	if policy == nil {
+		return
+	}
+
+	now := helper.Unix(o.clock)
+	entitlements, err := o.entitlements.FindRenewals(ctx, now+policy.LeadDays*secondsPerDay, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entitlements {
+		entitlement := &entitlements[i]
+
+		var event *pb.RenewalEvent
+		if inDunning(entitlement.Renewal) {
+			event, err = o.dun(ctx, entitlement, *policy, now)
+		} else {
+			event, err = o.createRenewal(ctx, entitlement, *policy, now)
+		}
+
+		// one failing entitlement must not hold up the others
+		if err != nil {
+			log.Printf("renew %s/%s: %v", entitlement.CustomerId, entitlement.ProductId, err)
+			res.Failed++
+			continue
+		}
+
+		if event == nil {
+			continue
+		}
+		if event.Stage == variable.RenewalStageCreated {
+			res.Created++
+		}
+		res.Events = append(res.Events, event)
+	}
+
+	return res, nil
+}
+
+// createRenewal creates the pending order renewing the entitlement, with the
+// buyer and product snapshot of the order that granted its last period. The
+// renewal is due when the access ends, and its order can be paid until the
+// access lapses, so that it does not expire while it is being dunned. A
+// period paid into a virtual account is renewed into a new one, so its bank
+// must have a scheme the service numbers accounts by.
+func (o *OrderUsecase) createRenewal(ctx context.Context, entitlement *domain.Entitlement, policy domain.RenewalPolicy, now int64) (*pb.RenewalEvent, error) {
+	if len(entitlement.Grants) == 0 {
+		return nil, nil
+	}
+
+	last := entitlement.Grants[len(entitlement.Grants)-1]
+	found, err := o.repository.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: last.OrderId})
+	if err != nil || found.IsEmpty {
+		return nil, err
+	}
+
+	previous := found.Payload
+	var line *pb.OrderItem
+	for _, item := range previous.Items {
+		if item.GetProduct().GetProductId() == entitlement.ProductId {
+			line = item
+			break
+		}
+	}
+	if line == nil {
+		return nil, nil
+	}
+
+	payment := previous.GetPayment()
+	if payment.GetPaymentType() != variable.PaymentTypeQRIS && payment.GetVaNumber() != "" &&
+		o.options.VirtualAccounts.Scheme(payment.GetBank()) == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "bank %s has no virtual account scheme to renew into", payment.GetBank())
+	}
+
+	renewal := domain.EntitlementRenewal{
+		OrderId:   helper.NewID(),
+		PeriodEnd: entitlement.EndsAt,
+		PayExp:    entitlement.EndsAt,
+		Stage:     variable.RenewalStageCreated,
+		UpdatedAt: now,
+	}
+	claimed, err := o.entitlements.ClaimRenewal(ctx, entitlement.CustomerId, entitlement.ProductId, renewal)
+	if err != nil || !claimed {
+		return nil, err
+	}
+
+	// the catalog renews at today's price
+	product := line.Product
+	if o.options.Catalog != nil {
+		product = &pb.OrderProduct{ProductId: product.ProductId}
+	}
+
+	_, err = o.Save(ctx, &pb.OrderCreateRequest{
+		Buyer: previous.Buyer,
+		Items: []*pb.OrderItem{{Product: product, Quantity: line.Quantity}},
+		Payment: &pb.OrderPayment{
+			PaymentType: payment.GetPaymentType(),
+			OrderId:     renewal.OrderId,
+			Bank:        payment.GetBank(),
+		},
+		TrxTime:    now,
+		PayExp:     renewal.PayExp + policy.LapseDays*secondsPerDay,
+		Currency:   previous.Currency,
+		MerchantId: previous.MerchantId,
+	})
+	if err != nil {
+		// let the next run try again
+		o.entitlements.ReleaseRenewal(ctx, entitlement.CustomerId, entitlement.ProductId, renewal.OrderId)
+		return nil, err
+	}
+
+	return renewalEvent(entitlement, renewal), nil
+}
+
+// dun moves an unpaid renewal to its next stage: paid once its order
+// settled, cancelled when the customer cancelled the order, opted out or
+// got access otherwise, and along the dunning schedule until it lapses. An
+// order that expired can no longer be paid, so its renewal lapses at once.
+// The reminders, final notice and lapse are written to the outbox with the
+// stage, for the buyer to be notified of.
+func (o *OrderUsecase) dun(ctx context.Context, entitlement *domain.Entitlement, policy domain.RenewalPolicy, now int64) (*pb.RenewalEvent, error) {
+	current := *entitlement.Renewal
+	next := current
+	next.UpdatedAt = now
+
+	found, err := o.repository.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: current.OrderId})
+	if err != nil {
+		return nil, err
+	}
+
+	var orderStatus string
+	if !found.IsEmpty {
+		orderStatus = found.Payload.Status
+	}
+
+	switch {
+	case isSettled(orderStatus):
+		next.Stage = variable.RenewalStagePaid
+	case found.IsEmpty || orderStatus == variable.PayementStatusCancel ||
+		entitlement.RenewalOptOut || entitlement.EndsAt != current.PeriodEnd:
+		next.Stage = variable.RenewalStageCancelled
+	case orderStatus == variable.PayementStatusExpire:
+		next.Stage = variable.RenewalStageLapsed
+	default:
+		next.Stage, next.Reminders = dunningStage(policy, current, now)
+		if next.Stage == current.Stage && next.Reminders == current.Reminders {
+			return nil, nil
+		}
+	}
+
+	// stop collecting a payment that is no longer wanted
+	if next.Stage == variable.RenewalStageCancelled || next.Stage == variable.RenewalStageLapsed {
+		cancel := &pb.OrderCancelRequest{OrderId: current.OrderId, UserId: entitlement.CustomerId}
+		if _, err := o.repository.Cancel(ctx, cancel); err != nil {
+			return nil, err
+		}
+	}
+
+	var event *domain.OutboxEvent
+	if eventType, ok := dunningEvents[next.Stage]; ok {
+		// the lapse is notified with the order it cancelled
+		if next.Stage == variable.RenewalStageLapsed {
+			found, err = o.repository.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: current.OrderId})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		event, err = dunningEvent(eventType, found.Payload, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := o.entitlements.UpdateRenewal(ctx, entitlement.CustomerId, entitlement.ProductId, current, next, event)
+	if err != nil || !updated {
+		return nil, err
+	}
+
+	return renewalEvent(entitlement, next), nil
+}
@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"order/app/qris"
+	"order/app/repository"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var renewalPolicy = &domain.RenewalPolicy{LeadDays: 3, ReminderDays: []int64{1, 3}, FinalNoticeDays: 5, LapseDays: 7}
+
+// bcaAccounts numbers the bca virtual accounts subscriptions are paid into.
+var bcaAccounts = &domain.VirtualAccountPolicy{Schemes: []domain.VirtualAccountScheme{
+	{Bank: "bca", Prefix: "88", Length: 7, From: 1, To: 99999, CheckDigit: variable.VACheckDigitNone},
+}}
+
+// newTestUsecase returns an OrderUsecase over memory repositories, reading
+// the time from the returned clock.
+func newTestUsecase(t *testing.T, options OrderOptions) (domain.OrderUsecase, *helper.FakeClock) {
+	t.Helper()
+
+	o, clock, _ := newTestUsecaseOutbox(t, options)
+
+	return o, clock
+}
+
+// newTestUsecaseOutbox is newTestUsecase also returning the outbox the
+// repositories write their events to.
+func newTestUsecaseOutbox(t *testing.T, options OrderOptions) (domain.OrderUsecase, *helper.FakeClock, domain.OutboxRepository) {
+	t.Helper()
+
+	clock := helper.NewFakeClock(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC))
+	if options.Currency == "" {
+		options.Currency = "IDR"
+	}
+	outbox := repository.NewOutboxMemoryRepository()
+	o := NewOrderUsecase(
+		repository.NewOrderMemoryRepository(clock, outbox),
+		repository.NewCouponMemoryRepository(),
+		repository.NewEntitlementMemoryRepository(outbox),
+		repository.NewVirtualAccountMemoryRepository(),
+		options,
+		clock,
+	)
+
+	return o, clock, outbox
+}
+
+// subscribe settles a 30 days subscription of customer c1 paid with
+// paymentType, and returns when its access ends.
+func subscribe(t *testing.T, o domain.OrderUsecase, clock *helper.FakeClock, paymentType string) int64 {
+	t.Helper()
+
+	ctx := context.Background()
+	order, err := o.Save(ctx, &pb.OrderCreateRequest{
+		Buyer:   &pb.OrderBuyer{CustomerId: "c1", Name: "Customer"},
+		Product: &pb.OrderProduct{ProductId: "p1", Name: "Premium", Price: 10000, Duration: 30},
+		Payment: &pb.OrderPayment{PaymentType: paymentType, OrderId: "first", Bank: "bca", VaNumber: "8800001"},
+		TrxTime: helper.Unix(clock),
+		PayExp:  helper.Unix(clock) + 3600,
+	})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	if _, err := o.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: order.GetPayment().GetOrderId(), Status: variable.PaymentStatusSettlement, SettlementTime: helper.Unix(clock)}); err != nil {
+		t.Fatalf("settle: %v", err)
+	}
+
+	return helper.Unix(clock) + 30*secondsPerDay
+}
+
+// renew runs Renew at the time at and returns its only event.
+func renew(t *testing.T, o domain.OrderUsecase, clock *helper.FakeClock, at int64) *pb.RenewalEvent {
+	t.Helper()
+
+	clock.Set(time.Unix(at, 0))
+	res, err := o.Renew(context.Background(), &pb.OrderRenewRequest{})
+	if err != nil || res.Failed > 0 || len(res.Events) != 1 {
+		t.Fatalf("Renew = %v, %v; want a single event", res, err)
+	}
+
+	return res.Events[0]
+}
+
+func findOrder(t *testing.T, o domain.OrderUsecase, orderId string) *pb.Order {
+	t.Helper()
+
+	found, err := o.FindOne(context.Background(), &pb.OrderFindOneRequest{OrderId: orderId})
+	if err != nil || found.IsEmpty {
+		t.Fatalf("FindOne(%s) = %v, %v", orderId, found, err)
+	}
+
+	return found.Payload
+}
+
+func TestRenewalOrderOutlivesDunning(t *testing.T) {
+	ctx := context.Background()
+	o, clock := newTestUsecase(t, OrderOptions{VirtualAccounts: bcaAccounts, Renewal: renewalPolicy})
+	ends := subscribe(t, o, clock, "bank_transfer")
+
+	created := renew(t, o, clock, ends-2*secondsPerDay)
+	if created.Stage != variable.RenewalStageCreated || created.PayExp != ends {
+		t.Fatalf("renewal = %v; want it created due at %d", created, ends)
+	}
+	if order := findOrder(t, o, created.OrderId); order.PayExp != ends+7*secondsPerDay {
+		t.Errorf("renewal order pay_exp = %d; want the lapse at %d", order.PayExp, ends+7*secondsPerDay)
+	}
+
+	// the expiry job runs throughout the dunning and leaves the order be
+	steps := []struct {
+		day   int64
+		stage string
+	}{
+		{1, variable.RenewalStageReminder},
+		{3, variable.RenewalStageReminder},
+		{5, variable.RenewalStageFinalNotice},
+	}
+	for _, step := range steps {
+		clock.Set(time.Unix(ends+step.day*secondsPerDay, 0))
+		if expired, err := o.Expire(ctx, &pb.OrderExpireRequest{}); err != nil || expired != 0 {
+			t.Fatalf("Expire on day %d = %d, %v; want nothing expired", step.day, expired, err)
+		}
+
+		if event := renew(t, o, clock, ends+step.day*secondsPerDay); event.Stage != step.stage {
+			t.Errorf("renewal on day %d = %v; want %s", step.day, event, step.stage)
+		}
+		if status := findOrder(t, o, created.OrderId).Status; status != variable.PaymentStatusPending {
+			t.Errorf("renewal order on day %d is %s; want it payable", step.day, status)
+		}
+	}
+
+	if event := renew(t, o, clock, ends+7*secondsPerDay); event.Stage != variable.RenewalStageLapsed {
+		t.Errorf("renewal at the lapse = %v; want lapsed", event)
+	}
+	if status := findOrder(t, o, created.OrderId).Status; status != variable.PayementStatusCancel {
+		t.Errorf("renewal order after the lapse is %s; want cancel", status)
+	}
+}
+
+func TestExpiredRenewalOrderLapses(t *testing.T) {
+	ctx := context.Background()
+	o, clock := newTestUsecase(t, OrderOptions{VirtualAccounts: bcaAccounts, Renewal: renewalPolicy})
+	ends := subscribe(t, o, clock, "bank_transfer")
+	created := renew(t, o, clock, ends-2*secondsPerDay)
+
+	// renewal orders created before they outlived the dunning expired when
+	// it began
+	repo := o.(*OrderUsecase).repository
+	if affected, err := repo.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: created.OrderId, Status: variable.PayementStatusExpire}, ends); err != nil || !affected {
+		t.Fatalf("expiring the renewal order = %v, %v", affected, err)
+	}
+
+	if event := renew(t, o, clock, ends+secondsPerDay); event.OrderId != created.OrderId || event.Stage != variable.RenewalStageLapsed {
+		t.Errorf("renewal of an expired order = %v; want lapsed instead of a reminder", event)
+	}
+
+	res, err := o.Renew(ctx, &pb.OrderRenewRequest{})
+	if err != nil || len(res.Events) != 0 {
+		t.Errorf("Renew after the lapse = %v, %v; want no more dunning", res, err)
+	}
+}
+
+func TestRenewalPaidByQRIS(t *testing.T) {
+	ctx := context.Background()
+	generator, err := qris.NewGenerator(domain.QRISMerchant{NMID: "ID1020000000001", Criteria: "UMI", MCC: "5814", Name: "Toko Kopi", City: "Jakarta"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, clock := newTestUsecase(t, OrderOptions{QRIS: generator, Renewal: renewalPolicy})
+	ends := subscribe(t, o, clock, variable.PaymentTypeQRIS)
+
+	created := renew(t, o, clock, ends-2*secondsPerDay)
+	if created.Stage != variable.RenewalStageCreated {
+		t.Fatalf("renewal = %v; want it created", created)
+	}
+
+	// the order ID is too long to be a reference label, the QR code is
+	// referenced by a shorter one
+	checkQR := func(order *pb.Order) string {
+		t.Helper()
+
+		reference := order.GetPayment().GetOrderId()
+		if len(reference) > 25 || reference == order.OrderId {
+			t.Fatalf("QR reference of %s = %q; want a shorter one", order.OrderId, reference)
+		}
+		if attempt := lastAttempt(order); attempt.Reference != reference || attempt.QrString != order.GetPayment().GetQrString() {
+			t.Errorf("attempt = %v; want the payment of the order", attempt)
+		}
+		payload, err := qris.Parse(order.GetPayment().GetQrString())
+		if err != nil || payload.Reference != reference || payload.Amount != "10000" {
+			t.Errorf("QR payload = %+v, %v; want 10000 under %s", payload, err, reference)
+		}
+
+		return reference
+	}
+	first := checkQR(findOrder(t, o, created.OrderId))
+
+	// so is a second attempt, instead of the order ID and its number
+	retried, err := o.RetryPayment(ctx, &pb.RetryPaymentRequest{OrderId: created.OrderId})
+	if err != nil {
+		t.Fatalf("RetryPayment: %v", err)
+	}
+	reference := checkQR(retried)
+	if reference == first {
+		t.Errorf("retry is referenced by %q, as the first attempt", reference)
+	}
+
+	if _, err := o.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: reference, Status: variable.PaymentStatusSettlement, SettlementTime: helper.Unix(clock)}); err != nil {
+		t.Fatalf("settling the QR payment: %v", err)
+	}
+	if event := renew(t, o, clock, ends); event.OrderId != created.OrderId || event.Stage != variable.RenewalStagePaid {
+		t.Errorf("renewal paid by QR = %v; want paid", event)
+	}
+}
+
+func TestDunningEvents(t *testing.T) {
+	ctx := context.Background()
+	o, clock, outbox := newTestUsecaseOutbox(t, OrderOptions{VirtualAccounts: bcaAccounts, Renewal: renewalPolicy})
+	ends := subscribe(t, o, clock, "bank_transfer")
+
+	created := renew(t, o, clock, ends-2*secondsPerDay)
+	if va := findOrder(t, o, created.OrderId).GetPayment().GetVaNumber(); va == "" {
+		t.Errorf("renewal order has no virtual account number")
+	}
+
+	steps := []struct {
+		day       int64
+		eventType string
+		status    string
+	}{
+		{1, variable.OrderEventRenewalReminder, variable.PaymentStatusPending},
+		{3, variable.OrderEventRenewalReminder, variable.PaymentStatusPending},
+		{5, variable.OrderEventRenewalFinalNotice, variable.PaymentStatusPending},
+		{7, variable.OrderEventRenewalLapsed, variable.PayementStatusCancel},
+	}
+	for _, step := range steps {
+		renew(t, o, clock, ends+step.day*secondsPerDay)
+	}
+
+	events, err := outbox.Pending(ctx, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dunning []domain.OutboxEvent
+	for _, event := range events {
+		if event.OrderId == created.OrderId && event.Type != variable.OrderEventCreated && event.Type != variable.OrderEventCancelled {
+			dunning = append(dunning, event)
+		}
+	}
+	if len(dunning) != len(steps) {
+		t.Fatalf("dunning events = %+v; want %d", dunning, len(steps))
+	}
+	for i, step := range steps {
+		order := &pb.Order{}
+		if err := protojson.Unmarshal(dunning[i].Payload, order); err != nil {
+			t.Fatalf("payload of %s: %v", dunning[i].Type, err)
+		}
+		if dunning[i].Type != step.eventType || order.Status != step.status {
+			t.Errorf("event on day %d = %s of a %s order; want %s of a %s order", step.day, dunning[i].Type, order.Status, step.eventType, step.status)
+		}
+	}
+}
+
+func TestRenewalWithoutVAScheme(t *testing.T) {
+	o, clock := newTestUsecase(t, OrderOptions{Renewal: renewalPolicy})
+	ends := subscribe(t, o, clock, "bank_transfer")
+
+	// the renewal could not be paid without a virtual account number
+	clock.Set(time.Unix(ends-2*secondsPerDay, 0))
+	res, err := o.Renew(context.Background(), &pb.OrderRenewRequest{})
+	if err != nil || res.Failed != 1 || res.Created != 0 || len(res.Events) != 0 {
+		t.Errorf("Renew without a bca scheme = %v, %v; want the renewal refused", res, err)
+	}
+}
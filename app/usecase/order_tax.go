@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"order/domain"
+	"order/variable"
+)
+
+// taxRule returns the most specific rule of policy matching a line of the
+// given product category bought in jurisdiction, or nil when none does.
+func taxRule(policy *domain.TaxPolicy, category string, jurisdiction string) (rule *domain.TaxRule) {
+	best := -1
+	for i := range policy.Rules {
+		candidate := &policy.Rules[i]
+		if candidate.Category != "" && candidate.Category != category {
+			continue
+		}
+		if candidate.Jurisdiction != "" && candidate.Jurisdiction != jurisdiction {
+			continue
+		}
+
+		score := 0
+		if candidate.Category != "" {
+			score += 2
+		}
+		if candidate.Jurisdiction != "" {
+			score++
+		}
+
+		if score > best {
+			best = score
+			rule = candidate
+		}
+	}
+
+	return
+}
+
+// divide returns n / d rounded with mode, for non-negative n and positive d.
+func divide(n int64, d int64, mode string) int64 {
+	q, r := n/d, n%d
+	switch {
+	case r == 0:
+	case mode == variable.TaxRoundingUp:
+		q++
+	case mode == variable.TaxRoundingHalfUp && 2*r >= d:
+		q++
+	}
+
+	return q
+}
+
+// computeTaxes groups the discounted lines of an order by the tax rule they
+// match and returns one tax line per rule, in the order of the rules. Under
+// an inclusive policy the tax is extracted from the amounts instead of
+// added on top of them.
+func computeTaxes(policy *domain.TaxPolicy, items []domain.OrderItem, jurisdiction string) (taxes []domain.OrderTax, total int64) {
+	if policy == nil {
+		return
+	}
+
+	taxable := map[*domain.TaxRule]int64{}
+	for _, line := range items {
+		rule := taxRule(policy, line.Product.Category, jurisdiction)
+		if rule != nil {
+			taxable[rule] += line.LineTotal - line.Discount
+		}
+	}
+
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		base, ok := taxable[rule]
+		if !ok {
+			continue
+		}
+
+		var amount int64
+		if policy.Inclusive {
+			amount = divide(base*rule.Rate, 10000+rule.Rate, policy.Rounding)
+		} else {
+			amount = divide(base*rule.Rate, 10000, policy.Rounding)
+		}
+
+		taxes = append(taxes, domain.OrderTax{
+			Name:    rule.Name,
+			Rate:    rule.Rate,
+			Taxable: base,
+			Amount:  amount,
+		})
+		total += amount
+	}
+
+	return
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"testing"
+)
+
+var testTaxRules = []domain.TaxRule{
+	{Name: "VAT", Rate: 1100},
+	{Name: "VAT SG", Rate: 900, Jurisdiction: "SG"},
+	{Name: "Books", Rate: 0, Category: "books"},
+	{Name: "Digital SG", Rate: 800, Category: "digital", Jurisdiction: "SG"},
+}
+
+func TestTaxRule(t *testing.T) {
+	policy := &domain.TaxPolicy{Rules: testTaxRules}
+
+	tests := []struct {
+		category     string
+		jurisdiction string
+		want         string
+	}{
+		{"", "", "VAT"},
+		{"software", "ID", "VAT"},
+		{"software", "SG", "VAT SG"},
+		// a category outweighs a jurisdiction
+		{"books", "SG", "Books"},
+		{"digital", "SG", "Digital SG"},
+		{"digital", "ID", "VAT"},
+	}
+	for _, tt := range tests {
+		rule := taxRule(policy, tt.category, tt.jurisdiction)
+		if rule == nil || rule.Name != tt.want {
+			t.Errorf("taxRule(%q, %q) = %v; want %s", tt.category, tt.jurisdiction, rule, tt.want)
+		}
+	}
+
+	if rule := taxRule(&domain.TaxPolicy{Rules: testTaxRules[1:2]}, "software", "ID"); rule != nil {
+		t.Errorf("taxRule without a matching rule = %v; want nil", rule)
+	}
+}
+
+func TestDivideRounding(t *testing.T) {
+	tests := []struct {
+		n, d int64
+		mode string
+		want int64
+	}{
+		{10, 5, variable.TaxRoundingUp, 2},
+		{11, 5, variable.TaxRoundingDown, 2},
+		{11, 5, variable.TaxRoundingUp, 3},
+		{11, 5, variable.TaxRoundingHalfUp, 2},
+		{12, 5, variable.TaxRoundingHalfUp, 2},
+		{13, 5, variable.TaxRoundingHalfUp, 3},
+		// exactly half rounds up
+		{5, 2, variable.TaxRoundingHalfUp, 3},
+		{0, 7, variable.TaxRoundingUp, 0},
+	}
+	for _, tt := range tests {
+		if got := divide(tt.n, tt.d, tt.mode); got != tt.want {
+			t.Errorf("divide(%d, %d, %s) = %d; want %d", tt.n, tt.d, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestComputeTaxes(t *testing.T) {
+	line := func(category string, total, discount int64) domain.OrderItem {
+		return domain.OrderItem{Product: domain.OrderProduct{Category: category}, Quantity: 1, UnitPrice: total, LineTotal: total, Discount: discount}
+	}
+
+	tests := []struct {
+		name         string
+		policy       *domain.TaxPolicy
+		items        []domain.OrderItem
+		jurisdiction string
+		want         []domain.OrderTax
+		total        int64
+	}{
+		{
+			name:   "no policy",
+			policy: nil,
+			items:  []domain.OrderItem{line("", 10000, 0)},
+		},
+		{
+			name:   "exclusive",
+			policy: &domain.TaxPolicy{Rounding: variable.TaxRoundingHalfUp, Rules: testTaxRules},
+			items:  []domain.OrderItem{line("", 10000, 0)},
+			want:   []domain.OrderTax{{Name: "VAT", Rate: 1100, Taxable: 10000, Amount: 1100}},
+			total:  1100,
+		},
+		{
+			name:   "inclusive",
+			policy: &domain.TaxPolicy{Inclusive: true, Rounding: variable.TaxRoundingHalfUp, Rules: testTaxRules},
+			items:  []domain.OrderItem{line("", 11100, 0)},
+			want:   []domain.OrderTax{{Name: "VAT", Rate: 1100, Taxable: 11100, Amount: 1100}},
+			total:  1100,
+		},
+		{
+			name:   "discounted lines are taxed on what is left",
+			policy: &domain.TaxPolicy{Rounding: variable.TaxRoundingDown, Rules: testTaxRules},
+			items:  []domain.OrderItem{line("", 10000, 1000), line("", 999, 0)},
+			want:   []domain.OrderTax{{Name: "VAT", Rate: 1100, Taxable: 9999, Amount: 1099}},
+			total:  1099,
+		},
+		{
+			name:         "one line per rule in the order of the rules",
+			policy:       &domain.TaxPolicy{Rounding: variable.TaxRoundingUp, Rules: testTaxRules},
+			items:        []domain.OrderItem{line("digital", 1001, 0), line("software", 1001, 0), line("books", 5000, 0)},
+			jurisdiction: "SG",
+			want: []domain.OrderTax{
+				{Name: "VAT SG", Rate: 900, Taxable: 1001, Amount: 91},
+				{Name: "Books", Rate: 0, Taxable: 5000, Amount: 0},
+				{Name: "Digital SG", Rate: 800, Taxable: 1001, Amount: 81},
+			},
+			total: 172,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taxes, total := computeTaxes(tt.policy, tt.items, tt.jurisdiction)
+			if total != tt.total || len(taxes) != len(tt.want) {
+				t.Fatalf("computeTaxes = %+v, %d; want %+v, %d", taxes, total, tt.want, tt.total)
+			}
+			for i := range taxes {
+				if taxes[i] != tt.want[i] {
+					t.Errorf("tax %d = %+v; want %+v", i, taxes[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTaxedOrderTotal(t *testing.T) {
+	tests := []struct {
+		inclusive bool
+		total     int64
+		tax       int64
+	}{
+		{false, 11100, 1100},
+		{true, 10000, 991},
+	}
+
+	for _, tt := range tests {
+		policy := &domain.TaxPolicy{Inclusive: tt.inclusive, Rounding: variable.TaxRoundingHalfUp, Rules: testTaxRules}
+		o, clock := newTestUsecase(t, OrderOptions{Tax: policy})
+
+		order, err := o.Save(context.Background(), &pb.OrderCreateRequest{
+			Buyer:   &pb.OrderBuyer{CustomerId: "c1", Name: "Customer", Jurisdiction: "ID"},
+			Product: &pb.OrderProduct{ProductId: "p1", Name: "Premium", Price: 10000},
+			Payment: &pb.OrderPayment{PaymentType: "bank_transfer", OrderId: "o1"},
+			TrxTime: helper.Unix(clock),
+			PayExp:  helper.Unix(clock) + 3600,
+		})
+		if err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		if order.Subtotal != 10000 || order.TaxTotal != tt.tax || order.Total != tt.total || order.TaxInclusive != tt.inclusive {
+			t.Errorf("inclusive %v: order subtotal %d, tax %d, total %d; want 10000, %d, %d", tt.inclusive, order.Subtotal, order.TaxTotal, order.Total, tt.tax, tt.total)
+		}
+	}
+}
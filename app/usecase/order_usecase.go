@@ -2,32 +2,121 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
+	"net/mail"
 	"order/domain"
+	"order/helper"
 	"order/pb"
+	"order/variable"
 	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// OrderOptions are the business settings of an OrderUsecase.
+type OrderOptions struct {
+	// Currency is the currency of orders created without one.
+	Currency string
+	// Tax decides the taxes charged on new orders, nil disables taxes.
+	Tax *domain.TaxPolicy
+	// VirtualAccounts numbers the virtual accounts of new orders, nil
+	// leaves them to callers.
+	VirtualAccounts *domain.VirtualAccountPolicy
+	// QRIS issues the QR payloads of orders paid by QR, nil disables the
+	// qris payment type.
+	QRIS domain.PaymentQR
+	// Renewal schedules renewal orders and dunning, nil disables renewals.
+	Renewal *domain.RenewalPolicy
+	// QuoteSecret signs quote tokens. Without one a random secret is used,
+	// and tokens only hold for the running process.
+	QuoteSecret []byte
+	// QuoteTTL is how long a quote can be ordered, 15 minutes by default.
+	QuoteTTL time.Duration
+	// Catalog prices the products of new orders, nil trusts the products
+	// sent by clients.
+	Catalog domain.ProductCatalog
+	// Webhooks tunes the delivery of merchant webhooks.
+	Webhooks domain.WebhookPolicy
+	// Invoices numbers the invoices of settled orders.
+	Invoices domain.InvoicePolicy
+	// Seller is who invoices are issued by.
+	Seller domain.InvoiceSeller
+	// Payments accounts for the money received for orders.
+	Payments domain.PaymentPolicy
+	// SettlementReports reads the settlement reports orders are reconciled
+	// with, nil disables reconciliation.
+	SettlementReports domain.SettlementReportParser
+}
+
 // OrderUsecase defines the use case for managing Orders.
 type OrderUsecase struct {
 	// repository is the underlying repository for storing Orders.
 	repository domain.OrderRepository
+	// coupons redeems the coupon codes orders are created with.
+	coupons domain.CouponRepository
+	// entitlements records the access granted by settled orders.
+	entitlements domain.EntitlementRepository
+	// accounts counts the virtual account numbers handed out per bank.
+	accounts domain.VirtualAccountRepository
+	options  OrderOptions
+	// clock stamps every time the use case records.
+	clock helper.Clock
 }
 
-// NewOrderUsecase creates a new OrderUsecase with the given repository.
-func NewOrderUsecase(repo domain.OrderRepository) domain.OrderUsecase {
+// NewOrderUsecase creates a new OrderUsecase with the given repositories,
+// options and clock.
+func NewOrderUsecase(repo domain.OrderRepository, coupons domain.CouponRepository, entitlements domain.EntitlementRepository, accounts domain.VirtualAccountRepository, options OrderOptions, clock helper.Clock) domain.OrderUsecase {
+	if options.Currency == "" {
+		options.Currency = variable.DefaultCurrency
+	}
+	if len(options.QuoteSecret) == 0 {
+		options.QuoteSecret = make([]byte, 32)
+		rand.Read(options.QuoteSecret)
+	}
+	if options.QuoteTTL <= 0 {
+		options.QuoteTTL = 15 * time.Minute
+	}
+
 	return &OrderUsecase{
-		repository: repo,
+		repository:   repo,
+		coupons:      coupons,
+		entitlements: entitlements,
+		accounts:     accounts,
+		options:      options,
+		clock:        clock,
 	}
 }
 
+// abortOnConflict turns a version conflict reported by the repository into an
+// Aborted status telling the caller the current version of the order.
+func abortOnConflict(err error) error {
+	var conflict *domain.VersionConflictError
+	if errors.As(err, &conflict) {
+		return status.Error(codes.Aborted, conflict.Error())
+	}
+
+	return err
+}
+
 // Template
 // func (pu *OrderUsecase) {}
+func (o *OrderUsecase) Expire(ctx context.Context, req *pb.OrderExpireRequest) (affected int64, err error) {
+	now := helper.Unix(o.clock)
+	affected, err = o.repository.Expire(ctx, now)
+
+	return
+}
+
 func (o *OrderUsecase) Cancel(ctx context.Context, req *pb.OrderCancelRequest) (res *pb.OperationResponse, err error) {
-	return o.repository.Cancel(ctx, req)
+	res, err = o.repository.Cancel(ctx, req)
+
+	return res, abortOnConflict(err)
 }
 
-func (o *OrderUsecase) SumIncome(ctx context.Context, req *pb.OrderSumIncomeRequest) (res int64, err error) {
-	res, err = o.repository.SumIncome(ctx, req)
+func (o *OrderUsecase) SumIncome(ctx context.Context, req *pb.OrderSumIncomeRequest) (totals []domain.Money, err error) {
+	totals, err = o.repository.SumIncome(ctx, req)
 
 	return
 }
@@ -44,17 +133,323 @@ func (o *OrderUsecase) FindOne(ctx context.Context, req *pb.OrderFindOneRequest)
 	return
 }
 
+func (o *OrderUsecase) TaxReport(ctx context.Context, req *pb.OrderTaxReportRequest) (res *pb.OrderTaxReportResponse, err error) {
+	switch req.Period {
+	case "":
+		req.Period = variable.ReportPeriodMonth
+	case variable.ReportPeriodDay, variable.ReportPeriodMonth, variable.ReportPeriodYear:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown period %q", req.Period)
+	}
+
+	rows, err := o.repository.TaxReport(ctx, req)
+	if err != nil {
+		return
+	}
+
+	res = &pb.OrderTaxReportResponse{Rows: rows}
+
+	return
+}
+
 func (o *OrderUsecase) ChangeStatus(ctx context.Context, req *pb.OrderChangeStatus) (affected bool, err error) {
-	updatedTime := time.Now().Unix()
+	updatedTime := helper.Unix(o.clock)
+	if req.Status == variable.PaymentStatusSettlement {
+		return o.settle(ctx, req, updatedTime)
+	}
+
 	affected, err = o.repository.ChangeStatus(ctx, req, updatedTime)
 
+	return affected, abortOnConflict(err)
+}
+
+// Save creates the order of req and returns it as stored, with its payment
+// instructions.
+func (o *OrderUsecase) Save(ctx context.Context, req *pb.OrderCreateRequest) (res *pb.Order, err error) {
+	now := helper.Unix(o.clock)
+
+	// a quoted order is priced as it was when quoted, and must come out at
+	// the exact quoted price
+	pricedAt := now
+	var claims *quoteClaims
+	if req.QuoteToken != "" {
+		claims, err = o.verifyQuote(req.QuoteToken, quoteKindOrder, now)
+		if err != nil {
+			return
+		}
+		pricedAt = claims.IssuedAt
+	}
+
+	order, coupon, err := o.priceRequest(ctx, req, pricedAt)
+	if err != nil {
+		return
+	}
+	if claims != nil {
+		if err = claims.check(order); err != nil {
+			return
+		}
+	}
+	if gross := req.GetPayment().GetGrossAmount(); o.options.Catalog != nil && gross != 0 && gross != order.Total {
+		return nil, status.Errorf(codes.InvalidArgument, "gross_amount is %d, the order costs %d", gross, order.Total)
+	}
+	order.CreatedAt = now
+
+	if coupon != "" {
+		err = o.coupons.Redeem(ctx, coupon, order.Buyer.CustomerId)
+		if err == domain.ErrCouponExhausted {
+			err = status.Errorf(codes.FailedPrecondition, "coupon %s: %v", coupon, err)
+		}
+		if err != nil {
+			return
+		}
+
+		defer func() {
+			if err != nil {
+				o.coupons.Release(ctx, coupon, order.Buyer.CustomerId)
+			}
+		}()
+	}
+
+	if err = o.saveOrder(ctx, order); err != nil {
+		return
+	}
+
+	found, err := o.repository.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: order.OrderId})
+	if err != nil {
+		return
+	}
+	res = found.Payload
+
 	return
 }
 
-func (o *OrderUsecase) Save(ctx context.Context, req *pb.OrderCreateRequest) (err error) {
-	t := time.Now()
-	createdTime := t.Unix()
-	err = o.repository.Save(ctx, req, createdTime)
+// saveOrder saves the new order with its payment as its first attempt.
+func (o *OrderUsecase) saveOrder(ctx context.Context, order *domain.Order) error {
+	if order.Payment == (domain.OrderPayment{}) {
+		return o.repository.Save(ctx, order)
+	}
+
+	attempt := &domain.PaymentAttempt{
+		Reference:   order.Payment.OrderID,
+		PaymentType: order.Payment.PaymentType,
+		Bank:        order.Payment.Bank,
+		VaNumber:    order.Payment.VaNumber,
+		Amount:      order.Payment.GrossAmount,
+		ExpiresAt:   order.PayExp,
+		Status:      variable.PaymentAttemptActive,
+		CreatedAt:   order.CreatedAt,
+	}
+
+	return o.openAttempt(ctx, attempt, order.Currency, func() error {
+		order.Payment = attempt.Payment()
+		order.Attempts = []domain.PaymentAttempt{*attempt}
+
+		return o.repository.Save(ctx, order)
+	})
+}
+
+// openAttempt completes the payment instructions of attempt and stores it
+// with write: the QRIS payload of attempts paid by QR, or the virtual
+// account number when the service numbers the accounts of its bank and the
+// caller sent none. Numbers are handed out in turn over the range of the
+// scheme, so that a number comes back once its order is no longer pending.
+func (o *OrderUsecase) openAttempt(ctx context.Context, attempt *domain.PaymentAttempt, currency string, write func() error) (err error) {
+	if attempt.PaymentType == variable.PaymentTypeQRIS {
+		if err = o.issueQR(attempt, currency); err != nil {
+			return
+		}
+	}
+
+	scheme := o.options.VirtualAccounts.Scheme(attempt.Bank)
+	if scheme == nil || attempt.VaNumber != "" {
+		if scheme != nil && !validVANumber(scheme, attempt.VaNumber) {
+			return status.Errorf(codes.InvalidArgument, "va_number %s is not a valid %s virtual account number", attempt.VaNumber, scheme.Bank)
+		}
+
+		err = write()
+		if errors.Is(err, domain.ErrVANumberTaken) {
+			err = status.Errorf(codes.AlreadyExists, "va_number %s: %v", attempt.VaNumber, err)
+		}
+
+		return
+	}
+
+	size := scheme.To - scheme.From + 1
+	attempts := int64(vaMaxAttempts)
+	if size < attempts {
+		attempts = size
+	}
+
+	for i := int64(0); i < attempts; i++ {
+		var value int64
+		value, err = o.accounts.Next(ctx, scheme.Bank)
+		if err != nil {
+			return
+		}
+
+		attempt.VaNumber = vaNumber(scheme, scheme.From+(value-1)%size)
+		err = write()
+		if !errors.Is(err, domain.ErrVANumberTaken) {
+			return
+		}
+	}
+	attempt.VaNumber = ""
+
+	return status.Errorf(codes.ResourceExhausted, "no %s virtual account number is free", scheme.Bank)
+}
+
+// priceRequest runs the pricing shared by Create and Quote at the time at:
+// it builds the order of req with its coupon discount and taxes, and
+// returns the coupon code to redeem when the order is saved.
+func (o *OrderUsecase) priceRequest(ctx context.Context, req *pb.OrderCreateRequest, at int64) (order *domain.Order, coupon string, err error) {
+	order, err = o.newOrder(ctx, req, at)
+	if err != nil {
+		return
+	}
+
+	if req.CouponCode != "" {
+		var discount domain.OrderDiscount
+		discount, err = o.findCouponDiscount(ctx, req.CouponCode, order)
+		if err != nil {
+			return
+		}
+
+		order.Discounts = append(order.Discounts, discount)
+		order.Total -= discount.Amount
+		coupon = discount.Code
+	}
+
+	o.priceOrder(order, req.Payment)
+
+	return
+}
+
+// validateContact checks the contacts buyers are notified at: an email
+// address, and a phone number in E.164 format.
+func validateContact(buyer *pb.OrderBuyer) error {
+	if email := buyer.GetEmail(); email != "" {
+		address, err := mail.ParseAddress(email)
+		if err != nil || address.Address != email {
+			return status.Errorf(codes.InvalidArgument, "invalid email %q", email)
+		}
+	}
+
+	if phone := buyer.GetPhone(); phone != "" {
+		digits := len(phone) - 1
+		valid := phone[0] == '+' && digits >= 8 && digits <= 15
+		for _, c := range phone[1:] {
+			valid = valid && c >= '0' && c <= '9'
+		}
+
+		if !valid {
+			return status.Errorf(codes.InvalidArgument, "phone %q must be in E.164 format, e.g. +6281234567890", phone)
+		}
+	}
+
+	return nil
+}
+
+// newOrder builds the pending order of req created at now, priced before
+// discounts and taxes.
+func (o *OrderUsecase) newOrder(ctx context.Context, req *pb.OrderCreateRequest, now int64) (order *domain.Order, err error) {
+	items, err := buildLines(req)
+	if err != nil {
+		return
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = o.options.Currency
+	}
+	if !domain.ValidCurrency(currency) {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported currency %q", currency)
+	}
+	if err = validateContact(req.GetBuyer()); err != nil {
+		return
+	}
+
+	if o.options.Catalog != nil {
+		if err = o.catalogLines(ctx, items, currency); err != nil {
+			return
+		}
+	}
+
+	order = &domain.Order{
+		OrderId: req.GetPayment().GetOrderId(),
+		Buyer: domain.OrderBuyer{
+			CustomerId:   req.GetBuyer().GetCustomerId(),
+			Name:         req.GetBuyer().GetName(),
+			User:         req.GetBuyer().GetUser(),
+			Jurisdiction: req.GetBuyer().GetJurisdiction(),
+			Email:        req.GetBuyer().GetEmail(),
+			Phone:        req.GetBuyer().GetPhone(),
+			Locale:       req.GetBuyer().GetLocale(),
+		},
+		Product:    items[0].Product,
+		Items:      items,
+		Status:     variable.PaymentStatusPending,
+		CreatedAt:  now,
+		TrxTime:    req.TrxTime,
+		PayExp:     req.PayExp,
+		Version:    1,
+		Currency:   currency,
+		MerchantId: req.MerchantId,
+	}
+
+	subtotal := domain.NewMoney(currency, 0)
+	for _, line := range items {
+		if subtotal, err = subtotal.Add(domain.NewMoney(currency, line.LineTotal)); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "the order total is too large")
+		}
+	}
+
+	order.Subtotal = subtotal.Amount
+	order.Total = order.Subtotal
+
+	return
+}
+
+// priceOrder charges the taxes of the discounted order and sets the amount
+// to pay.
+func (o *OrderUsecase) priceOrder(order *domain.Order, payment *pb.OrderPayment) {
+	tax := o.options.Tax
+	order.Taxes, order.TaxTotal = computeTaxes(tax, order.Items, order.Buyer.Jurisdiction)
+	order.TaxInclusive = tax != nil && tax.Inclusive
+	if !order.TaxInclusive {
+		order.Total += order.TaxTotal
+	}
+
+	if payment != nil {
+		// the amount to pay is always the server-side total
+		order.Payment = domain.OrderPayment{
+			PaymentType: payment.PaymentType,
+			OrderID:     payment.OrderId,
+			Bank:        payment.Bank,
+			VaNumber:    payment.VaNumber,
+			GrossAmount: order.Total,
+		}
+	}
+}
+
+// findCouponDiscount checks that the coupon code applies to order, returning
+// the discount to record on the order. Its use is counted when the order is
+// saved.
+func (o *OrderUsecase) findCouponDiscount(ctx context.Context, code string, order *domain.Order) (discount domain.OrderDiscount, err error) {
+	coupon, err := o.coupons.FindOne(ctx, normalizeCode(code))
+	if err != nil {
+		return
+	}
+
+	if coupon == nil {
+		return discount, status.Errorf(codes.InvalidArgument, "coupon %s does not exist", code)
+	}
+
+	// coupons created before coupons had a currency are in the default one
+	if coupon.Currency == "" {
+		coupon.Currency = o.options.Currency
+	}
+
+	discount, err = couponDiscount(coupon, order.Items, order.Currency, order.CreatedAt)
 
 	return
 }
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"order/domain"
+	"order/helper"
+	"sort"
+	"strings"
+)
+
+// OutboxRelay publishes the events of the outbox to brokers, at least once
+// and in order for each order. Only one relay should run per outbox.
+type OutboxRelay struct {
+	outbox domain.OutboxRepository
+	// brokers are the brokers by name, published to in the order of names.
+	brokers map[string]domain.Broker
+	names   []string
+	policy  domain.OutboxPolicy
+	clock   helper.Clock
+}
+
+// NewOutboxRelay creates an OutboxRelay publishing to brokers, named after
+// their keys, and fills the settings missing from policy with defaults.
+func NewOutboxRelay(outbox domain.OutboxRepository, brokers map[string]domain.Broker, policy domain.OutboxPolicy, clock helper.Clock) *OutboxRelay {
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = 100
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 10
+	}
+	if policy.Backoff <= 0 {
+		policy.Backoff = 5
+	}
+
+	names := make([]string, 0, len(brokers))
+	for name := range brokers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &OutboxRelay{
+		outbox:  outbox,
+		brokers: brokers,
+		names:   names,
+		policy:  policy,
+		clock:   clock,
+	}
+}
+
+// publishErrors are the errors of the brokers an event failed on, by name.
+type publishErrors map[string]error
+
+func (e publishErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for name, err := range e {
+		messages = append(messages, name+": "+err.Error())
+	}
+	sort.Strings(messages)
+
+	return strings.Join(messages, "; ")
+}
+
+// rejected reports whether every broker failing rejected the event, so that
+// publishing it again is bound to fail the same way.
+func (e publishErrors) rejected() bool {
+	for _, err := range e {
+		if !errors.Is(err, domain.ErrEventRejected) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// publish publishes event to the brokers it was not delivered to yet, adding
+// the ones accepting it to event.Delivered. A failing broker does not keep
+// the event from the others.
+func (r *OutboxRelay) publish(ctx context.Context, event *domain.OutboxEvent) publishErrors {
+	delivered := map[string]bool{}
+	for _, name := range event.Delivered {
+		delivered[name] = true
+	}
+
+	errs := publishErrors{}
+	for _, name := range r.names {
+		if delivered[name] {
+			continue
+		}
+
+		if err := r.brokers[name].Publish(ctx, *event); err != nil {
+			errs[name] = err
+			continue
+		}
+		event.Delivered = append(event.Delivered, name)
+	}
+
+	return errs
+}
+
+// retryAt returns when an event failing for the attempts-th time is retried.
+func (r *OutboxRelay) retryAt(attempts int64, now int64) int64 {
+	shift := attempts - 1
+	if shift > 10 {
+		shift = 10
+	}
+
+	return now + r.policy.Backoff<<shift
+}
+
+// Relay publishes a batch of pending events. An event that fails on a broker
+// is retried later on that broker only, and holds back the following events
+// of its order, until it is dead-lettered after too many attempts or when
+// every broker it failed on rejected it.
+func (r *OutboxRelay) Relay(ctx context.Context) (published int, failed int, dead int, err error) {
+	now := helper.Unix(r.clock)
+	events, err := r.outbox.Pending(ctx, r.policy.BatchSize)
+	if err != nil {
+		return
+	}
+
+	blocked := map[string]bool{}
+	for _, event := range events {
+		if blocked[event.OrderId] {
+			continue
+		}
+		if event.NextAttemptAt > now {
+			blocked[event.OrderId] = true
+			continue
+		}
+
+		publishErr := r.publish(ctx, &event)
+		if len(publishErr) == 0 {
+			if err = r.outbox.Published(ctx, event.EventId); err != nil {
+				return
+			}
+			published++
+			continue
+		}
+
+		event.Attempts++
+		event.LastError = publishErr.Error()
+		if event.Attempts >= r.policy.MaxAttempts || publishErr.rejected() {
+			log.Printf("outbox: dead-lettering %s event %s of order %s: %v", event.Type, event.EventId, event.OrderId, publishErr)
+
+			event.DeadAt = now
+			if err = r.outbox.DeadLetter(ctx, event); err != nil {
+				return
+			}
+			dead++
+			continue
+		}
+
+		event.NextAttemptAt = r.retryAt(event.Attempts, now)
+		if err = r.outbox.Failed(ctx, event); err != nil {
+			return
+		}
+		blocked[event.OrderId] = true
+		failed++
+	}
+
+	return
+}
+
+// Close closes the brokers, returning the first error.
+func (r *OutboxRelay) Close() (err error) {
+	for _, name := range r.names {
+		if closeErr := r.brokers[name].Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return
+}
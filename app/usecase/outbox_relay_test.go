@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order/domain"
+	"testing"
+	"time"
+)
+
+// testBroker fails with the errors it is given in turn, then accepts the
+// events, counting the ones it accepted.
+type testBroker struct {
+	errs      []error
+	published int
+}
+
+func (b *testBroker) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	if len(b.errs) > 0 {
+		err := b.errs[0]
+		b.errs = b.errs[1:]
+		return err
+	}
+
+	b.published++
+	return nil
+}
+
+func (b *testBroker) Close() error {
+	return nil
+}
+
+func TestRelayWithFailingBroker(t *testing.T) {
+	down := errors.New("broker down")
+	rejected := fmt.Errorf("%w: record too large", domain.ErrEventRejected)
+
+	tests := []struct {
+		name string
+		errs []error
+		// the runs publishing the event, and whether it ends dead-lettered
+		runs int
+		dead bool
+	}{
+		{"recovers", []error{down, down}, 3, false},
+		{"rejects", []error{rejected}, 1, true},
+		{"keeps failing", []error{down, down, down}, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			o, clock, outbox := newTestUsecaseOutbox(t, OrderOptions{})
+			saveTestOrder(t, o, clock)
+
+			good, bad := &testBroker{}, &testBroker{errs: tt.errs}
+			relay := NewOutboxRelay(outbox, map[string]domain.Broker{"good": good, "bad": bad}, domain.OutboxPolicy{MaxAttempts: 3, Backoff: 1}, clock)
+
+			for i := 0; i < tt.runs; i++ {
+				if _, _, _, err := relay.Relay(ctx); err != nil {
+					t.Fatalf("Relay: %v", err)
+				}
+				clock.Add(time.Hour)
+			}
+
+			// the broker that accepted the event is not published it again
+			if good.published != 1 {
+				t.Errorf("good broker got the event %d times; want once", good.published)
+			}
+			if want := map[bool]int{false: 1, true: 0}[tt.dead]; bad.published != want {
+				t.Errorf("failing broker got the event %d times; want %d", bad.published, want)
+			}
+
+			pending, err := outbox.Pending(ctx, 10)
+			if err != nil || len(pending) != 0 {
+				t.Errorf("Pending = %+v, %v; want none", pending, err)
+			}
+			dead, err := outbox.DeadLetters(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.dead {
+				if len(dead) != 0 {
+					t.Errorf("DeadLetters = %+v; want none", dead)
+				}
+				return
+			}
+			if len(dead) != 1 || len(dead[0].Delivered) != 1 || dead[0].Delivered[0] != "good" {
+				t.Errorf("DeadLetters = %+v; want the event delivered to the good broker only", dead)
+			}
+		})
+	}
+}
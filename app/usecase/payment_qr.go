@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QR code images are 256 pixels wide unless asked otherwise, and between
+// the bounds below.
+const (
+	qrDefaultSize = 256
+	qrMinSize     = 64
+	qrMaxSize     = 2048
+)
+
+// qrReferenceLength is the longest reference label a QRIS payload carries.
+const qrReferenceLength = 25
+
+// issueQR sets the QRIS payload charging the amount of attempt in currency,
+// referenced by the reference of the attempt. Attempts referenced by IDs
+// too long for a payload, e.g. those of renewals or retries, get a random
+// reference that fits, and the payment is reported back under it.
+func (o *OrderUsecase) issueQR(attempt *domain.PaymentAttempt, currency string) error {
+	if o.options.QRIS == nil {
+		return status.Error(codes.FailedPrecondition, "QRIS payments are not enabled")
+	}
+
+	if len(attempt.Reference) > qrReferenceLength {
+		attempt.Reference = helper.NewID()[:qrReferenceLength-1]
+	}
+
+	payload, err := o.options.QRIS.Payload(attempt.Amount, currency, attempt.Reference)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	attempt.QrString = payload
+
+	return nil
+}
+
+// PaymentQR renders the QR code of a pending order paid by QR as a PNG
+// image. With a user ID, only the orders of that customer are rendered.
+func (o *OrderUsecase) PaymentQR(ctx context.Context, req *pb.PaymentQRRequest) (res *pb.PaymentQR, err error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	size := req.Size
+	if size == 0 {
+		size = qrDefaultSize
+	}
+	if size < qrMinSize || size > qrMaxSize {
+		return nil, status.Errorf(codes.InvalidArgument, "size must be between %d and %d pixels", qrMinSize, qrMaxSize)
+	}
+
+	found, err := o.repository.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: req.OrderId})
+	if err != nil {
+		return
+	}
+	if found.IsEmpty || (req.UserId != "" && found.Payload.GetBuyer().GetCustomerId() != req.UserId) {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.OrderId)
+	}
+
+	order := found.Payload
+	payload := order.GetPayment().GetQrString()
+	switch {
+	case payload == "":
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s is not paid by QR", req.OrderId)
+	case order.Status != variable.PaymentStatusPending:
+		return nil, status.Errorf(codes.FailedPrecondition, "order %s is %s, there is nothing left to pay", req.OrderId, order.Status)
+	case o.options.QRIS == nil:
+		return nil, status.Error(codes.FailedPrecondition, "QRIS payments are not enabled")
+	}
+
+	image, err := o.options.QRIS.Render(payload, int(size))
+	if err != nil {
+		return
+	}
+
+	res = &pb.PaymentQR{
+		OrderId:     order.OrderId,
+		QrString:    payload,
+		ContentType: "image/png",
+		Content:     image,
+	}
+
+	return
+}
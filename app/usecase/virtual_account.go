@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"fmt"
+	"order/domain"
+	"order/variable"
+	"strings"
+)
+
+// vaMaxAttempts bounds the numbers tried for an order, the numbers still
+// held by pending orders being skipped.
+const vaMaxAttempts = 20
+
+// luhnDigit returns the Luhn check digit of digits.
+func luhnDigit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		// the digits at even positions from the check digit are doubled
+		if (len(digits)-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+// mod11Digit returns the modulo 11 check digit of digits, weighting them 2
+// to 7 from the right. Remainders leaving 10 or 11 give 0.
+func mod11Digit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		weight := 2 + (len(digits)-1-i)%6
+		sum += int(digits[i]-'0') * weight
+	}
+
+	d := 11 - sum%11
+	if d >= 10 {
+		d = 0
+	}
+
+	return byte('0' + d)
+}
+
+// vaNumber returns the virtual account number of serial under scheme.
+func vaNumber(scheme *domain.VirtualAccountScheme, serial int64) string {
+	width := scheme.Length - len(scheme.Prefix)
+	if scheme.CheckDigit != variable.VACheckDigitNone {
+		width--
+	}
+
+	number := fmt.Sprintf("%s%0*d", scheme.Prefix, width, serial)
+	switch scheme.CheckDigit {
+	case variable.VACheckDigitLuhn:
+		number += string(luhnDigit(number))
+	case variable.VACheckDigitMod11:
+		number += string(mod11Digit(number))
+	}
+
+	return number
+}
+
+// validVANumber reports whether number is a virtual account number of
+// scheme: of its length, starting with its prefix and ending with the right
+// check digit.
+func validVANumber(scheme *domain.VirtualAccountScheme, number string) bool {
+	if len(number) != scheme.Length || !strings.HasPrefix(number, scheme.Prefix) {
+		return false
+	}
+	for _, c := range number {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	body, check := number[:len(number)-1], number[len(number)-1]
+	switch scheme.CheckDigit {
+	case variable.VACheckDigitLuhn:
+		return luhnDigit(body) == check
+	case variable.VACheckDigitMod11:
+		return mod11Digit(body) == check
+	}
+
+	return true
+}
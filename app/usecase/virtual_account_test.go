@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckDigits(t *testing.T) {
+	tests := []struct {
+		digits string
+		luhn   byte
+		mod11  byte
+	}{
+		{"7992739871", '3', '6'},
+		{"12345", '5', '5'},
+		{"0", '0', '0'},
+		// a remainder leaving 10 gives 0
+		{"6", '7', '0'},
+		{"5", '9', '1'},
+	}
+	for _, tt := range tests {
+		if got := luhnDigit(tt.digits); got != tt.luhn {
+			t.Errorf("luhnDigit(%s) = %c; want %c", tt.digits, got, tt.luhn)
+		}
+		if got := mod11Digit(tt.digits); got != tt.mod11 {
+			t.Errorf("mod11Digit(%s) = %c; want %c", tt.digits, got, tt.mod11)
+		}
+	}
+}
+
+func TestVANumber(t *testing.T) {
+	tests := []struct {
+		scheme domain.VirtualAccountScheme
+		serial int64
+		want   string
+	}{
+		{domain.VirtualAccountScheme{Prefix: "88", Length: 8, CheckDigit: variable.VACheckDigitNone}, 42, "88000042"},
+		{domain.VirtualAccountScheme{Prefix: "88", Length: 8, CheckDigit: variable.VACheckDigitLuhn}, 42, "8800042" + string(luhnDigit("8800042"))},
+		{domain.VirtualAccountScheme{Prefix: "7001", Length: 10, CheckDigit: variable.VACheckDigitMod11}, 12345, "700112345" + string(mod11Digit("700112345"))},
+	}
+	for _, tt := range tests {
+		number := vaNumber(&tt.scheme, tt.serial)
+		if number != tt.want || !validVANumber(&tt.scheme, number) {
+			t.Errorf("vaNumber(%s, %d) = %s; want the valid %s", tt.scheme.CheckDigit, tt.serial, number, tt.want)
+		}
+	}
+
+	luhn := &domain.VirtualAccountScheme{Prefix: "88", Length: 8, CheckDigit: variable.VACheckDigitLuhn}
+	valid := vaNumber(luhn, 42)
+	wrongDigit := valid[:7] + string('0'+(valid[7]-'0'+1)%10)
+	for _, number := range []string{wrongDigit, "8800004", "880000420", "9900042" + valid[7:], "88a0042" + valid[7:]} {
+		if validVANumber(luhn, number) {
+			t.Errorf("validVANumber(%s) = true; want false", number)
+		}
+	}
+}
+
+func TestVAAllocation(t *testing.T) {
+	ctx := context.Background()
+	accounts := &domain.VirtualAccountPolicy{Schemes: []domain.VirtualAccountScheme{
+		{Bank: "bca", Prefix: "88", Length: 5, From: 1, To: 3, CheckDigit: variable.VACheckDigitNone},
+	}}
+	o, clock := newTestUsecase(t, OrderOptions{VirtualAccounts: accounts})
+
+	save := func(orderId string, va string) (*pb.Order, error) {
+		return o.Save(ctx, &pb.OrderCreateRequest{
+			Buyer:   &pb.OrderBuyer{CustomerId: "c1", Name: "Customer"},
+			Product: &pb.OrderProduct{ProductId: "p1", Name: "Premium", Price: 10000},
+			Payment: &pb.OrderPayment{PaymentType: "bank_transfer", OrderId: orderId, Bank: "bca", VaNumber: va},
+			TrxTime: helper.Unix(clock),
+			PayExp:  helper.Unix(clock) + 3600,
+		})
+	}
+
+	steps := []struct {
+		name    string
+		orderId string
+		va      string
+		// settle settles the order of the step before saving
+		settle string
+		code   codes.Code
+		want   string
+	}{
+		{"first number", "o1", "", "", codes.OK, "88001"},
+		{"next number", "o2", "", "", codes.OK, "88002"},
+		{"number sent by the caller", "o3", "88003", "", codes.OK, "88003"},
+		{"every number held by a pending order", "o4", "", "", codes.ResourceExhausted, ""},
+		{"number outside the scheme", "o5", "99001", "", codes.InvalidArgument, ""},
+		{"number held by a pending order", "o6", "88002", "", codes.AlreadyExists, ""},
+		{"number freed by a settled order", "o7", "", "o1", codes.OK, "88001"},
+	}
+
+	for _, step := range steps {
+		if step.settle != "" {
+			if _, err := o.ChangeStatus(ctx, &pb.OrderChangeStatus{OrderId: step.settle, Status: variable.PaymentStatusSettlement, SettlementTime: helper.Unix(clock)}); err != nil {
+				t.Fatalf("settle %s: %v", step.settle, err)
+			}
+		}
+
+		order, err := save(step.orderId, step.va)
+		if code := status.Code(err); code != step.code {
+			t.Fatalf("%s: Save = %v; want %s", step.name, err, step.code)
+		}
+		if err != nil {
+			continue
+		}
+		if va := order.GetPayment().GetVaNumber(); va != step.want {
+			t.Errorf("%s: order %s got %s; want %s", step.name, step.orderId, va, step.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"order/domain"
+	"order/helper"
+	"order/variable"
+)
+
+// WebhookBroker queues the webhook deliveries of the order events published
+// to it, one per subscription of the merchant of the order wanting the
+// event. WebhookUsecase.Dispatch sends them.
+type WebhookBroker struct {
+	repository domain.WebhookRepository
+	clock      helper.Clock
+}
+
+func NewWebhookBroker(repo domain.WebhookRepository, clock helper.Clock) domain.Broker {
+	return &WebhookBroker{
+		repository: repo,
+		clock:      clock,
+	}
+}
+
+func (w *WebhookBroker) Publish(ctx context.Context, event domain.OutboxEvent) error {
+	var order struct {
+		MerchantId string `json:"merchant_id"`
+	}
+	if err := json.Unmarshal(event.Payload, &order); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrEventRejected, err)
+	}
+	if order.MerchantId == "" {
+		return nil
+	}
+
+	subscriptions, err := w.repository.FindSubscriptions(ctx, order.MerchantId)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrEventRejected, err)
+	}
+
+	now := helper.Unix(w.clock)
+	var deliveries []domain.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if !subscription.Wants(event.Type) {
+			continue
+		}
+
+		deliveries = append(deliveries, domain.WebhookDelivery{
+			DeliveryId:     helper.NewID(),
+			SubscriptionId: subscription.SubscriptionId,
+			MerchantId:     subscription.MerchantId,
+			EventId:        event.EventId,
+			EventType:      event.Type,
+			OrderId:        event.OrderId,
+			Payload:        string(body),
+			Status:         variable.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+
+	return w.repository.Enqueue(ctx, deliveries)
+}
+
+func (w *WebhookBroker) Close() error {
+	return nil
+}
@@ -0,0 +1,439 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// webhookLease is how long, in seconds, a claimed delivery is left to its
+// dispatcher before another one retries it.
+const webhookLease = 5 * 60
+
+// Headers of webhook requests. The signature is "v1=" followed by the hex
+// HMAC-SHA256, keyed with the subscription secret, of the timestamp, a dot
+// and the body.
+const (
+	webhookIdHeader        = "X-Webhook-Id"
+	webhookEventHeader     = "X-Webhook-Event"
+	webhookTimestampHeader = "X-Webhook-Timestamp"
+	webhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// WebhookUsecase manages the webhook subscriptions of merchants and sends
+// their deliveries.
+type WebhookUsecase struct {
+	repository domain.WebhookRepository
+	policy     domain.WebhookPolicy
+	client     *http.Client
+	clock      helper.Clock
+}
+
+// NewWebhookUsecase creates a new WebhookUsecase with the given repository
+// and clock, filling the settings missing from policy with defaults.
+func NewWebhookUsecase(repo domain.WebhookRepository, policy domain.WebhookPolicy, clock helper.Clock) domain.WebhookUsecase {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 8
+	}
+	if policy.Backoff <= 0 {
+		policy.Backoff = 30
+	}
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = 50
+	}
+	if policy.Timeout <= 0 {
+		policy.Timeout = 10 * time.Second
+	}
+
+	client := &http.Client{
+		Timeout: policy.Timeout,
+		// a redirect is answered like any other non-2xx response
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return &WebhookUsecase{
+		repository: repo,
+		policy:     policy,
+		client:     client,
+		clock:      clock,
+	}
+}
+
+func signWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookSecret() string {
+	return "whsec_" + helper.NewID()
+}
+
+// parseSubscriptionResponse converts a subscription into its protobuf
+// representation, leaving the secret out unless withSecret.
+func parseSubscriptionResponse(subscription domain.WebhookSubscription, withSecret bool) *pb.WebhookSubscription {
+	res := &pb.WebhookSubscription{
+		SubscriptionId: subscription.SubscriptionId,
+		MerchantId:     subscription.MerchantId,
+		Url:            subscription.URL,
+		EventTypes:     subscription.EventTypes,
+		Disabled:       subscription.Disabled,
+		CreatedAt:      subscription.CreatedAt,
+		UpdatedAt:      subscription.UpdatedAt,
+	}
+	if withSecret {
+		res.Secret = subscription.Secret
+	}
+
+	return res
+}
+
+func parseDeliveryResponse(delivery domain.WebhookDelivery) *pb.WebhookDelivery {
+	var attempts []*pb.WebhookAttempt
+	for _, attempt := range delivery.Attempts {
+		attempts = append(attempts, &pb.WebhookAttempt{
+			At:         attempt.At,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMs: attempt.DurationMs,
+		})
+	}
+
+	return &pb.WebhookDelivery{
+		DeliveryId:     delivery.DeliveryId,
+		SubscriptionId: delivery.SubscriptionId,
+		MerchantId:     delivery.MerchantId,
+		EventId:        delivery.EventId,
+		EventType:      delivery.EventType,
+		OrderId:        delivery.OrderId,
+		Status:         delivery.Status,
+		Attempts:       attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+		Payload:        delivery.Payload,
+	}
+}
+
+func validateWebhook(merchantId string, rawURL string, eventTypes []string) error {
+	if merchantId == "" {
+		return status.Error(codes.InvalidArgument, "merchant_id is required")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return status.Error(codes.InvalidArgument, "url must be an absolute http or https URL")
+	}
+
+	for _, eventType := range eventTypes {
+		known := false
+		for _, orderEvent := range variable.OrderEventTypes {
+			known = known || eventType == orderEvent
+		}
+
+		if !known {
+			return status.Errorf(codes.InvalidArgument, "unknown event type %q", eventType)
+		}
+	}
+
+	return nil
+}
+
+// Create subscribes the merchant to its order events. The secret signing the
+// deliveries is generated unless given, and only returned here and when it
+// is rotated.
+func (w *WebhookUsecase) Create(ctx context.Context, req *pb.WebhookSubscription) (res *pb.WebhookSubscription, err error) {
+	if err = validateWebhook(req.MerchantId, req.Url, req.EventTypes); err != nil {
+		return
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret = newWebhookSecret()
+	}
+	if len(secret) < 16 {
+		return nil, status.Error(codes.InvalidArgument, "secret must be at least 16 characters long")
+	}
+
+	now := helper.Unix(w.clock)
+	subscription := domain.WebhookSubscription{
+		SubscriptionId: helper.NewID(),
+		MerchantId:     req.MerchantId,
+		URL:            req.Url,
+		Secret:         secret,
+		EventTypes:     req.EventTypes,
+		Disabled:       req.Disabled,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err = w.repository.SaveSubscription(ctx, &subscription); err != nil {
+		return
+	}
+
+	return parseSubscriptionResponse(subscription, true), nil
+}
+
+// Update replaces the URL, event types and state of a subscription, and
+// rotates its secret when asked to.
+func (w *WebhookUsecase) Update(ctx context.Context, req *pb.WebhookUpdateRequest) (res *pb.WebhookSubscription, err error) {
+	if err = validateWebhook(req.MerchantId, req.Url, req.EventTypes); err != nil {
+		return
+	}
+
+	subscription, err := w.repository.FindSubscription(ctx, req.MerchantId, req.SubscriptionId)
+	if err != nil {
+		return
+	}
+	if subscription == nil {
+		return nil, status.Errorf(codes.NotFound, "merchant %s has no webhook %s", req.MerchantId, req.SubscriptionId)
+	}
+
+	subscription.URL = req.Url
+	subscription.EventTypes = req.EventTypes
+	subscription.Disabled = req.Disabled
+	subscription.UpdatedAt = helper.Unix(w.clock)
+	if req.RotateSecret {
+		subscription.Secret = newWebhookSecret()
+	}
+
+	found, err := w.repository.UpdateSubscription(ctx, subscription)
+	if err != nil {
+		return
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "merchant %s has no webhook %s", req.MerchantId, req.SubscriptionId)
+	}
+
+	return parseSubscriptionResponse(*subscription, req.RotateSecret), nil
+}
+
+// Delete removes a subscription. Its pending deliveries fail when they are
+// next sent.
+func (w *WebhookUsecase) Delete(ctx context.Context, req *pb.WebhookDeleteRequest) (res *pb.OperationResponse, err error) {
+	if req.MerchantId == "" || req.SubscriptionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "merchant_id and subscription_id are required")
+	}
+
+	found, err := w.repository.DeleteSubscription(ctx, req.MerchantId, req.SubscriptionId)
+	if err != nil {
+		return
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "merchant %s has no webhook %s", req.MerchantId, req.SubscriptionId)
+	}
+
+	return &pb.OperationResponse{IsAffected: true}, nil
+}
+
+func (w *WebhookUsecase) List(ctx context.Context, req *pb.WebhookListRequest) (res *pb.WebhookListResponse, err error) {
+	if req.MerchantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "merchant_id is required")
+	}
+
+	subscriptions, err := w.repository.FindSubscriptions(ctx, req.MerchantId)
+	if err != nil {
+		return
+	}
+
+	res = &pb.WebhookListResponse{}
+	for _, subscription := range subscriptions {
+		res.Subscriptions = append(res.Subscriptions, parseSubscriptionResponse(subscription, false))
+	}
+
+	return
+}
+
+// ListDeliveries returns the delivery log of a merchant, newest first.
+func (w *WebhookUsecase) ListDeliveries(ctx context.Context, req *pb.WebhookDeliveryListRequest) (res *pb.WebhookDeliveryListResponse, err error) {
+	if req.MerchantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "merchant_id is required")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	deliveries, err := w.repository.FindDeliveries(ctx, domain.WebhookDeliveryFilter{
+		MerchantId:     req.MerchantId,
+		SubscriptionId: req.SubscriptionId,
+		Status:         req.Status,
+		OrderId:        req.OrderId,
+		Limit:          limit,
+	})
+	if err != nil {
+		return
+	}
+
+	res = &pb.WebhookDeliveryListResponse{}
+	for _, delivery := range deliveries {
+		res.Deliveries = append(res.Deliveries, parseDeliveryResponse(delivery))
+	}
+
+	return
+}
+
+// Replay sends a delivery again on the next dispatch, whatever its status,
+// with a fresh budget of attempts.
+func (w *WebhookUsecase) Replay(ctx context.Context, req *pb.WebhookReplayRequest) (res *pb.WebhookDelivery, err error) {
+	if req.DeliveryId == "" {
+		return nil, status.Error(codes.InvalidArgument, "delivery_id is required")
+	}
+
+	found, err := w.repository.Replay(ctx, req.DeliveryId, helper.Unix(w.clock))
+	if err != nil {
+		return
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "webhook delivery %s not found", req.DeliveryId)
+	}
+
+	delivery, err := w.repository.FindDelivery(ctx, req.DeliveryId)
+	if err != nil || delivery == nil {
+		return nil, err
+	}
+
+	return parseDeliveryResponse(*delivery), nil
+}
+
+// retryAt returns when a delivery failing for the failures-th time is
+// retried: the backoff doubles with each failure, and is jittered between
+// half and all of it so that retries spread out.
+func (w *WebhookUsecase) retryAt(failures int64, now int64) int64 {
+	shift := failures - 1
+	if shift > 10 {
+		shift = 10
+	}
+
+	delay := w.policy.Backoff << shift
+	half := delay / 2
+
+	return now + delay - half + rand.Int63n(half+1)
+}
+
+// send posts the delivery to the subscription and returns the attempt.
+func (w *WebhookUsecase) send(ctx context.Context, subscription *domain.WebhookSubscription, delivery domain.WebhookDelivery) (attempt domain.WebhookAttempt) {
+	started := w.clock.Now()
+	attempt.At = started.Unix()
+	defer func() {
+		attempt.DurationMs = w.clock.Now().Sub(started).Milliseconds()
+	}()
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return
+	}
+
+	timestamp := strconv.FormatInt(attempt.At, 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookIdHeader, delivery.DeliveryId)
+	req.Header.Set(webhookEventHeader, delivery.EventType)
+	req.Header.Set(webhookTimestampHeader, timestamp)
+	req.Header.Set(webhookSignatureHeader, signWebhook(subscription.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+
+	// drain a little of the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+
+	attempt.StatusCode = int64(resp.StatusCode)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		attempt.Error = fmt.Sprintf("unexpected status %s", resp.Status)
+	}
+
+	return
+}
+
+// deliver sends a claimed delivery and records the outcome.
+func (w *WebhookUsecase) deliver(ctx context.Context, delivery domain.WebhookDelivery, res *pb.WebhookDispatchResponse) error {
+	subscription, err := w.repository.FindSubscription(ctx, delivery.MerchantId, delivery.SubscriptionId)
+	if err != nil {
+		return err
+	}
+
+	var attempt domain.WebhookAttempt
+	switch {
+	case subscription == nil:
+		attempt = domain.WebhookAttempt{At: helper.Unix(w.clock), Error: "the webhook was deleted"}
+	case subscription.Disabled:
+		attempt = domain.WebhookAttempt{At: helper.Unix(w.clock), Error: "the webhook is disabled"}
+	default:
+		attempt = w.send(ctx, subscription, delivery)
+	}
+
+	now := helper.Unix(w.clock)
+	delivery.UpdatedAt = now
+	switch {
+	case attempt.Error == "":
+		delivery.Status = variable.WebhookDeliverySucceeded
+		res.Delivered++
+	case subscription == nil || subscription.Disabled || delivery.Failures+1 >= w.policy.MaxAttempts:
+		delivery.Status = variable.WebhookDeliveryFailed
+		delivery.Failures++
+		res.Failed++
+	default:
+		delivery.Failures++
+		delivery.NextAttemptAt = w.retryAt(delivery.Failures, now)
+		res.Retried++
+	}
+
+	return w.repository.RecordAttempt(ctx, delivery, attempt)
+}
+
+// Dispatch sends the deliveries due, retrying the failed ones with
+// exponential backoff until they run out of attempts.
+func (w *WebhookUsecase) Dispatch(ctx context.Context, req *pb.WebhookDispatchRequest) (res *pb.WebhookDispatchResponse, err error) {
+	now := helper.Unix(w.clock)
+	deliveries, err := w.repository.DueDeliveries(ctx, now, w.policy.BatchSize)
+	if err != nil {
+		return
+	}
+
+	res = &pb.WebhookDispatchResponse{}
+	for _, delivery := range deliveries {
+		claimed, err := w.repository.ClaimDelivery(ctx, delivery.DeliveryId, delivery.NextAttemptAt, now+webhookLease)
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := w.deliver(ctx, delivery, res); err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"order/app/repository"
+	"order/domain"
+	"order/helper"
+	"order/pb"
+	"order/variable"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "whsec_0123456789abcdef"
+
+// webhookReceiver is a merchant endpoint answering with the statuses it is
+// given in turn, the last one from then on, and verifying the signature of
+// every request it receives.
+type webhookReceiver struct {
+	t        *testing.T
+	clock    helper.Clock
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+
+	timestamp := req.Header.Get("X-Webhook-Timestamp")
+	if want := strconv.FormatInt(r.clock.Now().Unix(), 10); timestamp != want {
+		r.t.Errorf("timestamp header = %q; want %q", timestamp, want)
+	}
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write([]byte(timestamp + "." + string(body)))
+	if signature, want := req.Header.Get("X-Webhook-Signature"), "v1="+hex.EncodeToString(mac.Sum(nil)); signature != want {
+		r.t.Errorf("signature header = %q; want %q", signature, want)
+	}
+
+	status := r.statuses[0]
+	if len(r.statuses) > 1 {
+		r.statuses = r.statuses[1:]
+	}
+	if status >= 300 && status < 400 {
+		w.Header().Set("Location", "/moved")
+	}
+	w.WriteHeader(status)
+}
+
+func (r *webhookReceiver) answer(statuses ...int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statuses = statuses
+}
+
+func (r *webhookReceiver) received() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.requests)
+}
+
+// newWebhookTest subscribes merchant m1 to the receiver answering statuses,
+// and queues the delivery of one settled order event.
+func newWebhookTest(t *testing.T, policy domain.WebhookPolicy, statuses ...int) (domain.WebhookUsecase, *helper.FakeClock, *webhookReceiver) {
+	t.Helper()
+
+	ctx := context.Background()
+	clock := helper.NewFakeClock(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC))
+	receiver := &webhookReceiver{t: t, clock: clock, statuses: statuses}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	repo := repository.NewWebhookMemoryRepository()
+	w := NewWebhookUsecase(repo, policy, clock)
+	if _, err := w.Create(ctx, &pb.WebhookSubscription{MerchantId: "m1", Url: server.URL + "/hooks", Secret: testWebhookSecret}); err != nil {
+		t.Fatal(err)
+	}
+
+	event := domain.OutboxEvent{
+		EventId: "e1",
+		Type:    variable.OrderEventSettled,
+		OrderId: "o1",
+		Payload: json.RawMessage(`{"order_id":"o1","merchant_id":"m1"}`),
+	}
+	if err := NewWebhookBroker(repo, clock).Publish(ctx, event); err != nil {
+		t.Fatal(err)
+	}
+
+	return w, clock, receiver
+}
+
+func dispatch(t *testing.T, w domain.WebhookUsecase) *pb.WebhookDispatchResponse {
+	t.Helper()
+
+	res, err := w.Dispatch(context.Background(), &pb.WebhookDispatchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func onlyDelivery(t *testing.T, w domain.WebhookUsecase) *pb.WebhookDelivery {
+	t.Helper()
+
+	res, err := w.ListDeliveries(context.Background(), &pb.WebhookDeliveryListRequest{MerchantId: "m1"})
+	if err != nil || len(res.Deliveries) != 1 {
+		t.Fatalf("ListDeliveries = %v, %v; want one delivery", res, err)
+	}
+
+	return res.Deliveries[0]
+}
+
+// checkRetry fails unless delivery is retried after the failures-th failure
+// at now, within the jittered backoff.
+func checkRetry(t *testing.T, delivery *pb.WebhookDelivery, backoff int64, failures int64, now int64) {
+	t.Helper()
+
+	delay := backoff << (failures - 1)
+	if wait := delivery.NextAttemptAt - now; wait < delay-delay/2 || wait > delay {
+		t.Errorf("retry %d after %ds; want between %d and %d", failures, wait, delay-delay/2, delay)
+	}
+}
+
+func TestWebhookDispatchSignsAndRetries(t *testing.T) {
+	w, clock, receiver := newWebhookTest(t, domain.WebhookPolicy{MaxAttempts: 3, Backoff: 60}, http.StatusInternalServerError, http.StatusOK)
+
+	if res := dispatch(t, w); res.Retried != 1 || res.Delivered != 0 {
+		t.Fatalf("first dispatch = %v; want a retry", res)
+	}
+
+	req, body := receiver.requests[0], receiver.bodies[0]
+	if req.URL.Path != "/hooks" || req.Header.Get("X-Webhook-Event") != variable.OrderEventSettled || req.Header.Get("X-Webhook-Id") == "" {
+		t.Errorf("request = %s %v", req.URL, req.Header)
+	}
+	var event domain.OutboxEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.EventId != "e1" || event.OrderId != "o1" {
+		t.Errorf("body = %s, %v; want the event", body, err)
+	}
+
+	delivery := onlyDelivery(t, w)
+	if delivery.Status != variable.WebhookDeliveryPending || len(delivery.Attempts) != 1 || delivery.Attempts[0].StatusCode != 500 {
+		t.Errorf("delivery after a 500 = %v", delivery)
+	}
+	checkRetry(t, delivery, 60, 1, clock.Now().Unix())
+
+	// nothing is sent before the retry is due
+	if res := dispatch(t, w); res.Retried+res.Delivered+res.Failed != 0 || receiver.received() != 1 {
+		t.Errorf("dispatch before the retry = %v", res)
+	}
+
+	clock.Set(time.Unix(delivery.NextAttemptAt, 0))
+	if res := dispatch(t, w); res.Delivered != 1 {
+		t.Fatalf("dispatch at the retry = %v; want delivered", res)
+	}
+	if delivery := onlyDelivery(t, w); delivery.Status != variable.WebhookDeliverySucceeded || len(delivery.Attempts) != 2 {
+		t.Errorf("delivery after a 200 = %v", delivery)
+	}
+}
+
+func TestWebhookDeliveryFailsAfterMaxAttempts(t *testing.T) {
+	w, clock, receiver := newWebhookTest(t, domain.WebhookPolicy{MaxAttempts: 3, Backoff: 60}, http.StatusInternalServerError)
+
+	for failures := int64(1); failures < 3; failures++ {
+		if res := dispatch(t, w); res.Retried != 1 {
+			t.Fatalf("dispatch %d = %v; want a retry", failures, res)
+		}
+
+		delivery := onlyDelivery(t, w)
+		checkRetry(t, delivery, 60, failures, clock.Now().Unix())
+		clock.Set(time.Unix(delivery.NextAttemptAt, 0))
+	}
+
+	if res := dispatch(t, w); res.Failed != 1 {
+		t.Fatalf("last dispatch = %v; want failed", res)
+	}
+	delivery := onlyDelivery(t, w)
+	if delivery.Status != variable.WebhookDeliveryFailed || len(delivery.Attempts) != 3 {
+		t.Fatalf("delivery out of attempts = %v", delivery)
+	}
+
+	clock.Add(time.Hour)
+	if res := dispatch(t, w); res.Retried+res.Delivered+res.Failed != 0 || receiver.received() != 3 {
+		t.Errorf("dispatch of a failed delivery = %v", res)
+	}
+
+	// a replay starts over with the whole budget of attempts
+	replayed, err := w.Replay(context.Background(), &pb.WebhookReplayRequest{DeliveryId: delivery.DeliveryId})
+	if err != nil || replayed.Status != variable.WebhookDeliveryPending || replayed.NextAttemptAt != clock.Now().Unix() {
+		t.Fatalf("Replay = %v, %v; want pending now", replayed, err)
+	}
+	if res := dispatch(t, w); res.Retried != 1 {
+		t.Errorf("dispatch of a replayed delivery failing again = %v; want a retry", res)
+	}
+
+	receiver.answer(http.StatusOK)
+	clock.Set(time.Unix(onlyDelivery(t, w).NextAttemptAt, 0))
+	if res := dispatch(t, w); res.Delivered != 1 {
+		t.Errorf("dispatch of the replayed delivery = %v; want delivered", res)
+	}
+}
+
+func TestWebhookRedirectIsAFailure(t *testing.T) {
+	w, _, receiver := newWebhookTest(t, domain.WebhookPolicy{MaxAttempts: 3, Backoff: 60}, http.StatusFound)
+
+	if res := dispatch(t, w); res.Retried != 1 || res.Delivered != 0 {
+		t.Errorf("dispatch to a redirect = %v; want a retry", res)
+	}
+	if receiver.received() != 1 {
+		t.Errorf("receiver got %d requests; want the redirect not followed", receiver.received())
+	}
+	if delivery := onlyDelivery(t, w); delivery.Attempts[0].StatusCode != http.StatusFound || delivery.Attempts[0].Error == "" {
+		t.Errorf("attempt = %v; want a failed 302", delivery.Attempts[0])
+	}
+}
+
+func TestWebhookRetryJitter(t *testing.T) {
+	w := NewWebhookUsecase(repository.NewWebhookMemoryRepository(), domain.WebhookPolicy{Backoff: 30}, helper.NewFakeClock(time.Unix(0, 0))).(*WebhookUsecase)
+
+	for failures := int64(1); failures <= 14; failures++ {
+		shift := failures - 1
+		if shift > 10 {
+			shift = 10
+		}
+		delay := int64(30) << shift
+
+		for i := 0; i < 100; i++ {
+			if wait := w.retryAt(failures, 1000) - 1000; wait < delay-delay/2 || wait > delay {
+				t.Fatalf("retry %d after %ds; want between %d and %d", failures, wait, delay-delay/2, delay)
+			}
+		}
+	}
+}
@@ -0,0 +1,426 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"order/pb"
+	"order/variable"
+	"os"
+	"strconv"
+)
+
+type cli struct {
+	client pb.OrderServiceClient
+	out    *printer
+}
+
+func (c *cli) run(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "get":
+		return c.get(ctx, args)
+	case "list":
+		return c.list(ctx, args)
+	case "cancel":
+		return c.cancel(ctx, args)
+	case "status":
+		return c.status(ctx, args)
+	case "expire":
+		return c.expire(ctx, args)
+	case "renew":
+		return c.renew(ctx, args)
+	case "auto-renew":
+		return c.autoRenew(ctx, args)
+	case "report":
+		return c.report(ctx, args)
+	case "export":
+		return c.export(ctx, args)
+	case "refund":
+		return c.refund(ctx, args)
+	case "tax-report":
+		return c.taxReport(ctx, args)
+	case "reconcile":
+		return c.reconcile(ctx, args)
+	case "balances":
+		return c.balances(ctx, args)
+	case "check-ledger":
+		return c.checkLedger(ctx, args)
+	}
+
+	return fmt.Errorf("unknown command %q", command)
+}
+
+func parseArgs(name string, flags *flag.FlagSet, args []string, want int) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if flags.NArg() != want {
+		return nil, fmt.Errorf("%s: expected %d argument(s), got %d", name, want, flags.NArg())
+	}
+
+	return flags.Args(), nil
+}
+
+func (c *cli) get(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("get", flag.ContinueOnError)
+	args, err := parseArgs("get", flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: args[0]})
+	if err != nil {
+		return err
+	}
+
+	if res.IsEmpty {
+		return fmt.Errorf("order %s not found", args[0])
+	}
+
+	return c.out.order(res.Payload)
+}
+
+// filterFlags registers the FindAll filters shared by list and export.
+func filterFlags(flags *flag.FlagSet) *pb.OrderFindAllRequest {
+	req := &pb.OrderFindAllRequest{}
+	flags.StringVar(&req.Status, "status", "", "only orders with this status (cancel includes overdue orders)")
+	flags.StringVar(&req.UserId, "user", "", "only orders of this customer id")
+	flags.StringVar(&req.Search, "search", "", "case-insensitive search on id, status, buyer and product")
+	flags.StringVar(&req.ProductId, "product", "", "only orders with a line of this product id")
+	flags.StringVar(&req.Sort, "sort", "", "desc to list the newest orders first")
+
+	return req
+}
+
+func (c *cli) list(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	req := filterFlags(flags)
+	flags.Int64Var(&req.Page, "page", 0, "zero based page number")
+	flags.Int64Var(&req.PerPage, "per-page", 20, "orders per page, 0 for all")
+	if _, err := parseArgs("list", flags, args, 0); err != nil {
+		return err
+	}
+
+	res, err := c.client.FindAll(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.out.orders(res)
+}
+
+func (c *cli) cancel(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("cancel", flag.ContinueOnError)
+	user := flags.String("user", "", "customer id owning the order (looked up when empty)")
+	version := flags.Int64("version", 0, "only cancel when the order is at this version")
+	args, err := parseArgs("cancel", flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.OrderCancelRequest{OrderId: args[0], UserId: *user, ExpectedVersion: *version}
+	if req.UserId == "" {
+		found, err := c.client.FindOne(ctx, &pb.OrderFindOneRequest{OrderId: req.OrderId})
+		if err != nil {
+			return err
+		}
+
+		if found.IsEmpty {
+			return fmt.Errorf("order %s not found", req.OrderId)
+		}
+
+		req.UserId = found.Payload.GetBuyer().GetCustomerId()
+	}
+
+	res, err := c.client.Cancel(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.out.affected(res.IsAffected)
+}
+
+func (c *cli) status(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	reason := flags.String("reason", "", "why the status is changed (required)")
+	settlementTime := flags.Int64("settlement-time", 0, "unix settlement time when moving to settlement")
+	version := flags.Int64("version", 0, "only change the status when the order is at this version")
+	args, err := parseArgs("status", flags, args, 2)
+	if err != nil {
+		return err
+	}
+
+	if *reason == "" {
+		return fmt.Errorf("status: -reason is required")
+	}
+
+	res, err := c.client.ChangeStatus(ctx, &pb.OrderChangeStatus{
+		OrderId:         args[0],
+		Status:          args[1],
+		SettlementTime:  *settlementTime,
+		Reason:          *reason,
+		ExpectedVersion: *version,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.out.affected(res.IsAffected)
+}
+
+func (c *cli) refund(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("refund", flag.ContinueOnError)
+	req := &pb.OrderRefundRequest{}
+	flags.StringVar(&req.Reason, "reason", "", "why the order is refunded (required)")
+	flags.StringVar(&req.Actor, "actor", os.Getenv("USER"), "who issues the refund")
+	flags.StringVar(&req.Reference, "reference", "", "refund reference at the payment provider")
+	flags.Int64Var(&req.ExpectedVersion, "version", 0, "only refund when the order is at this version")
+	args, err := parseArgs("refund", flags, args, 2)
+	if err != nil {
+		return err
+	}
+
+	if req.Reason == "" {
+		return fmt.Errorf("refund: -reason is required")
+	}
+
+	req.OrderId = args[0]
+	req.Amount, err = strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("refund: amount: %w", err)
+	}
+
+	res, err := c.client.Refund(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.out.order(res.Payload)
+}
+
+func (c *cli) expire(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("expire", flag.ContinueOnError)
+	if _, err := parseArgs("expire", flags, args, 0); err != nil {
+		return err
+	}
+
+	res, err := c.client.Expire(ctx, &pb.OrderExpireRequest{})
+	if err != nil {
+		return err
+	}
+
+	return c.out.count("expired", res.Affected)
+}
+
+func (c *cli) renew(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("renew", flag.ContinueOnError)
+	if _, err := parseArgs("renew", flags, args, 0); err != nil {
+		return err
+	}
+
+	res, err := c.client.Renew(ctx, &pb.OrderRenewRequest{})
+	if err != nil {
+		return err
+	}
+
+	return c.out.renewals(res)
+}
+
+func (c *cli) autoRenew(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("auto-renew", flag.ContinueOnError)
+	args, err := parseArgs("auto-renew", flags, args, 3)
+	if err != nil {
+		return err
+	}
+
+	req := &pb.EntitlementAutoRenewRequest{CustomerId: args[0], ProductId: args[1]}
+	switch args[2] {
+	case "on":
+		req.AutoRenew = true
+	case "off":
+	default:
+		return fmt.Errorf("auto-renew: want on or off, got %q", args[2])
+	}
+
+	res, err := c.client.SetAutoRenew(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.out.affected(res.IsAffected)
+}
+
+// reportStatuses are the statuses summarised by the report command.
+var reportStatuses = []string{
+	variable.PaymentStatusPending,
+	variable.PaymentStatusPartiallyPaid,
+	variable.PaymentStatusSettlement,
+	variable.PaymentStatusPartiallyRefunded,
+	variable.PaymentStatusRefunded,
+	variable.PayementStatusCancel,
+	variable.PayementStatusExpire,
+}
+
+func (c *cli) report(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	user := flags.String("user", "", "only orders of this customer id")
+	from := flags.Int64("from", 0, "unix time the income period starts at")
+	to := flags.Int64("to", 0, "unix time the income period ends before")
+	if _, err := parseArgs("report", flags, args, 0); err != nil {
+		return err
+	}
+
+	var rows []reportRow
+	for _, status := range reportStatuses {
+		count, err := c.client.FindAll(ctx, &pb.OrderFindAllRequest{Status: status, UserId: *user, CountOnly: true})
+		if err != nil {
+			return err
+		}
+
+		sum, err := c.client.SumIncome(ctx, &pb.OrderSumIncomeRequest{Status: status, UserId: *user, From: *from, To: *to})
+		if err != nil {
+			return err
+		}
+
+		rows = append(rows, reportRow{
+			Status: status,
+			Orders: count.GetPayload().GetRows(),
+			Income: income(sum.GetPayload().GetTotals()),
+		})
+	}
+
+	return c.out.report(rows)
+}
+
+func (c *cli) taxReport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("tax-report", flag.ContinueOnError)
+	req := &pb.OrderTaxReportRequest{}
+	flags.Int64Var(&req.From, "from", 0, "unix time of the first settlement included")
+	flags.Int64Var(&req.To, "to", 0, "unix time the report stops before, 0 for now")
+	flags.StringVar(&req.Period, "period", "month", "day, month or year")
+	if _, err := parseArgs("tax-report", flags, args, 0); err != nil {
+		return err
+	}
+
+	res, err := c.client.TaxReport(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.out.taxReport(res)
+}
+
+func (c *cli) reconcile(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	req := &pb.ReconcileRequest{}
+	flags.Int64Var(&req.From, "from", 0, "unix time the reconciled period starts at (default the first row)")
+	flags.Int64Var(&req.To, "to", 0, "unix time the reconciled period ends before (default after the last row)")
+	flags.BoolVar(&req.AutoSettle, "auto-settle", false, "settle the unpaid orders the report settled")
+	all := flags.Bool("all", false, "list matched orders too")
+	args, err := parseArgs("reconcile", flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	req.Content, err = os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Reconcile(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.out.reconciliation(res, *all)
+}
+
+func (c *cli) balances(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("balances", flag.ContinueOnError)
+	req := &pb.LedgerBalanceRequest{}
+	flags.StringVar(&req.Account, "account", "", "only this account and its sub-accounts, e.g. revenue")
+	flags.StringVar(&req.Currency, "currency", "", "only this currency")
+	flags.Int64Var(&req.From, "from", 0, "unix time of the first movement included")
+	flags.Int64Var(&req.To, "to", 0, "unix time the period ends before, 0 for now")
+	if _, err := parseArgs("balances", flags, args, 0); err != nil {
+		return err
+	}
+
+	res, err := c.client.GetLedgerBalances(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.out.balances(res)
+}
+
+func (c *cli) checkLedger(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("check-ledger", flag.ContinueOnError)
+	req := &pb.LedgerCheckRequest{}
+	flags.Int64Var(&req.From, "from", 0, "unix time of the first movement checked")
+	flags.Int64Var(&req.To, "to", 0, "unix time the period ends before, 0 for now")
+	if _, err := parseArgs("check-ledger", flags, args, 0); err != nil {
+		return err
+	}
+
+	res, err := c.client.CheckLedger(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := c.out.ledgerCheck(res); err != nil {
+		return err
+	}
+	if !res.Balanced {
+		return fmt.Errorf("check-ledger: the ledger does not balance")
+	}
+
+	return nil
+}
+
+func (c *cli) export(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	req := filterFlags(flags)
+	format := flags.String("format", "csv", "csv or json (one order per line)")
+	file := flags.String("out", "", "write to this file instead of stdout")
+	if _, err := parseArgs("export", flags, args, 0); err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	exporter, err := newExporter(w, *format)
+	if err != nil {
+		return err
+	}
+
+	req.PerPage = 100
+	for req.Page = 0; ; req.Page++ {
+		res, err := c.client.FindAll(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		for _, order := range res.GetPayload().GetOrders() {
+			if err := exporter.write(order); err != nil {
+				return err
+			}
+		}
+
+		if int64(len(res.GetPayload().GetOrders())) < req.PerPage {
+			break
+		}
+	}
+
+	return exporter.flush()
+}
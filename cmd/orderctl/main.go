@@ -0,0 +1,146 @@
+// Command orderctl is the operator tool for inspecting and mutating orders.
+//
+// By default it talks to a running OrderService over gRPC. With -direct it
+// serves the same handler in-process on top of the database, which is meant
+// for break-glass use when the service itself is unavailable, and relays the
+// outbox before exiting so that the events of the command are published.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"order/app/usecase"
+	"order/config"
+	"order/helper"
+	"order/injector"
+	"order/pb"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const usage = `usage: orderctl [flags] <command> [command flags] [args]
+
+commands:
+  get <order_id>                     show a single order
+  list                               list and filter orders
+  cancel [-user id] <order_id>       cancel a pending order
+  status -reason r <order_id> <st>   change the status of an order
+  refund -reason r <order_id> <amt>  refund part or all of a settled order
+  expire                             expire pending orders past pay_exp
+  renew                              create renewal orders and run dunning
+  auto-renew <customer> <product> <on|off>
+                                     opt a customer in or out of renewals
+  report                             income and order counts per status
+  export [-format csv|json]          write every matching order
+  tax-report [-period month]         tax collected on settled orders
+  reconcile [-auto-settle] <file>    compare a gateway settlement report
+                                     with the orders it paid
+  balances [-account a]              ledger balances per account
+  check-ledger                       prove every ledger journal balances
+
+flags:
+`
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("orderctl: ")
+
+	flags := flag.NewFlagSet("orderctl", flag.ExitOnError)
+	envFile := flags.String("env", ".env", "env file with the shared configuration")
+	addr := flags.String("addr", "", "address of the OrderService (default localhost$PORT)")
+	direct := flags.Bool("direct", false, "bypass the service and operate on the database directly, publishing the order events before exiting")
+	output := flags.String("o", "table", "output format: table or json")
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout of the whole command")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(*envFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	var conn *grpc.ClientConn
+	var relay *usecase.OutboxRelay
+	if *direct {
+		conn, relay, err = dialDirect(ctx, cfg)
+	} else {
+		target := *addr
+		if target == "" {
+			target = "localhost" + cfg.Port
+		}
+		conn, err = grpc.DialContext(ctx, target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	cli := &cli{
+		client: pb.NewOrderServiceClient(conn),
+		out:    newPrinter(os.Stdout, *output),
+	}
+
+	if err := cli.run(ctx, flags.Arg(0), flags.Args()[1:]); err != nil {
+		log.Fatal(err)
+	}
+
+	if relay != nil {
+		if err := flushOutbox(ctx, relay); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// dialDirect serves the OrderService handler on an in-memory listener backed
+// by the configured store, so every command runs the exact server code. It
+// also returns the relay of the outbox the handler writes to.
+func dialDirect(ctx context.Context, cfg *config.Config) (*grpc.ClientConn, *usecase.OutboxRelay, error) {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	handler, relay := injector.NewOrderInjectorFromConfig(cfg, helper.NewClock())
+	pb.RegisterOrderServiceServer(server, handler)
+	go server.Serve(lis)
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+
+	return conn, relay, err
+}
+
+// flushOutbox publishes the events written by a direct command, as the
+// service is not there to. Events failing to publish are left in the outbox
+// for the relay of the service to retry.
+func flushOutbox(ctx context.Context, relay *usecase.OutboxRelay) error {
+	defer relay.Close()
+
+	for {
+		published, _, dead, err := relay.Relay(ctx)
+		if err != nil || published+dead == 0 {
+			return err
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"order/domain"
+	"order/pb"
+	"order/variable"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var jsonOptions = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+func newPrinter(w io.Writer, format string) *printer {
+	return &printer{w: w, json: format == "json"}
+}
+
+func (p *printer) message(m proto.Message) error {
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true, Multiline: true}.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(p.w, string(data))
+
+	return err
+}
+
+func (p *printer) table(header string, rows func(w io.Writer)) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, header)
+	rows(tw)
+
+	return tw.Flush()
+}
+
+func formatTime(unix int64) string {
+	if unix == 0 {
+		return "-"
+	}
+
+	return time.Unix(unix, 0).UTC().Format(variable.TimeLayout)
+}
+
+// formatRate renders a rate in basis points as a percentage.
+func formatRate(bps int64) string {
+	return strconv.FormatFloat(float64(bps)/100, 'f', -1, 64) + "%"
+}
+
+func (p *printer) order(order *pb.Order) error {
+	if p.json {
+		return p.message(order)
+	}
+
+	return p.table("FIELD\tVALUE", func(w io.Writer) {
+		fmt.Fprintf(w, "order_id\t%s\n", order.OrderId)
+		fmt.Fprintf(w, "status\t%s\n", order.Status)
+		fmt.Fprintf(w, "version\t%d\n", order.Version)
+		fmt.Fprintf(w, "currency\t%s\n", order.Currency)
+		if order.StatusReason != "" {
+			fmt.Fprintf(w, "status_reason\t%s\n", order.StatusReason)
+		}
+		fmt.Fprintf(w, "customer\t%s (%s)\n", order.GetBuyer().GetName(), order.GetBuyer().GetCustomerId())
+		for _, item := range order.Items {
+			fmt.Fprintf(w, "item\t%d x %s (%s) @ %d = %d\n", item.Quantity, item.GetProduct().GetName(), item.GetProduct().GetProductId(), item.UnitPrice, item.LineTotal)
+		}
+		fmt.Fprintf(w, "subtotal\t%d\n", order.Subtotal)
+		for _, discount := range order.Discounts {
+			fmt.Fprintf(w, "discount\t%s -%d\n", discount.Code, discount.Amount)
+		}
+		for _, tax := range order.Taxes {
+			fmt.Fprintf(w, "tax\t%s %s on %d = %d\n", tax.Name, formatRate(tax.Rate), tax.Taxable, tax.Amount)
+		}
+		if order.TaxInclusive {
+			fmt.Fprintf(w, "tax_total\t%d (included)\n", order.TaxTotal)
+		} else {
+			fmt.Fprintf(w, "tax_total\t%d\n", order.TaxTotal)
+		}
+		fmt.Fprintf(w, "total\t%d\n", order.Total)
+		for _, refund := range order.Refunds {
+			fmt.Fprintf(w, "refund\t%d by %s at %s: %s %s\n", refund.Amount, refund.Actor, formatTime(refund.CreatedAt), refund.Reason, refund.Reference)
+		}
+		if order.RefundedTotal > 0 {
+			fmt.Fprintf(w, "refunded_total\t%d\n", order.RefundedTotal)
+		}
+		fmt.Fprintf(w, "payment\t%s %s %s\n", order.GetPayment().GetPaymentType(), order.GetPayment().GetBank(), order.GetPayment().GetVaNumber())
+		fmt.Fprintf(w, "gross_amount\t%d\n", order.GetPayment().GetGrossAmount())
+		fmt.Fprintf(w, "created_at\t%s\n", formatTime(order.CreatedAt))
+		fmt.Fprintf(w, "updated_at\t%s\n", formatTime(order.UpdatedAt))
+		fmt.Fprintf(w, "pay_exp\t%s\n", formatTime(order.PayExp))
+		fmt.Fprintf(w, "settlement_time\t%s\n", formatTime(order.SettlementTime))
+	})
+}
+
+func (p *printer) orders(res *pb.OrderFindAllResponse) error {
+	if p.json {
+		return p.message(res)
+	}
+
+	err := p.table("ORDER_ID\tSTATUS\tCUSTOMER\tPRODUCT\tAMOUNT\tCREATED_AT\tPAY_EXP", func(w io.Writer) {
+		for _, order := range res.GetPayload().GetOrders() {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+				order.OrderId,
+				order.Status,
+				order.GetBuyer().GetCustomerId(),
+				order.GetProduct().GetName(),
+				order.GetPayment().GetGrossAmount(),
+				formatTime(order.CreatedAt),
+				formatTime(order.PayExp),
+			)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	payload := res.GetPayload()
+	_, err = fmt.Fprintf(p.w, "\npage %d of %d, %d of %d order(s)\n", payload.GetActivePage(), payload.GetPages(), payload.GetTotal(), payload.GetRows())
+
+	return err
+}
+
+func (p *printer) affected(affected bool) error {
+	return p.message(&pb.OperationResponse{IsAffected: affected})
+}
+
+func (p *printer) count(label string, n int64) error {
+	if p.json {
+		return json.NewEncoder(p.w).Encode(map[string]int64{label: n})
+	}
+
+	_, err := fmt.Fprintf(p.w, "%s: %d\n", label, n)
+
+	return err
+}
+
+type reportRow struct {
+	Status string         `json:"status"`
+	Orders int64          `json:"orders"`
+	Income []domain.Money `json:"income"`
+}
+
+func income(totals []*pb.Money) (list []domain.Money) {
+	for _, total := range totals {
+		list = append(list, domain.NewMoney(total.Currency, total.Amount))
+	}
+
+	return
+}
+
+func (p *printer) report(rows []reportRow) error {
+	if p.json {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	}
+
+	return p.table("STATUS\tORDERS\tINCOME", func(w io.Writer) {
+		for _, row := range rows {
+			var amounts []string
+			for _, amount := range row.Income {
+				amounts = append(amounts, amount.String())
+			}
+			if len(amounts) == 0 {
+				amounts = append(amounts, "-")
+			}
+
+			fmt.Fprintf(w, "%s\t%d\t%s\n", row.Status, row.Orders, strings.Join(amounts, ", "))
+		}
+	})
+}
+
+func (p *printer) taxReport(res *pb.OrderTaxReportResponse) error {
+	if p.json {
+		return p.message(res)
+	}
+
+	return p.table("PERIOD\tCURRENCY\tTAX\tRATE\tKIND\tORDERS\tTAXABLE\tAMOUNT", func(w io.Writer) {
+		for _, row := range res.Rows {
+			kind := "collected"
+			if row.Refunds {
+				kind = "refunded"
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\n", row.Period, row.Currency, row.Name, formatRate(row.Rate), kind, row.Orders, row.Taxable, row.Amount)
+		}
+	})
+}
+
+func (p *printer) renewals(res *pb.OrderRenewResponse) error {
+	if p.json {
+		return p.message(res)
+	}
+
+	err := p.table("CUSTOMER\tPRODUCT\tORDER\tSTAGE\tPAY_EXP", func(w io.Writer) {
+		for _, event := range res.Events {
+			stage := event.Stage
+			if event.Reminder > 0 {
+				stage = fmt.Sprintf("%s #%d", stage, event.Reminder)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", event.CustomerId, event.ProductId, event.OrderId, stage, formatTime(event.PayExp))
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(p.w, "\n%d renewal order(s) created, %d failure(s)\n", res.Created, res.Failed)
+
+	return err
+}
+
+// formatAmount renders amount in the major units of currency, or "-" for
+// nothing.
+func formatAmount(currency string, amount int64) string {
+	if currency == "" || amount == 0 {
+		return "-"
+	}
+
+	return domain.NewMoney(currency, amount).String()
+}
+
+func (p *printer) reconciliation(res *pb.ReconciliationReport, all bool) error {
+	if p.json {
+		return p.message(res)
+	}
+
+	err := p.table("OUTCOME\tLINES\tORDER_ID\tREFERENCE\tGATEWAY\tORDER\tSTATUS\tACTION\tDETAIL", func(w io.Writer) {
+		for _, item := range res.Items {
+			if item.Outcome == variable.ReconcileMatched && !all {
+				continue
+			}
+
+			var lines []string
+			for _, line := range item.Lines {
+				lines = append(lines, strconv.FormatInt(line, 10))
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s/%s\t%s\t%s\n",
+				item.Outcome,
+				orDash(strings.Join(lines, ",")),
+				orDash(item.OrderId),
+				orDash(item.Reference),
+				formatAmount(item.Currency, item.GatewayAmount),
+				formatAmount(item.Currency, item.OrderAmount),
+				orDash(item.GatewayStatus),
+				orDash(item.OrderStatus),
+				orDash(item.Action),
+				item.Detail,
+			)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(p.w, "\n%d row(s) from %s to %s: %d matched, %d amount mismatch(es), %d status mismatch(es), %d missing in ours, %d missing in the gateway, %d settled\n",
+		res.Rows, formatTime(res.From), formatTime(res.To), res.Matched, res.AmountMismatches, res.StatusMismatches, res.MissingInOurs, res.MissingInGateway, res.Settled)
+
+	return err
+}
+
+func (p *printer) balances(res *pb.LedgerBalanceResponse) error {
+	if p.json {
+		return p.message(res)
+	}
+
+	return p.table("ACCOUNT\tCURRENCY\tDEBIT\tCREDIT\tBALANCE", func(w io.Writer) {
+		for _, balance := range res.Balances {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", balance.Account, balance.Currency, balance.Debit, balance.Credit, balance.Balance)
+		}
+	})
+}
+
+func (p *printer) ledgerCheck(res *pb.LedgerCheckResponse) error {
+	if p.json {
+		return p.message(res)
+	}
+
+	err := p.table("CURRENCY\tDEBIT\tCREDIT\tBALANCE", func(w io.Writer) {
+		for _, total := range res.Totals {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", total.Currency, total.Debit, total.Credit, total.Balance)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, problem := range res.Problems {
+		fmt.Fprintf(p.w, "%s: %s\n", problem.JournalId, problem.Detail)
+	}
+
+	verdict := "balanced"
+	if !res.Balanced {
+		verdict = fmt.Sprintf("NOT balanced, %d problem(s)", len(res.Problems))
+	}
+	_, err = fmt.Fprintf(p.w, "\n%d journal(s), %d line(s): %s\n", res.Journals, res.Lines, verdict)
+
+	return err
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
+// exporter streams orders as CSV rows or JSON lines.
+type exporter struct {
+	csv  *csv.Writer
+	json io.Writer
+}
+
+var exportHeader = []string{
+	"order_id", "status", "customer_id", "buyer_name", "product_id", "product_name",
+	"price", "items", "currency", "total", "payment_type", "bank", "va_number", "gross_amount",
+	"created_at", "pay_exp", "settlement_time",
+}
+
+func newExporter(w io.Writer, format string) (*exporter, error) {
+	switch format {
+	case "json":
+		return &exporter{json: w}, nil
+	case "csv":
+		e := &exporter{csv: csv.NewWriter(w)}
+		return e, e.csv.Write(exportHeader)
+	}
+
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+func (e *exporter) write(order *pb.Order) error {
+	if e.json != nil {
+		data, err := jsonOptions.Marshal(order)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(e.json, string(data))
+
+		return err
+	}
+
+	itoa := func(n int64) string { return strconv.FormatInt(n, 10) }
+
+	return e.csv.Write([]string{
+		order.OrderId,
+		order.Status,
+		order.GetBuyer().GetCustomerId(),
+		order.GetBuyer().GetName(),
+		order.GetProduct().GetProductId(),
+		order.GetProduct().GetName(),
+		itoa(order.GetProduct().GetPrice()),
+		itoa(int64(len(order.Items))),
+		order.Currency,
+		itoa(order.Total),
+		order.GetPayment().GetPaymentType(),
+		order.GetPayment().GetBank(),
+		order.GetPayment().GetVaNumber(),
+		itoa(order.GetPayment().GetGrossAmount()),
+		itoa(order.CreatedAt),
+		itoa(order.PayExp),
+		itoa(order.SettlementTime),
+	})
+}
+
+func (e *exporter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+
+	return nil
+}
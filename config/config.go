@@ -0,0 +1,276 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"order/variable"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Repository implementations selectable with the STORE setting.
+const (
+	StoreMongo  = "mongo"
+	StoreMemory = "memory"
+)
+
+// Config holds the settings shared by the server and the command-line tools.
+type Config struct {
+	// Port is the address the gRPC server listens on, e.g. ":5011".
+	Port string
+	// HTTPPort is the address of the HTTP gateway; empty disables it.
+	HTTPPort string
+	// MongoURI is the connection string of the MongoDB server.
+	MongoURI string
+	// Database is the name of the MongoDB database holding the orders.
+	Database string
+	// Store selects the repository implementation: "mongo" or "memory".
+	Store string
+	// TaxRules is the path of the JSON tax policy, see LoadTaxPolicy and
+	// tax_rules.example.json; empty disables taxes.
+	TaxRules string
+	// VirtualAccounts is the path of the JSON virtual account schemes, see
+	// LoadVirtualAccountPolicy and virtual_accounts.example.json; empty
+	// leaves virtual account numbers to callers.
+	VirtualAccounts string
+	// QRISNMID and QRISMerchantPAN identify the merchant in QRIS payloads,
+	// the PAN along with the domain of its acquirer, QRISAcquirerDomain,
+	// and the merchant ID there, QRISMerchantId. Setting neither disables
+	// the qris payment type.
+	QRISNMID           string
+	QRISMerchantPAN    string
+	QRISAcquirerDomain string
+	QRISMerchantId     string
+	// QRISCriteria is the business size criteria of the merchant, e.g.
+	// "UMI".
+	QRISCriteria string
+	// QRISMCC is the merchant category code, "5999" by default.
+	QRISMCC string
+	// QRISMerchantName, QRISMerchantCity and QRISPostalCode are shown to
+	// payers. The name defaults to SellerName.
+	QRISMerchantName string
+	QRISMerchantCity string
+	QRISPostalCode   string
+	// Currency is the ISO 4217 code of orders created without one, also
+	// stamped on orders stored before orders had a currency.
+	Currency string
+	// Overpayment is what the surplus of overpaid orders becomes, "credit"
+	// or "refund_due".
+	Overpayment string
+	// RenewalLeadDays is how many days before an entitlement ends its
+	// renewal order is created; "0" disables renewals.
+	RenewalLeadDays string
+	// DunningSchedule lists the days past the end of the renewed access of
+	// the reminders, final notice and lapse of unpaid renewals, see
+	// ParseRenewalPolicy.
+	DunningSchedule string
+	// RenewInterval is how often the server runs renewals; zero leaves them
+	// to an external scheduler calling Renew.
+	RenewInterval time.Duration
+	// QuoteSecret is the key signing quote tokens, shared by every instance
+	// of the service; empty uses a random key per process.
+	QuoteSecret string
+	// QuoteTTL is how long a quote can be ordered.
+	QuoteTTL time.Duration
+	// CatalogAddr is the address of the product catalog service pricing
+	// new orders.
+	CatalogAddr string
+	// CatalogFile is the path of a JSON product catalog used instead of
+	// the catalog service, see catalog.example.json. Without either, the
+	// prices sent by clients are trusted.
+	CatalogFile string
+	// BrokerURL is where order events are published besides the merchant
+	// webhooks, see broker.Open; empty publishes them to the webhooks only.
+	BrokerURL string
+	// RelayInterval is how often the outbox is published, always positive.
+	RelayInterval time.Duration
+	// OutboxMaxAttempts is how many times an event is published before it
+	// is dead-lettered.
+	OutboxMaxAttempts int64
+	// OutboxBackoff is the delay before an event is published again,
+	// doubling with each attempt.
+	OutboxBackoff time.Duration
+	// WebhookInterval is how often the server sends the webhooks due; zero
+	// leaves them to an external scheduler calling DispatchWebhooks.
+	WebhookInterval time.Duration
+	// WebhookMaxAttempts is how many times a webhook is sent before its
+	// delivery fails.
+	WebhookMaxAttempts int64
+	// WebhookBackoff is the delay before a webhook is sent again, doubling
+	// with each attempt and jittered.
+	WebhookBackoff time.Duration
+	// WebhookTimeout bounds each webhook request.
+	WebhookTimeout time.Duration
+	// NotificationEmailURL is the channel buyers are emailed through, see
+	// notifier.Open, e.g. "stdout" in development; empty sends no email.
+	NotificationEmailURL string
+	// NotificationSMSURL is the channel buyers are texted through; empty
+	// sends no SMS.
+	NotificationSMSURL string
+	// NotificationTemplates is the directory of the notification templates,
+	// one directory per locale; empty uses the built-in English and
+	// Indonesian ones.
+	NotificationTemplates string
+	// NotificationLocale is the locale of buyers without one, or whose
+	// locale has no templates.
+	NotificationLocale string
+	// NotificationTimezone is the IANA time zone notifications show times
+	// in.
+	NotificationTimezone string
+	// SellerName, SellerAddress, SellerTaxId and SellerEmail are who
+	// invoices are issued by. The address may span lines separated by "\n".
+	SellerName    string
+	SellerAddress string
+	SellerTaxId   string
+	SellerEmail   string
+	// InvoicePrefix starts every invoice number, e.g. "INV-2024-000001".
+	InvoicePrefix string
+	// InvoiceTimezone is the IANA time zone invoices are dated in, which
+	// also decides the year invoices are numbered in.
+	InvoiceTimezone string
+	// SettlementTimezone is the IANA time zone of the times without a zone
+	// in settlement reports.
+	SettlementTimezone string
+}
+
+// Load reads the given env files (".env" by default) into the environment
+// and returns the resulting configuration. Missing files are ignored so that
+// the process environment alone is enough.
+func Load(filenames ...string) (*Config, error) {
+	if len(filenames) == 0 {
+		filenames = []string{".env"}
+	}
+
+	for _, filename := range filenames {
+		err := godotenv.Load(filename)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	renewInterval, err := time.ParseDuration(getEnv("RENEW_INTERVAL", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("RENEW_INTERVAL: %w", err)
+	}
+
+	quoteTTL, err := time.ParseDuration(getEnv("QUOTE_TTL", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("QUOTE_TTL: %w", err)
+	}
+
+	relayInterval, err := time.ParseDuration(getEnv("OUTBOX_RELAY_INTERVAL", "1s"))
+	if err != nil {
+		return nil, fmt.Errorf("OUTBOX_RELAY_INTERVAL: %w", err)
+	}
+	// the outbox is always relayed, the events are not published otherwise
+	if relayInterval <= 0 {
+		return nil, fmt.Errorf("OUTBOX_RELAY_INTERVAL: must be positive, not %s", relayInterval)
+	}
+
+	maxAttempts, err := strconv.ParseInt(getEnv("OUTBOX_MAX_ATTEMPTS", "10"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("OUTBOX_MAX_ATTEMPTS: %w", err)
+	}
+
+	backoff, err := time.ParseDuration(getEnv("OUTBOX_BACKOFF", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("OUTBOX_BACKOFF: %w", err)
+	}
+
+	webhookInterval, err := time.ParseDuration(getEnv("WEBHOOK_INTERVAL", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("WEBHOOK_INTERVAL: %w", err)
+	}
+
+	webhookAttempts, err := strconv.ParseInt(getEnv("WEBHOOK_MAX_ATTEMPTS", "8"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("WEBHOOK_MAX_ATTEMPTS: %w", err)
+	}
+
+	webhookBackoff, err := time.ParseDuration(getEnv("WEBHOOK_BACKOFF", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("WEBHOOK_BACKOFF: %w", err)
+	}
+
+	webhookTimeout, err := time.ParseDuration(getEnv("WEBHOOK_TIMEOUT", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("WEBHOOK_TIMEOUT: %w", err)
+	}
+
+	overpayment := getEnv("OVERPAYMENT", variable.OverpaymentCredit)
+	if overpayment != variable.OverpaymentCredit && overpayment != variable.OverpaymentRefundDue {
+		return nil, fmt.Errorf("OVERPAYMENT: must be %q or %q, not %q", variable.OverpaymentCredit, variable.OverpaymentRefundDue, overpayment)
+	}
+
+	return &Config{
+		Port:     getEnv("PORT", ":5011"),
+		HTTPPort: os.Getenv("HTTP_PORT"),
+		MongoURI: getEnv("MONGO_URI", "mongodb://db:27017"),
+		Database: getEnv("MONGO_DATABASE", "db_order"),
+		Store:    getEnv("STORE", StoreMongo),
+		TaxRules: os.Getenv("TAX_RULES"),
+		Currency: getEnv("DEFAULT_CURRENCY", "IDR"),
+
+		Overpayment: overpayment,
+
+		VirtualAccounts: os.Getenv("VIRTUAL_ACCOUNTS"),
+
+		QRISNMID:           os.Getenv("QRIS_NMID"),
+		QRISMerchantPAN:    os.Getenv("QRIS_MERCHANT_PAN"),
+		QRISAcquirerDomain: os.Getenv("QRIS_ACQUIRER_DOMAIN"),
+		QRISMerchantId:     os.Getenv("QRIS_MERCHANT_ID"),
+		QRISCriteria:       os.Getenv("QRIS_CRITERIA"),
+		QRISMCC:            getEnv("QRIS_MCC", "5999"),
+		QRISMerchantName:   getEnv("QRIS_MERCHANT_NAME", os.Getenv("SELLER_NAME")),
+		QRISMerchantCity:   getEnv("QRIS_MERCHANT_CITY", "JAKARTA"),
+		QRISPostalCode:     os.Getenv("QRIS_POSTAL_CODE"),
+
+		RenewalLeadDays: getEnv("RENEWAL_LEAD_DAYS", "7"),
+		DunningSchedule: getEnv("DUNNING_SCHEDULE", "1,3,5,7"),
+		RenewInterval:   renewInterval,
+
+		QuoteSecret: os.Getenv("QUOTE_SECRET"),
+		QuoteTTL:    quoteTTL,
+
+		CatalogAddr: os.Getenv("CATALOG_ADDR"),
+		CatalogFile: os.Getenv("CATALOG_FILE"),
+
+		BrokerURL:         os.Getenv("BROKER_URL"),
+		RelayInterval:     relayInterval,
+		OutboxMaxAttempts: maxAttempts,
+		OutboxBackoff:     backoff,
+
+		WebhookInterval:    webhookInterval,
+		WebhookMaxAttempts: webhookAttempts,
+		WebhookBackoff:     webhookBackoff,
+		WebhookTimeout:     webhookTimeout,
+
+		NotificationEmailURL:  os.Getenv("NOTIFICATION_EMAIL_URL"),
+		NotificationSMSURL:    os.Getenv("NOTIFICATION_SMS_URL"),
+		NotificationTemplates: os.Getenv("NOTIFICATION_TEMPLATES"),
+		NotificationLocale:    getEnv("NOTIFICATION_LOCALE", "en"),
+		NotificationTimezone:  getEnv("NOTIFICATION_TIMEZONE", "Asia/Jakarta"),
+
+		SellerName:      os.Getenv("SELLER_NAME"),
+		SellerAddress:   strings.ReplaceAll(os.Getenv("SELLER_ADDRESS"), `\n`, "\n"),
+		SellerTaxId:     os.Getenv("SELLER_TAX_ID"),
+		SellerEmail:     os.Getenv("SELLER_EMAIL"),
+		InvoicePrefix:   getEnv("INVOICE_PREFIX", "INV"),
+		InvoiceTimezone: getEnv("INVOICE_TIMEZONE", "Asia/Jakarta"),
+
+		SettlementTimezone: getEnv("SETTLEMENT_TIMEZONE", "Asia/Jakarta"),
+	}, nil
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+
+	return fallback
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"order/domain"
+	"strconv"
+	"strings"
+)
+
+// ParseRenewalPolicy builds the renewal policy from the lead time in days and
+// the dunning schedule, a comma separated list of days past the end of the
+// renewed access: one for each reminder, then the final notice and the
+// lapse, e.g. "1,3,5,7". A lead time of 0 disables renewals and yields nil.
+func ParseRenewalPolicy(leadDays, schedule string) (*domain.RenewalPolicy, error) {
+	lead, err := strconv.ParseInt(leadDays, 10, 64)
+	if err != nil || lead < 0 {
+		return nil, fmt.Errorf("renewal lead days %q: want a non-negative number", leadDays)
+	}
+	if lead == 0 {
+		return nil, nil
+	}
+
+	var days []int64
+	for _, field := range strings.Split(schedule, ",") {
+		day, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+		if err != nil || day <= 0 || (len(days) > 0 && day <= days[len(days)-1]) {
+			return nil, fmt.Errorf("dunning schedule %q: want increasing positive days", schedule)
+		}
+		days = append(days, day)
+	}
+	if len(days) < 2 {
+		return nil, fmt.Errorf("dunning schedule %q: want at least the final notice and lapse days", schedule)
+	}
+
+	n := len(days)
+
+	return &domain.RenewalPolicy{
+		LeadDays:        lead,
+		ReminderDays:    days[:n-2],
+		FinalNoticeDays: days[n-2],
+		LapseDays:       days[n-1],
+	}, nil
+}
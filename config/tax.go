@@ -0,0 +1,43 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"order/domain"
+	"order/variable"
+	"os"
+)
+
+// LoadTaxPolicy reads the tax policy from the JSON file at path. An empty
+// path yields a policy without rules, under which orders are not taxed.
+func LoadTaxPolicy(path string) (*domain.TaxPolicy, error) {
+	policy := &domain.TaxPolicy{Rounding: variable.TaxRoundingHalfUp}
+	if path == "" {
+		return policy, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	switch policy.Rounding {
+	case "":
+		policy.Rounding = variable.TaxRoundingHalfUp
+	case variable.TaxRoundingHalfUp, variable.TaxRoundingDown, variable.TaxRoundingUp:
+	default:
+		return nil, fmt.Errorf("%s: unknown rounding %q", path, policy.Rounding)
+	}
+
+	for _, rule := range policy.Rules {
+		if rule.Name == "" || rule.Rate < 0 {
+			return nil, fmt.Errorf("%s: tax rules need a name and a non-negative rate", path)
+		}
+	}
+
+	return policy, nil
+}
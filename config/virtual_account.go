@@ -0,0 +1,76 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"order/domain"
+	"order/variable"
+	"os"
+)
+
+// LoadVirtualAccountPolicy reads the virtual account schemes from the JSON
+// file at path. An empty path yields nil: the service allocates no virtual
+// account numbers and callers pass their own.
+func LoadVirtualAccountPolicy(path string) (*domain.VirtualAccountPolicy, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &domain.VirtualAccountPolicy{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	banks := map[string]bool{}
+	for i := range policy.Schemes {
+		scheme := &policy.Schemes[i]
+		if scheme.Bank == "" || banks[scheme.Bank] {
+			return nil, fmt.Errorf("%s: virtual account schemes need a distinct bank", path)
+		}
+		banks[scheme.Bank] = true
+
+		for _, c := range scheme.Prefix {
+			if c < '0' || c > '9' {
+				return nil, fmt.Errorf("%s: prefix %q of %s is not numeric", path, scheme.Prefix, scheme.Bank)
+			}
+		}
+
+		check := 1
+		switch scheme.CheckDigit {
+		case "":
+			scheme.CheckDigit = variable.VACheckDigitNone
+			check = 0
+		case variable.VACheckDigitNone:
+			check = 0
+		case variable.VACheckDigitLuhn, variable.VACheckDigitMod11:
+		default:
+			return nil, fmt.Errorf("%s: unknown check digit %q of %s", path, scheme.CheckDigit, scheme.Bank)
+		}
+
+		// the serial takes the digits left by the prefix and check digit
+		width := scheme.Length - len(scheme.Prefix) - check
+		if width < 1 || scheme.Length > 19 {
+			return nil, fmt.Errorf("%s: length %d of %s leaves no room for a serial", path, scheme.Length, scheme.Bank)
+		}
+
+		largest := int64(1)
+		for i := 0; i < width; i++ {
+			largest *= 10
+		}
+		largest--
+
+		if scheme.To == 0 {
+			scheme.To = largest
+		}
+		if scheme.From < 0 || scheme.From > scheme.To || scheme.To > largest {
+			return nil, fmt.Errorf("%s: range %d to %d of %s must fit in %d digits", path, scheme.From, scheme.To, scheme.Bank, width)
+		}
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+// CatalogProduct is a product as sold by the product catalog, the source of
+// truth for the prices orders are charged.
+type CatalogProduct struct {
+	ProductId   string `json:"product_id"`
+	Name        string `json:"name"`
+	Price       int64  `json:"price"`
+	Duration    int64  `json:"duration"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+	// Currency is the currency of Price, empty for the default currency.
+	Currency string `json:"currency"`
+}
+
+type ProductCatalog interface {
+	// FindProduct returns the product, or nil when the catalog does not
+	// sell it.
+	FindProduct(ctx context.Context, productId string) (product *CatalogProduct, err error)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"order/pb"
+)
+
+// Coupon is a promotion code that discounts orders at creation.
+type Coupon struct {
+	Code        string `bson:"code"`
+	Description string `bson:"description"`
+	// Type is either variable.CouponTypePercentage, where Amount is a
+	// percentage, or variable.CouponTypeFixed, where Amount is deducted as is.
+	Type   string `bson:"type"`
+	Amount int64  `bson:"amount"`
+	// Currency of the fixed Amount and of MinOrderAmount; the coupon only
+	// applies to orders in this currency.
+	Currency string `bson:"currency"`
+	StartsAt int64  `bson:"starts_at"`
+	EndsAt   int64  `bson:"ends_at"`
+	// MaxUses and MaxUsesPerCustomer cap redemptions, zero means unlimited.
+	MaxUses            int64 `bson:"max_uses"`
+	MaxUsesPerCustomer int64 `bson:"max_uses_per_customer"`
+	MinOrderAmount     int64 `bson:"min_order_amount"`
+	// ProductIds restricts the discount to lines of these products, every
+	// line is eligible when empty.
+	ProductIds []string `bson:"product_ids"`
+	Used       int64    `bson:"used"`
+	CreatedAt  int64    `bson:"created_at"`
+}
+
+type CouponUsecase interface {
+	Create(ctx context.Context, req *pb.Coupon) error
+	FindOne(ctx context.Context, req *pb.CouponFindOneRequest) (res *pb.CouponFindOneResponse, err error)
+}
+
+type CouponRepository interface {
+	// Save stores a new coupon, returning ErrCouponExists when the code is
+	// taken.
+	Save(ctx context.Context, coupon *Coupon) error
+	// FindOne returns the coupon with the given code, or nil when there is
+	// none.
+	FindOne(ctx context.Context, code string) (coupon *Coupon, err error)
+	// Redeem atomically counts one use of the coupon by the customer,
+	// returning ErrCouponExhausted when either usage cap is reached.
+	Redeem(ctx context.Context, code string, customerId string) error
+	// Release gives back a use counted by Redeem.
+	Release(ctx context.Context, code string, customerId string) error
+}
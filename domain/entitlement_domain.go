@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"order/pb"
+)
+
+// EntitlementGrant is the access period bought by one settled order.
+type EntitlementGrant struct {
+	OrderId   string `bson:"order_id"`
+	Days      int64  `bson:"days"`
+	SettledAt int64  `bson:"settled_at"`
+	StartsAt  int64  `bson:"starts_at"`
+	EndsAt    int64  `bson:"ends_at"`
+}
+
+// Entitlement is the access of a customer to a product. Consecutive
+// purchases stack: a grant starts when the previous one ends, or at
+// settlement when the access had already lapsed.
+type Entitlement struct {
+	CustomerId  string `bson:"customer_id"`
+	ProductId   string `bson:"product_id"`
+	ProductName string `bson:"product_name"`
+	// StartsAt is the start of the current uninterrupted access period.
+	StartsAt  int64              `bson:"starts_at"`
+	EndsAt    int64              `bson:"ends_at"`
+	Grants    []EntitlementGrant `bson:"grants"`
+	UpdatedAt int64              `bson:"updated_at"`
+	// RenewalOptOut stops renewal orders from being created.
+	RenewalOptOut bool `bson:"renewal_opt_out"`
+	// Renewal is the latest renewal order, nil before the first one.
+	Renewal *EntitlementRenewal `bson:"renewal,omitempty"`
+}
+
+// EntitlementRenewal is the renewal order created for the access period
+// ending at PeriodEnd, and how far its dunning went.
+type EntitlementRenewal struct {
+	OrderId   string `bson:"order_id"`
+	PeriodEnd int64  `bson:"period_end"`
+	// PayExp is when the renewal is due, the end of the period it renews.
+	// Its order can be paid until the renewal lapses.
+	PayExp int64  `bson:"pay_exp"`
+	Stage  string `bson:"stage"`
+	// Reminders counts the reminders sent for the order.
+	Reminders int64 `bson:"reminders"`
+	UpdatedAt int64 `bson:"updated_at"`
+}
+
+// RenewalPolicy schedules renewal orders and the dunning of the unpaid ones.
+// The dunning offsets are days past the end of the access period a renewal
+// order renews, and the order stays payable until LapseDays.
+type RenewalPolicy struct {
+	// LeadDays is how long before the access ends the renewal is created.
+	LeadDays        int64
+	ReminderDays    []int64
+	FinalNoticeDays int64
+	LapseDays       int64
+}
+
+// Active reports whether the entitlement grants access at now.
+func (e *Entitlement) Active(now int64) bool {
+	return e.StartsAt <= now && now < e.EndsAt
+}
+
+type EntitlementUsecase interface {
+	Check(ctx context.Context, req *pb.EntitlementCheckRequest) (res *pb.EntitlementCheckResponse, err error)
+	List(ctx context.Context, req *pb.EntitlementListRequest) (res *pb.EntitlementListResponse, err error)
+	SetAutoRenew(ctx context.Context, req *pb.EntitlementAutoRenewRequest) (res *pb.OperationResponse, err error)
+}
+
+type EntitlementRepository interface {
+	// Grant adds the grant of grant.OrderId, computing its StartsAt and
+	// EndsAt. Granting an order twice is a no-op.
+	Grant(ctx context.Context, customerId string, product OrderProduct, grant EntitlementGrant, updatedTime int64) error
+	// Revoke removes the grant of orderId at updatedTime, shortening the
+	// entitlement by what was not used of the grant yet. Only the grants
+	// stacked on it start earlier; access paid after a lapse is kept.
+	Revoke(ctx context.Context, customerId string, productId string, orderId string, updatedTime int64) error
+	// Terminate ends the entitlement at at on behalf of orderId, recording
+	// an empty grant of orderId so that terminating twice is a no-op.
+	Terminate(ctx context.Context, customerId string, productId string, orderId string, at int64, updatedTime int64) error
+	// FindOne returns the entitlement, or nil when there is none.
+	FindOne(ctx context.Context, customerId string, productId string) (entitlement *Entitlement, err error)
+	FindAll(ctx context.Context, customerId string) (entitlements []Entitlement, err error)
+	// FindRenewals returns the entitlements needing a renewal order, that
+	// is ending after now and by endsBefore without a renewal for that end,
+	// and the entitlements whose renewal is in one of the dunning stages.
+	FindRenewals(ctx context.Context, endsBefore int64, now int64) (entitlements []Entitlement, err error)
+	// ClaimRenewal records renewal when the entitlement still ends at
+	// renewal.PeriodEnd, is not opted out and has no renewal for that end.
+	ClaimRenewal(ctx context.Context, customerId string, productId string, renewal EntitlementRenewal) (claimed bool, err error)
+	// UpdateRenewal replaces the renewal current with next, unless it
+	// changed since current was read, writing event, when not nil, to the
+	// outbox in the same write.
+	UpdateRenewal(ctx context.Context, customerId string, productId string, current EntitlementRenewal, next EntitlementRenewal, event *OutboxEvent) (updated bool, err error)
+	// ReleaseRenewal removes the renewal of orderId.
+	ReleaseRenewal(ctx context.Context, customerId string, productId string, orderId string) error
+	// SetRenewalOptOut reports false when there is no such entitlement.
+	SetRenewalOptOut(ctx context.Context, customerId string, productId string, optOut bool, updatedTime int64) (found bool, err error)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// VersionConflictError is returned by a repository when a write carrying an
+// expected version finds the order at a different version.
+type VersionConflictError struct {
+	OrderId string
+	Current int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("order %s was modified concurrently, current version is %d", e.OrderId, e.Current)
+}
+
+var (
+	// ErrCouponExists is returned when saving a coupon whose code is taken.
+	ErrCouponExists = errors.New("coupon code already exists")
+	// ErrCouponExhausted is returned when redeeming a coupon past its usage
+	// caps.
+	ErrCouponExhausted = errors.New("coupon usage limit reached")
+	// ErrNotRefundable is returned when refunding an order that is not
+	// settled.
+	ErrNotRefundable = errors.New("only settled orders can be refunded")
+	// ErrRefundExceedsPaid is returned when a refund would give back more
+	// than was paid.
+	ErrRefundExceedsPaid = errors.New("refund exceeds the amount left to refund")
+	// ErrDuplicateRefund is returned when a refund reference was already
+	// recorded on the order.
+	ErrDuplicateRefund = errors.New("refund reference already recorded")
+	// ErrVANumberTaken is returned when saving an order with the virtual
+	// account number of another pending order of the same bank.
+	ErrVANumberTaken = errors.New("virtual account number is in use by a pending order")
+	// ErrNotRetryable is returned when opening a payment attempt on an
+	// order that is neither pending nor expired.
+	ErrNotRetryable = errors.New("only pending or expired orders can be paid again")
+	// ErrUnbalancedJournal is wrapped by the errors of journals that are
+	// not valid double entries.
+	ErrUnbalancedJournal = errors.New("unbalanced journal")
+	// ErrEventRejected is wrapped by a Broker refusing an event for good, so
+	// that it is dead-lettered without being retried.
+	ErrEventRejected = errors.New("event rejected by the broker")
+)
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"order/pb"
+	"time"
+)
+
+// Invoice is the invoice of a settled order, rendered once when it is issued
+// and served as is from then on.
+type Invoice struct {
+	OrderId string `bson:"order_id"`
+	// Number is the invoice number shown, made of Year and Sequence.
+	Number string `bson:"number"`
+	Year   int64  `bson:"year"`
+	// Sequence is the rank of the invoice among the invoices of Year,
+	// starting at 1 and without gaps.
+	Sequence int64  `bson:"sequence"`
+	IssuedAt int64  `bson:"issued_at"`
+	HTML     []byte `bson:"html"`
+	PDF      []byte `bson:"pdf"`
+}
+
+// InvoiceSeller is the seller shown on invoices.
+type InvoiceSeller struct {
+	Name    string
+	Address string
+	// TaxId is the tax registration number of the seller, e.g. its NPWP.
+	TaxId string
+	Email string
+}
+
+// InvoicePolicy numbers invoices.
+type InvoicePolicy struct {
+	// Prefix starts every invoice number, "INV" by default.
+	Prefix string
+	// Location decides the year an invoice is issued in, UTC by default.
+	Location *time.Location
+}
+
+// InvoiceData is what an invoice is rendered from.
+type InvoiceData struct {
+	Number   string
+	IssuedAt int64
+	Order    *pb.Order
+}
+
+type InvoiceRenderer interface {
+	// Render returns the HTML and PDF documents of the invoice.
+	Render(data InvoiceData) (html []byte, pdf []byte, err error)
+}
+
+type InvoiceUsecase interface {
+	Get(ctx context.Context, req *pb.InvoiceRequest) (res *pb.Invoice, err error)
+}
+
+type InvoiceRepository interface {
+	// FindOne returns the invoice of the order, or nil when there is none.
+	FindOne(ctx context.Context, orderId string) (invoice *Invoice, err error)
+	// Issue saves the invoice built by build with the next sequence of year,
+	// atomically, so that an invoice failing to be built or saved uses no
+	// sequence. When the order already has an invoice, that invoice is
+	// returned instead.
+	Issue(ctx context.Context, orderId string, year int64, build func(sequence int64) (*Invoice, error)) (invoice *Invoice, err error)
+}
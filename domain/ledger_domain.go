@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"order/pb"
+)
+
+// JournalLine debits or credits an account of the ledger.
+type JournalLine struct {
+	Account string `bson:"account"`
+	Debit   int64  `bson:"debit"`
+	Credit  int64  `bson:"credit"`
+}
+
+// Journal is a balanced entry of the ledger: its lines debit as much as they
+// credit. Journals are never changed once posted; mistakes are corrected by
+// posting more journals.
+type Journal struct {
+	// JournalId identifies the money movement the journal records, e.g.
+	// "refund:<order_id>:<refund_id>", so that it is posted once.
+	JournalId string `bson:"journal_id"`
+	// Kind is the kind of movement, see variable.JournalReceipt.
+	Kind     string        `bson:"kind"`
+	OrderId  string        `bson:"order_id"`
+	Currency string        `bson:"currency"`
+	Memo     string        `bson:"memo"`
+	Lines    []JournalLine `bson:"lines"`
+	// PostedAt is when the movement happened, which decides the period it
+	// counts in, and RecordedAt when the journal was written.
+	PostedAt   int64 `bson:"posted_at"`
+	RecordedAt int64 `bson:"recorded_at"`
+}
+
+// Check returns an error wrapping ErrUnbalancedJournal when the journal is
+// not a valid double entry: two lines at least, each either debiting or
+// crediting a named account, debiting as much as they credit.
+func (j *Journal) Check() error {
+	if j.JournalId == "" || j.Currency == "" {
+		return fmt.Errorf("%w: journal %q has no ID or currency", ErrUnbalancedJournal, j.JournalId)
+	}
+	if len(j.Lines) < 2 {
+		return fmt.Errorf("%w: journal %s has %d line(s)", ErrUnbalancedJournal, j.JournalId, len(j.Lines))
+	}
+
+	var debit, credit int64
+	for _, line := range j.Lines {
+		if line.Account == "" || line.Debit < 0 || line.Credit < 0 || (line.Debit == 0) == (line.Credit == 0) {
+			return fmt.Errorf("%w: journal %s has an invalid line %+v", ErrUnbalancedJournal, j.JournalId, line)
+		}
+		debit += line.Debit
+		credit += line.Credit
+	}
+	if debit != credit {
+		return fmt.Errorf("%w: journal %s debits %d and credits %d", ErrUnbalancedJournal, j.JournalId, debit, credit)
+	}
+
+	return nil
+}
+
+// AccountBalance sums the lines posted to an account in one currency.
+// Balance is Debit minus Credit.
+type AccountBalance struct {
+	Account  string
+	Currency string
+	Debit    int64
+	Credit   int64
+	Balance  int64
+}
+
+type LedgerUsecase interface {
+	Balances(ctx context.Context, req *pb.LedgerBalanceRequest) (res *pb.LedgerBalanceResponse, err error)
+	Check(ctx context.Context, req *pb.LedgerCheckRequest) (res *pb.LedgerCheckResponse, err error)
+}
+
+type LedgerRepository interface {
+	// Post appends journal to the ledger and reports whether it did: a
+	// journal whose ID was posted already is left out. Journals failing
+	// Check are refused.
+	Post(ctx context.Context, journal *Journal) (posted bool, err error)
+	// Balances sums the lines posted in the period from from to to per
+	// account and currency, sorted by account and currency. With an
+	// account, only that account and its sub-accounts are summed, e.g.
+	// "liabilities" sums "liabilities:tax_payable".
+	Balances(ctx context.Context, account string, currency string, from int64, to int64) (balances []AccountBalance, err error)
+	// Journals calls fn with the journals posted in the period from from
+	// to to, in the order they were recorded, until fn returns an error.
+	Journals(ctx context.Context, from int64, to int64, fn func(journal Journal) error) error
+}
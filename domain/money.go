@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"order/variable"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned by Money arithmetic on amounts of different
+// currencies.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// ErrAmountOverflow is returned by Money arithmetic whose result does not
+// fit in an int64.
+var ErrAmountOverflow = errors.New("amount overflow")
+
+// Money is an amount in the minor units of its ISO 4217 currency.
+type Money struct {
+	Currency string `bson:"currency" json:"currency"`
+	Amount   int64  `bson:"amount" json:"amount"`
+}
+
+func NewMoney(currency string, amount int64) Money {
+	return Money{Currency: currency, Amount: amount}
+}
+
+// ValidCurrency reports whether currency is supported.
+func ValidCurrency(currency string) bool {
+	_, ok := variable.CurrencyExponents[currency]
+	return ok
+}
+
+func (m Money) check(other Money) error {
+	if m.Currency != other.Currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+
+	return nil
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.check(other); err != nil {
+		return Money{}, err
+	}
+
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, fmt.Errorf("%w: %v + %v", ErrAmountOverflow, m, other)
+	}
+
+	return Money{Currency: m.Currency, Amount: sum}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.check(other); err != nil {
+		return Money{}, err
+	}
+
+	difference := m.Amount - other.Amount
+	if (other.Amount > 0 && difference > m.Amount) || (other.Amount < 0 && difference < m.Amount) {
+		return Money{}, fmt.Errorf("%w: %v - %v", ErrAmountOverflow, m, other)
+	}
+
+	return Money{Currency: m.Currency, Amount: difference}, nil
+}
+
+// Mul multiplies m by n, a quantity rather than an amount, so it only fails
+// on overflow.
+func (m Money) Mul(n int64) (Money, error) {
+	product := m.Amount * n
+	if m.Amount != 0 && (product/m.Amount != n || (m.Amount == -1 && n == math.MinInt64)) {
+		return Money{}, fmt.Errorf("%w: %v * %d", ErrAmountOverflow, m, n)
+	}
+
+	return Money{Currency: m.Currency, Amount: product}, nil
+}
+
+// Cmp compares m to other, returning -1, 0 or +1.
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.check(other); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+// String formats m in major units, e.g. "USD 12.50".
+func (m Money) String() string {
+	exp := variable.CurrencyExponents[m.Currency]
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exp > 0 {
+		if len(digits) <= exp {
+			digits = strings.Repeat("0", exp-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+	}
+
+	return m.Currency + " " + sign + digits
+}
+
+// ParseMoney parses amount, in the major units of currency such as "12.50"
+// or "15000", into Money. Digits beyond the exponent of currency must be
+// zeros.
+func ParseMoney(currency string, amount string) (Money, error) {
+	exp, ok := variable.CurrencyExponents[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("unknown currency %q", currency)
+	}
+
+	digits := strings.TrimSpace(amount)
+	sign := int64(1)
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = -1, digits[1:]
+	}
+
+	whole, fraction := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		whole, fraction = digits[:i], digits[i+1:]
+	}
+	if len(fraction) > exp {
+		if strings.Trim(fraction[exp:], "0") != "" {
+			return Money{}, fmt.Errorf("amount %q has more than %d decimals for %s", amount, exp, currency)
+		}
+		fraction = fraction[:exp]
+	}
+	fraction += strings.Repeat("0", exp-len(fraction))
+
+	if whole == "" {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	n, err := strconv.ParseUint(whole+fraction, 10, 63)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	return Money{Currency: currency, Amount: sign * int64(n)}, nil
+}
+
+// MoneyTotals accumulates amounts per currency.
+type MoneyTotals map[string]int64
+
+// Add adds m to the total of its currency, failing when it overflows.
+func (t MoneyTotals) Add(m Money) error {
+	sum, err := NewMoney(m.Currency, t[m.Currency]).Add(m)
+	if err != nil {
+		return err
+	}
+	t[m.Currency] = sum.Amount
+
+	return nil
+}
+
+// List returns the totals sorted by currency.
+func (t MoneyTotals) List() (list []Money) {
+	for currency, amount := range t {
+		list = append(list, Money{Currency: currency, Amount: amount})
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Currency < list[j].Currency })
+
+	return
+}
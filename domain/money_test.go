@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMoneyArithmetic(t *testing.T) {
+	idr := func(amount int64) Money { return NewMoney("IDR", amount) }
+	usd := func(amount int64) Money { return NewMoney("USD", amount) }
+
+	tests := []struct {
+		name string
+		op   func() (Money, error)
+		want Money
+		err  error
+	}{
+		{"add", func() (Money, error) { return idr(1500).Add(idr(500)) }, idr(2000), nil},
+		{"add a negative amount", func() (Money, error) { return idr(1500).Add(idr(-2000)) }, idr(-500), nil},
+		{"add another currency", func() (Money, error) { return idr(1500).Add(usd(500)) }, Money{}, ErrCurrencyMismatch},
+		{"add without a currency", func() (Money, error) { return idr(1500).Add(Money{Amount: 500}) }, Money{}, ErrCurrencyMismatch},
+		{"add beyond int64", func() (Money, error) { return idr(math.MaxInt64).Add(idr(1)) }, Money{}, ErrAmountOverflow},
+		{"add below int64", func() (Money, error) { return idr(math.MinInt64).Add(idr(-1)) }, Money{}, ErrAmountOverflow},
+		{"sub", func() (Money, error) { return usd(1250).Sub(usd(250)) }, usd(1000), nil},
+		{"sub another currency", func() (Money, error) { return usd(1250).Sub(idr(250)) }, Money{}, ErrCurrencyMismatch},
+		{"sub below int64", func() (Money, error) { return idr(math.MinInt64).Sub(idr(1)) }, Money{}, ErrAmountOverflow},
+		{"sub beyond int64", func() (Money, error) { return idr(math.MaxInt64).Sub(idr(-1)) }, Money{}, ErrAmountOverflow},
+		{"mul", func() (Money, error) { return usd(1250).Mul(3) }, usd(3750), nil},
+		{"mul by a negative quantity", func() (Money, error) { return usd(1250).Mul(-2) }, usd(-2500), nil},
+		{"mul zero", func() (Money, error) { return usd(0).Mul(math.MaxInt64) }, usd(0), nil},
+		{"mul beyond int64", func() (Money, error) { return idr(math.MaxInt64 / 2).Mul(3) }, Money{}, ErrAmountOverflow},
+		{"mul the smallest int64", func() (Money, error) { return idr(-1).Mul(math.MinInt64) }, Money{}, ErrAmountOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op()
+			if !errors.Is(err, tt.err) || got != tt.want {
+				t.Errorf("got %v, %v; want %v, %v", got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestMoneyCmp(t *testing.T) {
+	if cmp, err := NewMoney("IDR", 100).Cmp(NewMoney("IDR", 200)); err != nil || cmp != -1 {
+		t.Errorf("Cmp(IDR 100, IDR 200) = %d, %v; want -1", cmp, err)
+	}
+	if _, err := NewMoney("IDR", 100).Cmp(NewMoney("USD", 100)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Cmp(IDR 100, USD 1.00) error = %v; want ErrCurrencyMismatch", err)
+	}
+}
+
+func TestMoneyTotals(t *testing.T) {
+	totals := MoneyTotals{}
+	for _, m := range []Money{NewMoney("USD", 1250), NewMoney("IDR", 5000), NewMoney("USD", -250)} {
+		if err := totals.Add(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// currencies are kept apart rather than mixed
+	list := totals.List()
+	if len(list) != 2 || list[0] != NewMoney("IDR", 5000) || list[1] != NewMoney("USD", 1000) {
+		t.Errorf("List = %v; want IDR 5000 and USD 10.00", list)
+	}
+
+	if err := totals.Add(NewMoney("IDR", math.MaxInt64)); !errors.Is(err, ErrAmountOverflow) {
+		t.Errorf("Add beyond int64 = %v; want ErrAmountOverflow", err)
+	}
+	if totals["IDR"] != 5000 {
+		t.Errorf("IDR total after an overflow = %d; want it unchanged", totals["IDR"])
+	}
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"order/pb"
+)
+
+// Notification is a message sent to a buyer about one of their orders. Its
+// NotificationId derives from the event, template and channel, so that an
+// event published again is not notified twice.
+type Notification struct {
+	NotificationId string `bson:"notification_id"`
+	EventId        string `bson:"event_id"`
+	OrderId        string `bson:"order_id"`
+	CustomerId     string `bson:"customer_id"`
+	Template       string `bson:"template"`
+	Channel        string `bson:"channel"`
+	// Recipient is the email address or phone number notified.
+	Recipient string `bson:"recipient"`
+	Status    string `bson:"status"`
+	Error     string `bson:"error"`
+	// Attempts counts the times the notification was claimed.
+	Attempts  int64 `bson:"attempts"`
+	CreatedAt int64 `bson:"created_at"`
+	UpdatedAt int64 `bson:"updated_at"`
+	SentAt    int64 `bson:"sent_at"`
+}
+
+// NotificationMessage is a rendered notification, ready to be sent.
+type NotificationMessage struct {
+	Channel string `json:"channel"`
+	// To is the email address or phone number of the recipient.
+	To      string `json:"to"`
+	Subject string `json:"subject,omitempty"`
+	Text    string `json:"text"`
+	// HTML is the HTML alternative of Text, for emails.
+	HTML string `json:"html,omitempty"`
+}
+
+// NotificationData is what notification templates are rendered with.
+type NotificationData struct {
+	Order   *pb.Order
+	Buyer   *pb.OrderBuyer
+	Product *pb.OrderProduct
+	Payment *pb.OrderPayment
+	// Locale is the locale of the template rendered, e.g. "id".
+	Locale string
+}
+
+type NotificationChannel interface {
+	Send(ctx context.Context, message NotificationMessage) error
+	Close() error
+}
+
+// SMSProvider sends text messages, for the SMS notification channel.
+type SMSProvider interface {
+	SendSMS(ctx context.Context, to string, text string) error
+}
+
+type NotificationTemplates interface {
+	// Render renders template for channel in the language of locale,
+	// falling back to the default language. It reports false when there is
+	// no such template.
+	Render(template string, channel string, locale string, data NotificationData) (message NotificationMessage, found bool, err error)
+}
+
+type NotificationRepository interface {
+	// Claim saves the notification as being sent. It reports false when the
+	// notification was sent, or is being sent since staleBefore or later.
+	Claim(ctx context.Context, notification *Notification, staleBefore int64) (claimed bool, err error)
+	// Complete records the Status, Error, UpdatedAt and SentAt of the
+	// notification.
+	Complete(ctx context.Context, notification Notification) error
+}
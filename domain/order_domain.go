@@ -3,12 +3,20 @@ package domain
 import (
 	"context"
 	"order/pb"
+	"order/variable"
 )
 
 type OrderBuyer struct {
 	CustomerId string `bson:"customer_id"`
 	Name       string `bson:"name"`
 	User       string `bson:"user"`
+	// Jurisdiction is the tax jurisdiction of the buyer, e.g. "ID".
+	Jurisdiction string `bson:"jurisdiction"`
+	Email        string `bson:"email"`
+	// Phone is the number in E.164 format, e.g. "+6281234567890".
+	Phone string `bson:"phone"`
+	// Locale is the language notifications are written in, e.g. "id-ID".
+	Locale string `bson:"locale"`
 }
 
 type OrderProduct struct {
@@ -17,6 +25,56 @@ type OrderProduct struct {
 	Price       int64  `bson:"price"`
 	Duration    int64  `bson:"duration"`
 	Description string `bson:"description"`
+	// Category selects the tax rule of the product.
+	Category string `bson:"category"`
+}
+
+// OrderItem is a line of an order: a snapshot of the product bought, how many
+// and at which price.
+type OrderItem struct {
+	Product   OrderProduct `bson:"product"`
+	Quantity  int64        `bson:"quantity"`
+	UnitPrice int64        `bson:"unit_price"`
+	LineTotal int64        `bson:"line_total"`
+	// Discount is the share of the order discounts taken off this line.
+	Discount int64 `bson:"discount"`
+}
+
+// OrderDiscount is a discount applied to an order, e.g. by a coupon.
+type OrderDiscount struct {
+	Code        string `bson:"code"`
+	Description string `bson:"description"`
+	Amount      int64  `bson:"amount"`
+}
+
+// OrderTax is the tax charged under one rule on the lines it matched.
+type OrderTax struct {
+	Name    string `bson:"name"`
+	Rate    int64  `bson:"rate"`
+	Taxable int64  `bson:"taxable"`
+	Amount  int64  `bson:"amount"`
+}
+
+// OrderRefund is money given back on a settled order.
+type OrderRefund struct {
+	RefundId string `bson:"refund_id"`
+	Amount   int64  `bson:"amount"`
+	Reason   string `bson:"reason"`
+	// Actor is who issued the refund.
+	Actor string `bson:"actor"`
+	// Reference identifies the refund at the payment provider, a reference
+	// is only accepted once per order.
+	Reference string `bson:"reference"`
+	CreatedAt int64  `bson:"created_at"`
+}
+
+// OrderPlanChange records the plan an order moves the customer from. The
+// unused value of the old plan is taken off the order, and what exceeds the
+// price of the new plan is a credit owed to the customer.
+type OrderPlanChange struct {
+	FromProductId string `bson:"from_product_id"`
+	UnusedValue   int64  `bson:"unused_value"`
+	Credit        int64  `bson:"credit"`
 }
 
 type OrderPayment struct {
@@ -25,35 +83,262 @@ type OrderPayment struct {
 	Bank        string `bson:"bank"`
 	VaNumber    string `bson:"va_number"`
 	GrossAmount int64  `bson:"gross_amount"`
+	// QrString is the QRIS payload orders paid by QR are paid with.
+	QrString string `bson:"qr_string"`
 }
 
+// PaymentAttempt is one way offered to pay an order: a virtual account or a
+// QR code, valid until ExpiresAt. An order has a single active attempt,
+// which its Payment mirrors; opening another supersedes it.
+type PaymentAttempt struct {
+	// Reference identifies the attempt at the payment gateway, settlement
+	// notifications carry it as their order ID.
+	Reference   string `bson:"reference"`
+	PaymentType string `bson:"payment_type"`
+	Bank        string `bson:"bank"`
+	VaNumber    string `bson:"va_number"`
+	QrString    string `bson:"qr_string"`
+	Amount      int64  `bson:"amount"`
+	ExpiresAt   int64  `bson:"expires_at"`
+	Status      string `bson:"status"`
+	CreatedAt   int64  `bson:"created_at"`
+}
+
+// Payment returns the payment instructions of the attempt.
+func (a PaymentAttempt) Payment() OrderPayment {
+	return OrderPayment{
+		PaymentType: a.PaymentType,
+		OrderID:     a.Reference,
+		Bank:        a.Bank,
+		VaNumber:    a.VaNumber,
+		GrossAmount: a.Amount,
+		QrString:    a.QrString,
+	}
+}
+
+// OrderReceipt is money received for an order, as notified by the payment
+// gateway.
+type OrderReceipt struct {
+	// TransactionId identifies the transfer at the gateway, a transaction
+	// is only counted once per order.
+	TransactionId string `bson:"transaction_id"`
+	// Reference is the payment attempt the money was paid to.
+	Reference  string `bson:"reference"`
+	Amount     int64  `bson:"amount"`
+	Reason     string `bson:"reason"`
+	ReceivedAt int64  `bson:"received_at"`
+	// Fee is what the gateway charged for the transaction, out of Amount.
+	Fee int64 `bson:"fee"`
+}
+
+// OrderOverpayment is what a customer paid beyond the total of an order,
+// owed back to them as Disposition says.
+type OrderOverpayment struct {
+	Amount      int64  `bson:"amount"`
+	Disposition string `bson:"disposition"`
+}
+
+// PaymentPolicy decides how the money received for orders is accounted for.
+type PaymentPolicy struct {
+	// Overpayment is what the surplus of overpaid orders becomes, a
+	// customer credit by default.
+	Overpayment string
+}
+
+// Order amounts are in the minor units of the order currency.
 type Order struct {
-	OrderId        string       `bson:"order_id"`
-	Buyer          OrderBuyer   `bson:"buyer"`
-	Product        OrderProduct `bson:"product"`
-	Payment        OrderPayment `bson:"payment"`
-	Status         string       `bson:"status"`
-	CreatedAt      int64        `bson:"created_at"`
-	UpdatedAt      int64        `bson:"updated_at"`
-	SettlementTime int64        `bson:"settlement_time"`
-	TrxTime        int64        `bson:"trx_time"`
-	PayExp         int64        `bson:"pay_exp"`
+	OrderId string     `bson:"order_id"`
+	Buyer   OrderBuyer `bson:"buyer"`
+	// Product mirrors the first line item for readers predating Items.
+	Product      OrderProduct    `bson:"product"`
+	Items        []OrderItem     `bson:"items"`
+	Subtotal     int64           `bson:"subtotal"`
+	Discounts    []OrderDiscount `bson:"discounts"`
+	Taxes        []OrderTax      `bson:"taxes"`
+	TaxInclusive bool            `bson:"tax_inclusive"`
+	TaxTotal     int64           `bson:"tax_total"`
+	Total        int64           `bson:"total"`
+	// Payment mirrors the active attempt, or the one the order was paid by.
+	Payment        OrderPayment  `bson:"payment"`
+	Status         string        `bson:"status"`
+	CreatedAt      int64         `bson:"created_at"`
+	UpdatedAt      int64         `bson:"updated_at"`
+	SettlementTime int64         `bson:"settlement_time"`
+	TrxTime        int64         `bson:"trx_time"`
+	PayExp         int64         `bson:"pay_exp"`
+	StatusReason   string        `bson:"status_reason"`
+	Version        int64         `bson:"version"`
+	Currency       string        `bson:"currency"`
+	Refunds        []OrderRefund `bson:"refunds"`
+	RefundedTotal  int64         `bson:"refunded_total"`
+	// PlanChange is set on the orders made by ChangePlan.
+	PlanChange *OrderPlanChange `bson:"plan_change,omitempty"`
+	// MerchantId is the merchant selling the order, whose webhooks are
+	// told about it.
+	MerchantId string `bson:"merchant_id"`
+	// Attempts are the payment attempts of the order, oldest first.
+	Attempts []PaymentAttempt `bson:"attempts"`
+	// Receipts are the payments received for the order, adding up to
+	// PaidTotal.
+	Receipts  []OrderReceipt `bson:"receipts"`
+	PaidTotal int64          `bson:"paid_total"`
+	// Overpayment is set once more than the total was paid.
+	Overpayment *OrderOverpayment `bson:"overpayment,omitempty"`
+}
+
+// Lines returns the line items of the order. Orders stored before line items
+// existed hold a single product, which is returned as one line.
+func (o *Order) Lines() []OrderItem {
+	if len(o.Items) > 0 || o.Product == (OrderProduct{}) {
+		return o.Items
+	}
+
+	return []OrderItem{{
+		Product:   o.Product,
+		Quantity:  1,
+		UnitPrice: o.Product.Price,
+		LineTotal: o.Product.Price,
+	}}
+}
+
+// PaymentAttempts returns the payment attempts of the order. Orders stored
+// before attempts existed hold a single payment, which is returned as one
+// attempt.
+func (o *Order) PaymentAttempts() []PaymentAttempt {
+	if len(o.Attempts) > 0 || o.Payment == (OrderPayment{}) {
+		return o.Attempts
+	}
+
+	status := variable.PaymentAttemptActive
+	if o.SettlementTime > 0 {
+		status = variable.PaymentAttemptPaid
+	}
+
+	return []PaymentAttempt{{
+		Reference:   o.Payment.OrderID,
+		PaymentType: o.Payment.PaymentType,
+		Bank:        o.Payment.Bank,
+		VaNumber:    o.Payment.VaNumber,
+		QrString:    o.Payment.QrString,
+		Amount:      o.Payment.GrossAmount,
+		ExpiresAt:   o.PayExp,
+		Status:      status,
+		CreatedAt:   o.CreatedAt,
+	}}
+}
+
+// AmountPaid returns the money received for the order. Orders settled
+// before receipts existed were paid their gross amount.
+func (o *Order) AmountPaid() int64 {
+	if len(o.Receipts) > 0 {
+		return o.PaidTotal
+	}
+
+	for _, status := range variable.SettledStatuses {
+		if o.Status == status {
+			return o.Payment.GrossAmount
+		}
+	}
+
+	return 0
+}
+
+// OrderSubtotal returns the sum of the line totals, before discounts.
+func (o *Order) OrderSubtotal() (subtotal int64) {
+	for _, line := range o.Lines() {
+		subtotal += line.LineTotal
+	}
+
+	return
+}
+
+// OrderTotal returns the amount to pay for the order. Orders stored before
+// line items existed have no total and pay their single product.
+func (o *Order) OrderTotal() int64 {
+	if len(o.Items) > 0 {
+		return o.Total
+	}
+
+	return o.OrderSubtotal()
 }
 
 type OrderUsecase interface {
-	Save(ctx context.Context, req *pb.OrderCreateRequest) error
+	Save(ctx context.Context, req *pb.OrderCreateRequest) (res *pb.Order, err error)
+	Quote(ctx context.Context, req *pb.OrderCreateRequest) (res *pb.OrderQuote, err error)
 	ChangeStatus(ctx context.Context, req *pb.OrderChangeStatus) (affected bool, err error)
 	FindOne(ctx context.Context, req *pb.OrderFindOneRequest) (res *pb.OrderFindOneResponse, err error)
 	FindAll(ctx context.Context, req *pb.OrderFindAllRequest) (res *pb.OrderFindAllResponse, err error)
-	SumIncome(ctx context.Context, req *pb.OrderSumIncomeRequest) (res int64, err error)
+	SumIncome(ctx context.Context, req *pb.OrderSumIncomeRequest) (totals []Money, err error)
 	Cancel(ctx context.Context, req *pb.OrderCancelRequest) (res *pb.OperationResponse, err error)
+	Expire(ctx context.Context, req *pb.OrderExpireRequest) (affected int64, err error)
+	TaxReport(ctx context.Context, req *pb.OrderTaxReportRequest) (res *pb.OrderTaxReportResponse, err error)
+	Refund(ctx context.Context, req *pb.OrderRefundRequest) (res *pb.OrderRefundResponse, err error)
+	Renew(ctx context.Context, req *pb.OrderRenewRequest) (res *pb.OrderRenewResponse, err error)
+	QuotePlanChange(ctx context.Context, req *pb.PlanChangeRequest) (res *pb.PlanChangeQuote, err error)
+	ChangePlan(ctx context.Context, req *pb.PlanChangeRequest) (res *pb.PlanChangeResponse, err error)
+	PaymentQR(ctx context.Context, req *pb.PaymentQRRequest) (res *pb.PaymentQR, err error)
+	RetryPayment(ctx context.Context, req *pb.RetryPaymentRequest) (res *pb.Order, err error)
+	Reconcile(ctx context.Context, req *pb.ReconcileRequest) (res *pb.ReconciliationReport, err error)
 }
 
 type OrderRepository interface {
-	Save(ctx context.Context, req *pb.OrderCreateRequest, createdTime int64) error
+	// Save stores a new order, returning ErrVANumberTaken when another
+	// pending order of its bank has its virtual account number.
+	Save(ctx context.Context, order *Order) error
+	// ChangeStatus applies req to the order whose active attempt is
+	// req.OrderId. Settlements apply to the order of any attempt, which
+	// becomes the attempt the order is paid by.
 	ChangeStatus(ctx context.Context, req *pb.OrderChangeStatus, updatedTime int64) (affected bool, err error)
 	FindOne(ctx context.Context, req *pb.OrderFindOneRequest) (res *pb.OrderFindOneResponse, err error)
+	// FindByPaymentReference returns the order with an attempt referenced
+	// reference, or nil when there is none.
+	FindByPaymentReference(ctx context.Context, reference string) (order *pb.Order, err error)
+	// FindByVANumber returns the latest order created at or before at, any
+	// time when 0, with an attempt paid into the virtual account vaNumber of
+	// bank, any bank when empty. It returns nil when there is none.
+	FindByVANumber(ctx context.Context, bank string, vaNumber string, at int64) (order *pb.Order, err error)
+	// FindPaid returns the orders that received money in the period from
+	// from to to, by settlement time or by receipt, sorted by order ID.
+	// Orders with nothing to pay are left out.
+	FindPaid(ctx context.Context, from int64, to int64) (orders []*pb.Order, err error)
 	FindAll(ctx context.Context, req *pb.OrderFindAllRequest) (orders *pb.OrderFindAllResponse, err error)
-	SumIncome(ctx context.Context, req *pb.OrderSumIncomeRequest) (res int64, err error)
+	// SumIncome totals the orders in req.Status per currency, sorted by
+	// currency, and returns mongo.ErrNoDocuments when no order matches.
+	// The settlement status covers every settled order, refunded or not,
+	// net of the refunds issued in the period, and partially paid orders
+	// count what they received so far under their own status only. A
+	// req.UserId restricts the totals to the orders of that customer.
+	SumIncome(ctx context.Context, req *pb.OrderSumIncomeRequest) (totals []Money, err error)
 	Cancel(ctx context.Context, req *pb.OrderCancelRequest) (res *pb.OperationResponse, err error)
+	// Expire expires the pending and partially paid orders whose payment
+	// window elapsed before now, which frees their virtual account numbers.
+	// What a partially paid order received stays on it, and counts towards
+	// a payment retried later.
+	Expire(ctx context.Context, now int64) (affected int64, err error)
+	// TaxReport sums the taxes of settled orders per period of their
+	// settlement time, refunded or not, and the taxes refunds gave back as
+	// negative refund rows per period of the refund, req.Period having been
+	// resolved by the caller.
+	TaxReport(ctx context.Context, req *pb.OrderTaxReportRequest) (rows []*pb.OrderTaxReportRow, err error)
+	// Refund records refund on the settled order orderId, refusing refunds
+	// beyond the amount paid, and returns the updated order. It returns nil
+	// when the order does not exist.
+	Refund(ctx context.Context, orderId string, refund *OrderRefund, expectedVersion int64) (order *pb.Order, err error)
+	// RecordPayment records receipt on the order with an attempt referenced
+	// receipt.Reference, and returns the order. A receipt without an amount
+	// pays what is left to pay. The order is partially paid until its total
+	// is covered, then settled, and what exceeds the total is recorded as
+	// an overpayment under policy. A receipt whose transaction was counted
+	// already, or for a refunded order, changes nothing. It returns nil when
+	// no order matches.
+	RecordPayment(ctx context.Context, receipt *OrderReceipt, policy PaymentPolicy, updatedTime int64, expectedVersion int64) (order *pb.Order, affected bool, err error)
+	// RetryPayment opens attempt on the pending or expired order orderId,
+	// superseding its active attempt, and returns the updated order. It
+	// returns ErrNotRetryable for orders in another status,
+	// ErrVANumberTaken when another pending order of the bank has the
+	// virtual account number of attempt, and nil when the order does not
+	// exist.
+	RetryPayment(ctx context.Context, orderId string, attempt *PaymentAttempt, expectedVersion int64) (order *pb.Order, err error)
+	// StampCurrency sets currency on the orders stored without one.
+	StampCurrency(ctx context.Context, currency string) (affected int64, err error)
 }
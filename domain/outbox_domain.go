@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// OutboxEvent is a domain event about an order. It is written to the outbox
+// together with the change it records, and published from there by the
+// relay, at least once.
+type OutboxEvent struct {
+	EventId string `bson:"event_id" json:"event_id"`
+	Type    string `bson:"type" json:"type"`
+	OrderId string `bson:"order_id" json:"order_id"`
+	// Sequence is the version of the order after the change, ordering the
+	// events of an order.
+	Sequence  int64 `bson:"sequence" json:"sequence"`
+	CreatedAt int64 `bson:"created_at" json:"created_at"`
+	// Payload is the order after the change, in protobuf JSON.
+	Payload json.RawMessage `bson:"payload" json:"payload"`
+	// Attempts counts the failed publications, retried from NextAttemptAt.
+	Attempts      int64  `bson:"attempts" json:"-"`
+	NextAttemptAt int64  `bson:"next_attempt_at" json:"-"`
+	LastError     string `bson:"last_error" json:"-"`
+	// Delivered names the brokers that accepted the event, which are not
+	// published it again when the others are retried.
+	Delivered []string `bson:"delivered,omitempty" json:"-"`
+	// DeadAt is when the event was moved to the dead letters.
+	DeadAt int64 `bson:"dead_at,omitempty" json:"-"`
+}
+
+// OutboxPolicy tunes the relay publishing the outbox.
+type OutboxPolicy struct {
+	// BatchSize is how many events a relay run reads at most.
+	BatchSize int64
+	// MaxAttempts is how many times an event is tried before it is
+	// dead-lettered.
+	MaxAttempts int64
+	// Backoff is the delay in seconds before the first retry, doubling
+	// with each attempt.
+	Backoff int64
+}
+
+type OutboxRepository interface {
+	// Pending returns up to limit events, including the ones waiting for a
+	// retry, oldest first and in sequence for each order.
+	Pending(ctx context.Context, limit int64) (events []OutboxEvent, err error)
+	// Published removes the event from the outbox.
+	Published(ctx context.Context, eventId string) error
+	// Failed records the Attempts, NextAttemptAt, LastError and Delivered of
+	// event.
+	Failed(ctx context.Context, event OutboxEvent) error
+	// DeadLetter moves the event from the outbox to the dead letters.
+	DeadLetter(ctx context.Context, event OutboxEvent) error
+	// DeadLetters returns the dead-lettered events, oldest first.
+	DeadLetters(ctx context.Context) (events []OutboxEvent, err error)
+}
+
+// Broker delivers events to the services reacting to orders.
+type Broker interface {
+	// Publish returns once the broker accepted the event.
+	Publish(ctx context.Context, event OutboxEvent) error
+	Close() error
+}
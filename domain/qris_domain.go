@@ -0,0 +1,35 @@
+package domain
+
+// QRISMerchant is the merchant QRIS payloads are issued for, as registered
+// with its acquirer.
+type QRISMerchant struct {
+	// AcquirerDomain is the globally unique identifier of the acquirer, in
+	// reverse domain notation, e.g. "ID.CO.BANKMANDIRI.WWW".
+	AcquirerDomain string
+	// MerchantPAN is the primary account number of the merchant at the
+	// acquirer, and MerchantId its merchant ID there.
+	MerchantPAN string
+	MerchantId  string
+	// NMID is the national merchant ID given by the QRIS registry.
+	NMID string
+	// Criteria is the business size criteria of the merchant: UMI, UKE,
+	// UME, UBE or URE.
+	Criteria string
+	// MCC is the ISO 18245 merchant category code.
+	MCC        string
+	Name       string
+	City       string
+	PostalCode string
+	// Country is the ISO 3166-1 alpha-2 code of the merchant, "ID" by
+	// default.
+	Country string
+}
+
+type PaymentQR interface {
+	// Payload returns the dynamic QR payload charging amount, in the minor
+	// units of currency, under reference.
+	Payload(amount int64, currency string, reference string) (payload string, err error)
+	// Render returns the QR code of payload as a PNG image about size
+	// pixels wide.
+	Render(payload string, size int) (image []byte, err error)
+}
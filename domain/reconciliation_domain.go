@@ -0,0 +1,29 @@
+package domain
+
+// SettlementRow is a transaction listed by the settlement report of a
+// payment gateway.
+type SettlementRow struct {
+	// Line is the line of the row in the report, the header being line 1.
+	Line int64
+	// Reference is the reference the transaction was charged under, the
+	// order_id of the payment of an order or of one of its attempts.
+	Reference string
+	Bank      string
+	VaNumber  string
+	// TransactionId is the ID of the transaction at the gateway.
+	TransactionId string
+	Amount        Money
+	// Fee is what the gateway kept of Amount.
+	Fee Money
+	// Status is the status of the transaction, settlement once paid.
+	Status string
+	// SettledAt is when the transaction was paid, 0 when the report does
+	// not say.
+	SettledAt int64
+}
+
+type SettlementReportParser interface {
+	// Parse reads the rows of the settlement report content, amounts
+	// without a currency being in currency.
+	Parse(content []byte, currency string) (rows []SettlementRow, err error)
+}
@@ -0,0 +1,22 @@
+package domain
+
+// TaxRule is the tax charged on the lines it matches. Empty Category or
+// Jurisdiction match any value; when several rules match a line the most
+// specific one wins, a category match weighing more than a jurisdiction one.
+type TaxRule struct {
+	Name string `json:"name"`
+	// Rate is expressed in basis points, 1100 being 11%.
+	Rate         int64  `json:"rate"`
+	Category     string `json:"category"`
+	Jurisdiction string `json:"jurisdiction"`
+}
+
+// TaxPolicy decides how orders are taxed at creation.
+type TaxPolicy struct {
+	// Inclusive reports whether product prices already contain the tax.
+	Inclusive bool `json:"inclusive"`
+	// Rounding is one of the variable.TaxRounding modes, applied once per
+	// tax line.
+	Rounding string    `json:"rounding"`
+	Rules    []TaxRule `json:"rules"`
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "context"
+
+// VirtualAccountScheme is how a bank numbers the virtual accounts of the
+// merchant: Prefix, the serial of the account padded with zeros, then the
+// check digit, Length digits in all.
+type VirtualAccountScheme struct {
+	Bank string `json:"bank"`
+	// Prefix is the company code the bank gave the merchant.
+	Prefix string `json:"prefix"`
+	Length int    `json:"length"`
+	// From and To bound the serials allocated, both included. To defaults
+	// to the largest serial that fits.
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+	// CheckDigit is one of the variable.VACheckDigit algorithms.
+	CheckDigit string `json:"check_digit"`
+}
+
+// VirtualAccountPolicy lists the banks the service allocates virtual
+// account numbers for.
+type VirtualAccountPolicy struct {
+	Schemes []VirtualAccountScheme `json:"schemes"`
+}
+
+// Scheme returns the scheme of bank, or nil when its numbers are not
+// allocated by the service.
+func (p *VirtualAccountPolicy) Scheme(bank string) *VirtualAccountScheme {
+	if p == nil {
+		return nil
+	}
+
+	for i := range p.Schemes {
+		if p.Schemes[i].Bank == bank {
+			return &p.Schemes[i]
+		}
+	}
+
+	return nil
+}
+
+type VirtualAccountRepository interface {
+	// Next returns the next value of the allocation counter of bank,
+	// starting at 1. Values are never handed out twice.
+	Next(ctx context.Context, bank string) (value int64, err error)
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"context"
+	"order/pb"
+	"time"
+)
+
+// WebhookSubscription asks for the order events of a merchant to be posted
+// to URL, signed with Secret.
+type WebhookSubscription struct {
+	SubscriptionId string `bson:"subscription_id"`
+	MerchantId     string `bson:"merchant_id"`
+	URL            string `bson:"url"`
+	Secret         string `bson:"secret"`
+	// EventTypes are the event types delivered, all of them when empty.
+	EventTypes []string `bson:"event_types"`
+	Disabled   bool     `bson:"disabled"`
+	CreatedAt  int64    `bson:"created_at"`
+	UpdatedAt  int64    `bson:"updated_at"`
+}
+
+// Wants reports whether the subscription delivers events of eventType.
+func (s *WebhookSubscription) Wants(eventType string) bool {
+	if s.Disabled {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+
+	for _, wanted := range s.EventTypes {
+		if wanted == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookAttempt is one request made to deliver a webhook. StatusCode is
+// zero when no response was received.
+type WebhookAttempt struct {
+	At         int64  `bson:"at"`
+	StatusCode int64  `bson:"status_code"`
+	Error      string `bson:"error"`
+	DurationMs int64  `bson:"duration_ms"`
+}
+
+// WebhookDelivery is an event to post to a subscription, with the log of
+// the attempts made.
+type WebhookDelivery struct {
+	DeliveryId     string `bson:"delivery_id"`
+	SubscriptionId string `bson:"subscription_id"`
+	MerchantId     string `bson:"merchant_id"`
+	EventId        string `bson:"event_id"`
+	EventType      string `bson:"event_type"`
+	OrderId        string `bson:"order_id"`
+	// Payload is the JSON body posted.
+	Payload  string           `bson:"payload"`
+	Status   string           `bson:"status"`
+	Attempts []WebhookAttempt `bson:"attempts"`
+	// Failures counts the failed attempts since the delivery was queued or
+	// last replayed.
+	Failures int64 `bson:"failures"`
+	// NextAttemptAt is when a pending delivery is due.
+	NextAttemptAt int64 `bson:"next_attempt_at"`
+	CreatedAt     int64 `bson:"created_at"`
+	UpdatedAt     int64 `bson:"updated_at"`
+}
+
+// WebhookDeliveryFilter selects deliveries, unset fields matching all.
+type WebhookDeliveryFilter struct {
+	MerchantId     string
+	SubscriptionId string
+	Status         string
+	OrderId        string
+	Limit          int64
+}
+
+// WebhookPolicy tunes the delivery of webhooks.
+type WebhookPolicy struct {
+	// MaxAttempts is how many times a delivery is tried before it fails.
+	MaxAttempts int64
+	// Backoff is the delay in seconds before the first retry, doubling
+	// with each attempt and jittered.
+	Backoff int64
+	// BatchSize is how many deliveries a dispatch run sends at most.
+	BatchSize int64
+	// Timeout bounds each request.
+	Timeout time.Duration
+}
+
+type WebhookUsecase interface {
+	Create(ctx context.Context, req *pb.WebhookSubscription) (res *pb.WebhookSubscription, err error)
+	Update(ctx context.Context, req *pb.WebhookUpdateRequest) (res *pb.WebhookSubscription, err error)
+	Delete(ctx context.Context, req *pb.WebhookDeleteRequest) (res *pb.OperationResponse, err error)
+	List(ctx context.Context, req *pb.WebhookListRequest) (res *pb.WebhookListResponse, err error)
+	ListDeliveries(ctx context.Context, req *pb.WebhookDeliveryListRequest) (res *pb.WebhookDeliveryListResponse, err error)
+	Replay(ctx context.Context, req *pb.WebhookReplayRequest) (res *pb.WebhookDelivery, err error)
+	Dispatch(ctx context.Context, req *pb.WebhookDispatchRequest) (res *pb.WebhookDispatchResponse, err error)
+}
+
+type WebhookRepository interface {
+	SaveSubscription(ctx context.Context, subscription *WebhookSubscription) error
+	// UpdateSubscription replaces the subscription of the same merchant and
+	// ID, reporting false when there is none.
+	UpdateSubscription(ctx context.Context, subscription *WebhookSubscription) (found bool, err error)
+	DeleteSubscription(ctx context.Context, merchantId string, subscriptionId string) (found bool, err error)
+	// FindSubscription returns the subscription, or nil when there is none.
+	FindSubscription(ctx context.Context, merchantId string, subscriptionId string) (subscription *WebhookSubscription, err error)
+	// FindSubscriptions returns the subscriptions of the merchant, oldest
+	// first.
+	FindSubscriptions(ctx context.Context, merchantId string) (subscriptions []WebhookSubscription, err error)
+	// Enqueue saves the deliveries. A delivery of an event to a
+	// subscription that already has one is skipped.
+	Enqueue(ctx context.Context, deliveries []WebhookDelivery) error
+	// DueDeliveries returns up to limit pending deliveries due at now,
+	// oldest first.
+	DueDeliveries(ctx context.Context, now int64, limit int64) (deliveries []WebhookDelivery, err error)
+	// ClaimDelivery moves the next attempt of the pending delivery from
+	// dueAt to leaseUntil, so that a single dispatcher sends it. It reports
+	// false when the delivery was claimed or changed since it was read.
+	ClaimDelivery(ctx context.Context, deliveryId string, dueAt int64, leaseUntil int64) (claimed bool, err error)
+	// RecordAttempt appends attempt to the log of the delivery and sets its
+	// Status, Failures, NextAttemptAt and UpdatedAt to those of delivery.
+	RecordAttempt(ctx context.Context, delivery WebhookDelivery, attempt WebhookAttempt) error
+	// FindDelivery returns the delivery, or nil when there is none.
+	FindDelivery(ctx context.Context, deliveryId string) (delivery *WebhookDelivery, err error)
+	// FindDeliveries returns the deliveries matching filter, newest first.
+	FindDeliveries(ctx context.Context, filter WebhookDeliveryFilter) (deliveries []WebhookDelivery, err error)
+	// Replay makes the delivery pending again, due at now. It reports false
+	// when there is no such delivery.
+	Replay(ctx context.Context, deliveryId string, now int64) (found bool, err error)
+}
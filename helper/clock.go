@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time. Every timestamp the service stores or
+// compares against is read from a Clock, so expiry and settlement logic can
+// be driven by a FakeClock in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// Unix returns the reading of c as stored in the database: UTC seconds.
+func Unix(c Clock) int64 {
+	return c.Now().UTC().Unix()
+}
+
+type systemClock struct{}
+
+// NewClock returns the Clock backed by the system time, in UTC.
+func NewClock() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// FakeClock is a Clock that only moves when told to.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a FakeClock stopped at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now.UTC()}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Set moves the clock to now.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now.UTC()
+}
+
+// Add moves the clock forward by d.
+func (c *FakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
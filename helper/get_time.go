@@ -1,23 +0,0 @@
-package helper
-
-import (
-	"order/variable"
-	"time"
-)
-
-func GetTime(timeType *string) (res int64) {
-	t := time.Now().UTC()
-
-	switch timeType {
-	case &variable.UnixNano:
-		res = t.UnixNano()
-	case &variable.UnixMili:
-		res = t.UnixMilli()
-	case &variable.UnixMicro:
-		res = t.UnixMicro()
-	default:
-		res = t.Unix()
-	}
-
-	return
-}
@@ -0,0 +1,16 @@
+package helper
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// NewID returns a random 128-bit identifier in hex.
+func NewID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
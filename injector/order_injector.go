@@ -1,16 +1,312 @@
 package injector
 
 import (
+	"context"
+	"log"
+	"order/app/broker"
 	"order/app/delivery"
+	"order/app/invoice"
+	"order/app/notifier"
+	"order/app/qris"
+	"order/app/reconcile"
 	"order/app/repository"
 	"order/app/usecase"
+	"order/config"
+	"order/domain"
+	"order/helper"
+	"order/variable"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
-func NewOrderInjector(db *mongo.Database) *delivery.OrderDelivery {
-	repo := repository.NewOrderRepository(db)
-	usecase := usecase.NewOrderUsecase(repo)
+// NewOrderInjector wires the OrderService on top of MongoDB, writing order
+// events to the outbox of db.
+func NewOrderInjector(db *mongo.Database, options usecase.OrderOptions, clock helper.Clock) *delivery.OrderDelivery {
+	repo := repository.NewOrderRepository(db, clock)
+	coupons := repository.NewCouponRepository(db)
+	entitlements := repository.NewEntitlementRepository(db)
+	webhooks := repository.NewWebhookRepository(db)
+	invoices := repository.NewInvoiceRepository(db)
+	accounts := repository.NewVirtualAccountRepository(db)
+	ledger := repository.NewLedgerRepository(db)
 
-	return delivery.NewOrderDelivery(usecase)
+	return newOrderDelivery(repo, coupons, entitlements, accounts, webhooks, invoices, ledger, options, clock)
+}
+
+// NewOrderMemoryInjector wires the OrderService on top of the in-memory
+// repository, for local development without a database. Order events are
+// written to outbox, webhooks stored in webhooks, and the ledger read from
+// ledger.
+func NewOrderMemoryInjector(outbox *repository.OutboxMemoryRepository, webhooks domain.WebhookRepository, ledger domain.LedgerRepository, options usecase.OrderOptions, clock helper.Clock) *delivery.OrderDelivery {
+	repo := repository.NewOrderMemoryRepository(clock, outbox)
+	coupons := repository.NewCouponMemoryRepository()
+	entitlements := repository.NewEntitlementMemoryRepository(outbox)
+	invoices := repository.NewInvoiceMemoryRepository()
+	accounts := repository.NewVirtualAccountMemoryRepository()
+
+	return newOrderDelivery(repo, coupons, entitlements, accounts, webhooks, invoices, ledger, options, clock)
+}
+
+// NewOrderInjectorFromConfig picks the repository implementation selected by
+// cfg.Store, connecting to MongoDB only when it is needed, and applies the
+// currency, tax, virtual account, QRIS, renewal, quote, webhook and invoice
+// settings of cfg. It also returns the relay publishing the order events to
+// the webhooks, the ledger, the buyer notifications and cfg.BrokerURL.
+func NewOrderInjectorFromConfig(cfg *config.Config, clock helper.Clock) (*delivery.OrderDelivery, *usecase.OutboxRelay) {
+	if !domain.ValidCurrency(cfg.Currency) {
+		log.Fatalf("unsupported default currency %q", cfg.Currency)
+	}
+
+	tax, err := config.LoadTaxPolicy(cfg.TaxRules)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	accounts, err := config.LoadVirtualAccountPolicy(cfg.VirtualAccounts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	qr, err := newPaymentQR(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	renewal, err := config.ParseRenewalPolicy(cfg.RenewalLeadDays, cfg.DunningSchedule)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	invoiceLocation, err := time.LoadLocation(cfg.InvoiceTimezone)
+	if err != nil {
+		log.Fatalf("INVOICE_TIMEZONE: %v", err)
+	}
+
+	settlementLocation, err := time.LoadLocation(cfg.SettlementTimezone)
+	if err != nil {
+		log.Fatalf("SETTLEMENT_TIMEZONE: %v", err)
+	}
+
+	if cfg.QuoteSecret == "" {
+		log.Print("QUOTE_SECRET is not set, quote tokens are only valid on this instance until it restarts")
+	}
+
+	options := usecase.OrderOptions{
+		Currency:        cfg.Currency,
+		Tax:             tax,
+		VirtualAccounts: accounts,
+		QRIS:            qr,
+		Renewal:         renewal,
+		QuoteSecret:     []byte(cfg.QuoteSecret),
+		QuoteTTL:        cfg.QuoteTTL,
+		Catalog:         newCatalog(cfg),
+		Webhooks: domain.WebhookPolicy{
+			MaxAttempts: cfg.WebhookMaxAttempts,
+			Backoff:     int64(cfg.WebhookBackoff.Seconds()),
+			Timeout:     cfg.WebhookTimeout,
+		},
+		Invoices: domain.InvoicePolicy{
+			Prefix:   cfg.InvoicePrefix,
+			Location: invoiceLocation,
+		},
+		Seller: domain.InvoiceSeller{
+			Name:    cfg.SellerName,
+			Address: cfg.SellerAddress,
+			TaxId:   cfg.SellerTaxId,
+			Email:   cfg.SellerEmail,
+		},
+		Payments:          domain.PaymentPolicy{Overpayment: cfg.Overpayment},
+		SettlementReports: reconcile.NewParser(settlementLocation),
+	}
+	if cfg.Store == config.StoreMemory {
+		outbox := repository.NewOutboxMemoryRepository()
+		webhooks := repository.NewWebhookMemoryRepository()
+		ledger := repository.NewLedgerMemoryRepository()
+		handler := NewOrderMemoryInjector(outbox, webhooks, ledger, options, clock)
+
+		return handler, newOutboxRelay(cfg, outbox, webhooks, repository.NewNotificationMemoryRepository(), ledger, clock)
+	}
+
+	db := config.NewConn(cfg.MongoURI).Database(cfg.Database)
+	if err := migrate(context.Background(), db, cfg, clock); err != nil {
+		log.Fatal(err)
+	}
+
+	handler := NewOrderInjector(db, options, clock)
+
+	return handler, newOutboxRelay(cfg, repository.NewOutboxRepository(db), repository.NewWebhookRepository(db), repository.NewNotificationRepository(db), repository.NewLedgerRepository(db), clock)
+}
+
+// newPaymentQR returns the QRIS generator of the merchant configured by cfg,
+// or nil when QRIS payments are not set up.
+func newPaymentQR(cfg *config.Config) (domain.PaymentQR, error) {
+	if cfg.QRISNMID == "" && cfg.QRISMerchantPAN == "" {
+		return nil, nil
+	}
+
+	return qris.NewGenerator(domain.QRISMerchant{
+		AcquirerDomain: cfg.QRISAcquirerDomain,
+		MerchantPAN:    cfg.QRISMerchantPAN,
+		MerchantId:     cfg.QRISMerchantId,
+		NMID:           cfg.QRISNMID,
+		Criteria:       cfg.QRISCriteria,
+		MCC:            cfg.QRISMCC,
+		Name:           cfg.QRISMerchantName,
+		City:           cfg.QRISMerchantCity,
+		PostalCode:     cfg.QRISPostalCode,
+	})
+}
+
+// newOutboxRelay returns the relay publishing outbox to the merchant
+// webhooks of webhooks, to the ledger, to the buyer notifications recorded
+// in notifications and to the broker configured by cfg, if any. The
+// in-process broker logs the events.
+func newOutboxRelay(cfg *config.Config, outbox domain.OutboxRepository, webhooks domain.WebhookRepository, notifications domain.NotificationRepository, ledger domain.LedgerRepository, clock helper.Clock) *usecase.OutboxRelay {
+	brokers := map[string]domain.Broker{
+		"webhooks": usecase.NewWebhookBroker(webhooks, clock),
+		"ledger":   usecase.NewLedgerBroker(ledger, clock),
+	}
+	if notification := newNotificationBroker(cfg, notifications, clock); notification != nil {
+		brokers["notifications"] = notification
+	}
+
+	if cfg.BrokerURL != "" {
+		b, err := broker.Open(cfg.BrokerURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if memory, ok := b.(*broker.MemoryBroker); ok {
+			memory.Subscribe(func(ctx context.Context, event domain.OutboxEvent) error {
+				log.Printf("event %s %s of order %s (#%d)", event.EventId, event.Type, event.OrderId, event.Sequence)
+				return nil
+			})
+		}
+
+		brokers["broker"] = b
+	}
+
+	policy := domain.OutboxPolicy{
+		MaxAttempts: cfg.OutboxMaxAttempts,
+		Backoff:     int64(cfg.OutboxBackoff.Seconds()),
+	}
+
+	return usecase.NewOutboxRelay(outbox, brokers, policy, clock)
+}
+
+// newNotificationBroker returns the broker notifying buyers through the
+// channels configured by cfg, nil when there is none.
+func newNotificationBroker(cfg *config.Config, notifications domain.NotificationRepository, clock helper.Clock) domain.Broker {
+	channels := map[string]domain.NotificationChannel{}
+	for channel, rawURL := range map[string]string{
+		variable.NotificationChannelEmail: cfg.NotificationEmailURL,
+		variable.NotificationChannelSMS:   cfg.NotificationSMSURL,
+	} {
+		if rawURL == "" {
+			continue
+		}
+
+		opened, err := notifier.Open(rawURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		channels[channel] = opened
+	}
+
+	if len(channels) == 0 {
+		log.Print("no notification channel is configured, buyers are not notified")
+		return nil
+	}
+
+	location, err := time.LoadLocation(cfg.NotificationTimezone)
+	if err != nil {
+		log.Fatalf("NOTIFICATION_TIMEZONE: %v", err)
+	}
+
+	templates, err := notifier.LoadTemplates(cfg.NotificationTemplates, cfg.NotificationLocale, location)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return usecase.NewNotificationBroker(notifications, templates, channels, clock)
+}
+
+// newCatalog returns the product catalog configured by cfg, nil when there is
+// none.
+func newCatalog(cfg *config.Config) domain.ProductCatalog {
+	switch {
+	case cfg.CatalogAddr != "":
+		conn, err := grpc.Dial(cfg.CatalogAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return repository.NewCatalogGRPCRepository(conn)
+	case cfg.CatalogFile != "":
+		catalog, err := repository.NewCatalogFileRepository(cfg.CatalogFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return catalog
+	}
+
+	log.Print("no product catalog is configured, the prices sent by clients are trusted")
+
+	return nil
+}
+
+// migrate brings the database up to date with the running code. Every step
+// is idempotent, so it runs on each start.
+func migrate(ctx context.Context, db *mongo.Database, cfg *config.Config, clock helper.Clock) error {
+	if err := repository.CreateCouponIndexes(ctx, db); err != nil {
+		return err
+	}
+	if err := repository.CreateEntitlementIndexes(ctx, db); err != nil {
+		return err
+	}
+	if err := repository.CreateOutboxIndexes(ctx, db); err != nil {
+		return err
+	}
+	if err := repository.CreateWebhookIndexes(ctx, db); err != nil {
+		return err
+	}
+	if err := repository.CreateNotificationIndexes(ctx, db); err != nil {
+		return err
+	}
+	if err := repository.CreateInvoiceIndexes(ctx, db); err != nil {
+		return err
+	}
+	if err := repository.CreateVirtualAccountIndexes(ctx, db); err != nil {
+		return err
+	}
+	if err := repository.CreateLedgerIndexes(ctx, db); err != nil {
+		return err
+	}
+
+	stamped, err := repository.NewOrderRepository(db, clock).StampCurrency(ctx, cfg.Currency)
+	if err != nil {
+		return err
+	}
+	if stamped > 0 {
+		log.Printf("migrate: stamped %d order(s) with currency %s", stamped, cfg.Currency)
+	}
+
+	return nil
+}
+
+func newOrderDelivery(repo domain.OrderRepository, coupons domain.CouponRepository, entitlements domain.EntitlementRepository, accounts domain.VirtualAccountRepository, webhooks domain.WebhookRepository, invoices domain.InvoiceRepository, ledger domain.LedgerRepository, options usecase.OrderOptions, clock helper.Clock) *delivery.OrderDelivery {
+	orders := usecase.NewOrderUsecase(repo, coupons, entitlements, accounts, options, clock)
+	couponUsecase := usecase.NewCouponUsecase(coupons, options.Currency, clock)
+	entitlementUsecase := usecase.NewEntitlementUsecase(entitlements, clock)
+	webhookUsecase := usecase.NewWebhookUsecase(webhooks, options.Webhooks, clock)
+	renderer := invoice.NewRenderer(options.Seller, options.Invoices.Location)
+	invoiceUsecase := usecase.NewInvoiceUsecase(repo, invoices, renderer, options.Invoices, clock)
+
+	ledgerUsecase := usecase.NewLedgerUsecase(ledger)
+
+	return delivery.NewOrderDelivery(orders, couponUsecase, entitlementUsecase, webhookUsecase, invoiceUsecase, ledgerUsecase)
 }
@@ -1,30 +1,32 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"order/app/gateway"
+	"order/app/usecase"
 	"order/config"
+	"order/helper"
 	"order/injector"
 	"order/pb"
-	"os"
+	"time"
 
-	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	// load .env file
-	if err := godotenv.Load(); err != nil {
+	// load the configuration from .env and the environment
+	cfg, err := config.Load()
+	if err != nil {
 		log.Fatal(err)
 	}
 
-	// create a connection to the database
-	db := config.NewConn().Database("db_order")
-
-	// get the port number from the environment
-	port := os.Getenv("PORT")
+	// get the port number from the configuration
+	port := cfg.Port
 
 	// listen for incoming connections on the specified port
 	lis, err := net.Listen("tcp", port)
@@ -38,12 +40,37 @@ func main() {
 	// enable reflection on the server
 	reflection.Register(GRPServer)
 
-	// create a new OrderService handler with the database connection
-	handler := injector.NewOrderInjector(db)
+	// create a new OrderService handler backed by the configured store
+	handler, relay := injector.NewOrderInjectorFromConfig(cfg, helper.NewClock())
 
 	// register the handler with the gRPC server
 	pb.RegisterOrderServiceServer(GRPServer, handler)
 
+	// serve the same handler over HTTP/JSON when a port is configured
+	if httpPort := cfg.HTTPPort; httpPort != "" {
+		gw := gateway.NewGateway(handler)
+
+		go func() {
+			fmt.Printf("⚡️[server]: HTTP gateway is running on port %s\n", httpPort)
+			if err := http.ListenAndServe(httpPort, gw); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	// run renewals in the background when an interval is configured
+	if interval := cfg.RenewInterval; interval > 0 {
+		go renew(handler, interval)
+	}
+
+	// publish the order events to the webhooks and the broker in the background
+	go relayOutbox(relay, cfg.RelayInterval)
+
+	// send the webhooks in the background when an interval is configured
+	if interval := cfg.WebhookInterval; interval > 0 {
+		go dispatchWebhooks(handler, interval)
+	}
+
 	// log that the server is ready
 	fmt.Printf("⚡️[server]: gRPC Server is running on port %s\n", port)
 
@@ -52,3 +79,57 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// renew creates renewal orders and runs dunning every interval.
+func renew(handler pb.OrderServiceServer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		res, err := handler.Renew(context.Background(), &pb.OrderRenewRequest{})
+		if err != nil {
+			log.Printf("renew: %v", err)
+			continue
+		}
+
+		if len(res.Events) > 0 || res.Failed > 0 {
+			log.Printf("renew: %d renewal order(s) created, %d event(s), %d failure(s)", res.Created, len(res.Events), res.Failed)
+		}
+	}
+}
+
+// relayOutbox publishes the outbox every interval.
+func relayOutbox(relay *usecase.OutboxRelay, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		published, failed, dead, err := relay.Relay(context.Background())
+		if err != nil {
+			log.Printf("outbox: %v", err)
+			continue
+		}
+
+		if failed > 0 || dead > 0 {
+			log.Printf("outbox: %d event(s) published, %d failed, %d dead-lettered", published, failed, dead)
+		}
+	}
+}
+
+// dispatchWebhooks sends the webhooks due every interval.
+func dispatchWebhooks(handler pb.OrderServiceServer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		res, err := handler.DispatchWebhooks(context.Background(), &pb.WebhookDispatchRequest{})
+		if err != nil {
+			log.Printf("webhooks: %v", err)
+			continue
+		}
+
+		if res.Retried > 0 || res.Failed > 0 {
+			log.Printf("webhooks: %d delivered, %d to retry, %d failed", res.Delivered, res.Retried, res.Failed)
+		}
+	}
+}
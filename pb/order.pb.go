@@ -25,16 +25,34 @@ type Order struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OrderId        string        `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Buyer          *OrderBuyer   `protobuf:"bytes,2,opt,name=buyer,proto3" json:"buyer,omitempty"`
-	Product        *OrderProduct `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
-	Payment        *OrderPayment `protobuf:"bytes,4,opt,name=payment,proto3" json:"payment,omitempty"`
-	Status         string        `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt      int64         `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt      int64         `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	SettlementTime int64         `protobuf:"varint,8,opt,name=settlement_time,json=settlementTime,proto3" json:"settlement_time,omitempty"`
-	TrxTime        int64         `protobuf:"varint,9,opt,name=trx_time,json=trxTime,proto3" json:"trx_time,omitempty"`
-	PayExp         int64         `protobuf:"varint,10,opt,name=pay_exp,json=payExp,proto3" json:"pay_exp,omitempty"`
+	OrderId        string            `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Buyer          *OrderBuyer       `protobuf:"bytes,2,opt,name=buyer,proto3" json:"buyer,omitempty"`
+	Product        *OrderProduct     `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
+	Payment        *OrderPayment     `protobuf:"bytes,4,opt,name=payment,proto3" json:"payment,omitempty"`
+	Status         string            `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt      int64             `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      int64             `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	SettlementTime int64             `protobuf:"varint,8,opt,name=settlement_time,json=settlementTime,proto3" json:"settlement_time,omitempty"`
+	TrxTime        int64             `protobuf:"varint,9,opt,name=trx_time,json=trxTime,proto3" json:"trx_time,omitempty"`
+	PayExp         int64             `protobuf:"varint,10,opt,name=pay_exp,json=payExp,proto3" json:"pay_exp,omitempty"`
+	StatusReason   string            `protobuf:"bytes,11,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
+	Version        int64             `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	Items          []*OrderItem      `protobuf:"bytes,13,rep,name=items,proto3" json:"items,omitempty"`
+	Total          int64             `protobuf:"varint,14,opt,name=total,proto3" json:"total,omitempty"`
+	Discounts      []*OrderDiscount  `protobuf:"bytes,15,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	Subtotal       int64             `protobuf:"varint,16,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Taxes          []*OrderTax       `protobuf:"bytes,17,rep,name=taxes,proto3" json:"taxes,omitempty"`
+	TaxInclusive   bool              `protobuf:"varint,18,opt,name=tax_inclusive,json=taxInclusive,proto3" json:"tax_inclusive,omitempty"`
+	TaxTotal       int64             `protobuf:"varint,19,opt,name=tax_total,json=taxTotal,proto3" json:"tax_total,omitempty"`
+	Currency       string            `protobuf:"bytes,20,opt,name=currency,proto3" json:"currency,omitempty"`
+	Refunds        []*OrderRefund    `protobuf:"bytes,21,rep,name=refunds,proto3" json:"refunds,omitempty"`
+	RefundedTotal  int64             `protobuf:"varint,22,opt,name=refunded_total,json=refundedTotal,proto3" json:"refunded_total,omitempty"`
+	PlanChange     *OrderPlanChange  `protobuf:"bytes,23,opt,name=plan_change,json=planChange,proto3" json:"plan_change,omitempty"`
+	MerchantId     string            `protobuf:"bytes,24,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Attempts       []*PaymentAttempt `protobuf:"bytes,25,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	Receipts       []*OrderReceipt   `protobuf:"bytes,26,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	PaidTotal      int64             `protobuf:"varint,27,opt,name=paid_total,json=paidTotal,proto3" json:"paid_total,omitempty"`
+	Overpayment    *OrderOverpayment `protobuf:"bytes,28,opt,name=overpayment,proto3" json:"overpayment,omitempty"`
 }
 
 func (x *Order) Reset() {
@@ -139,20 +157,146 @@ func (x *Order) GetPayExp() int64 {
 	return 0
 }
 
-type OrderProduct struct {
+func (x *Order) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *Order) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Order) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Order) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Order) GetDiscounts() []*OrderDiscount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
+
+func (x *Order) GetSubtotal() int64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *Order) GetTaxes() []*OrderTax {
+	if x != nil {
+		return x.Taxes
+	}
+	return nil
+}
+
+func (x *Order) GetTaxInclusive() bool {
+	if x != nil {
+		return x.TaxInclusive
+	}
+	return false
+}
+
+func (x *Order) GetTaxTotal() int64 {
+	if x != nil {
+		return x.TaxTotal
+	}
+	return 0
+}
+
+func (x *Order) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Order) GetRefunds() []*OrderRefund {
+	if x != nil {
+		return x.Refunds
+	}
+	return nil
+}
+
+func (x *Order) GetRefundedTotal() int64 {
+	if x != nil {
+		return x.RefundedTotal
+	}
+	return 0
+}
+
+func (x *Order) GetPlanChange() *OrderPlanChange {
+	if x != nil {
+		return x.PlanChange
+	}
+	return nil
+}
+
+func (x *Order) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *Order) GetAttempts() []*PaymentAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+func (x *Order) GetReceipts() []*OrderReceipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+func (x *Order) GetPaidTotal() int64 {
+	if x != nil {
+		return x.PaidTotal
+	}
+	return 0
+}
+
+func (x *Order) GetOverpayment() *OrderOverpayment {
+	if x != nil {
+		return x.Overpayment
+	}
+	return nil
+}
+
+type OrderItem struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ProductId   string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Price       int64  `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
-	Duration    int64  `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
-	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Product   *OrderProduct `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Quantity  int64         `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice int64         `protobuf:"varint,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	LineTotal int64         `protobuf:"varint,4,opt,name=line_total,json=lineTotal,proto3" json:"line_total,omitempty"`
+	Discount  int64         `protobuf:"varint,5,opt,name=discount,proto3" json:"discount,omitempty"`
 }
 
-func (x *OrderProduct) Reset() {
-	*x = OrderProduct{}
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -160,13 +304,13 @@ func (x *OrderProduct) Reset() {
 	}
 }
 
-func (x *OrderProduct) String() string {
+func (x *OrderItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderProduct) ProtoMessage() {}
+func (*OrderItem) ProtoMessage() {}
 
-func (x *OrderProduct) ProtoReflect() protoreflect.Message {
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -178,58 +322,58 @@ func (x *OrderProduct) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderProduct.ProtoReflect.Descriptor instead.
-func (*OrderProduct) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *OrderProduct) GetProductId() string {
+func (x *OrderItem) GetProduct() *OrderProduct {
 	if x != nil {
-		return x.ProductId
+		return x.Product
 	}
-	return ""
+	return nil
 }
 
-func (x *OrderProduct) GetName() string {
+func (x *OrderItem) GetQuantity() int64 {
 	if x != nil {
-		return x.Name
+		return x.Quantity
 	}
-	return ""
+	return 0
 }
 
-func (x *OrderProduct) GetPrice() int64 {
+func (x *OrderItem) GetUnitPrice() int64 {
 	if x != nil {
-		return x.Price
+		return x.UnitPrice
 	}
 	return 0
 }
 
-func (x *OrderProduct) GetDuration() int64 {
+func (x *OrderItem) GetLineTotal() int64 {
 	if x != nil {
-		return x.Duration
+		return x.LineTotal
 	}
 	return 0
 }
 
-func (x *OrderProduct) GetDescription() string {
+func (x *OrderItem) GetDiscount() int64 {
 	if x != nil {
-		return x.Description
+		return x.Discount
 	}
-	return ""
+	return 0
 }
 
-type OrderBuyer struct {
+type OrderDiscount struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CustomerId string `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	User       string `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Code        string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Amount      int64  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
 }
 
-func (x *OrderBuyer) Reset() {
-	*x = OrderBuyer{}
+func (x *OrderDiscount) Reset() {
+	*x = OrderDiscount{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -237,13 +381,13 @@ func (x *OrderBuyer) Reset() {
 	}
 }
 
-func (x *OrderBuyer) String() string {
+func (x *OrderDiscount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderBuyer) ProtoMessage() {}
+func (*OrderDiscount) ProtoMessage() {}
 
-func (x *OrderBuyer) ProtoReflect() protoreflect.Message {
+func (x *OrderDiscount) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -255,46 +399,45 @@ func (x *OrderBuyer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderBuyer.ProtoReflect.Descriptor instead.
-func (*OrderBuyer) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderDiscount.ProtoReflect.Descriptor instead.
+func (*OrderDiscount) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *OrderBuyer) GetCustomerId() string {
+func (x *OrderDiscount) GetCode() string {
 	if x != nil {
-		return x.CustomerId
+		return x.Code
 	}
 	return ""
 }
 
-func (x *OrderBuyer) GetName() string {
+func (x *OrderDiscount) GetDescription() string {
 	if x != nil {
-		return x.Name
+		return x.Description
 	}
 	return ""
 }
 
-func (x *OrderBuyer) GetUser() string {
+func (x *OrderDiscount) GetAmount() int64 {
 	if x != nil {
-		return x.User
+		return x.Amount
 	}
-	return ""
+	return 0
 }
 
-type OrderPayment struct {
+type OrderTax struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PaymentType string `protobuf:"bytes,1,opt,name=payment_type,json=paymentType,proto3" json:"payment_type,omitempty"`
-	OrderId     string `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Bank        string `protobuf:"bytes,3,opt,name=bank,proto3" json:"bank,omitempty"`
-	VaNumber    string `protobuf:"bytes,4,opt,name=va_number,json=vaNumber,proto3" json:"va_number,omitempty"`
-	GrossAmount int64  `protobuf:"varint,5,opt,name=gross_amount,json=grossAmount,proto3" json:"gross_amount,omitempty"`
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rate    int64  `protobuf:"varint,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	Taxable int64  `protobuf:"varint,3,opt,name=taxable,proto3" json:"taxable,omitempty"`
+	Amount  int64  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
 }
 
-func (x *OrderPayment) Reset() {
-	*x = OrderPayment{}
+func (x *OrderTax) Reset() {
+	*x = OrderTax{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -302,13 +445,13 @@ func (x *OrderPayment) Reset() {
 	}
 }
 
-func (x *OrderPayment) String() string {
+func (x *OrderTax) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderPayment) ProtoMessage() {}
+func (*OrderTax) ProtoMessage() {}
 
-func (x *OrderPayment) ProtoReflect() protoreflect.Message {
+func (x *OrderTax) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -320,60 +463,54 @@ func (x *OrderPayment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderPayment.ProtoReflect.Descriptor instead.
-func (*OrderPayment) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderTax.ProtoReflect.Descriptor instead.
+func (*OrderTax) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *OrderPayment) GetPaymentType() string {
-	if x != nil {
-		return x.PaymentType
-	}
-	return ""
-}
-
-func (x *OrderPayment) GetOrderId() string {
+func (x *OrderTax) GetName() string {
 	if x != nil {
-		return x.OrderId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *OrderPayment) GetBank() string {
+func (x *OrderTax) GetRate() int64 {
 	if x != nil {
-		return x.Bank
+		return x.Rate
 	}
-	return ""
+	return 0
 }
 
-func (x *OrderPayment) GetVaNumber() string {
+func (x *OrderTax) GetTaxable() int64 {
 	if x != nil {
-		return x.VaNumber
+		return x.Taxable
 	}
-	return ""
+	return 0
 }
 
-func (x *OrderPayment) GetGrossAmount() int64 {
+func (x *OrderTax) GetAmount() int64 {
 	if x != nil {
-		return x.GrossAmount
+		return x.Amount
 	}
 	return 0
 }
 
-type OrderCreateRequest struct {
+type OrderRefund struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Buyer   *OrderBuyer   `protobuf:"bytes,1,opt,name=buyer,proto3" json:"buyer,omitempty"`
-	Product *OrderProduct `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
-	Payment *OrderPayment `protobuf:"bytes,3,opt,name=payment,proto3" json:"payment,omitempty"`
-	TrxTime int64         `protobuf:"varint,4,opt,name=trx_time,json=trxTime,proto3" json:"trx_time,omitempty"`
-	PayExp  int64         `protobuf:"varint,5,opt,name=pay_exp,json=payExp,proto3" json:"pay_exp,omitempty"`
+	RefundId  string `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	Amount    int64  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason    string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor     string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reference string `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	CreatedAt int64  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 }
 
-func (x *OrderCreateRequest) Reset() {
-	*x = OrderCreateRequest{}
+func (x *OrderRefund) Reset() {
+	*x = OrderRefund{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -381,13 +518,13 @@ func (x *OrderCreateRequest) Reset() {
 	}
 }
 
-func (x *OrderCreateRequest) String() string {
+func (x *OrderRefund) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderCreateRequest) ProtoMessage() {}
+func (*OrderRefund) ProtoMessage() {}
 
-func (x *OrderCreateRequest) ProtoReflect() protoreflect.Message {
+func (x *OrderRefund) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -399,58 +536,65 @@ func (x *OrderCreateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderCreateRequest.ProtoReflect.Descriptor instead.
-func (*OrderCreateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderRefund.ProtoReflect.Descriptor instead.
+func (*OrderRefund) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *OrderCreateRequest) GetBuyer() *OrderBuyer {
+func (x *OrderRefund) GetRefundId() string {
 	if x != nil {
-		return x.Buyer
+		return x.RefundId
 	}
-	return nil
+	return ""
 }
 
-func (x *OrderCreateRequest) GetProduct() *OrderProduct {
+func (x *OrderRefund) GetAmount() int64 {
 	if x != nil {
-		return x.Product
+		return x.Amount
 	}
-	return nil
+	return 0
 }
 
-func (x *OrderCreateRequest) GetPayment() *OrderPayment {
+func (x *OrderRefund) GetReason() string {
 	if x != nil {
-		return x.Payment
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-func (x *OrderCreateRequest) GetTrxTime() int64 {
+func (x *OrderRefund) GetActor() string {
 	if x != nil {
-		return x.TrxTime
+		return x.Actor
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderCreateRequest) GetPayExp() int64 {
+func (x *OrderRefund) GetReference() string {
 	if x != nil {
-		return x.PayExp
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *OrderRefund) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return 0
 }
 
-type OrderChangeStatus struct {
+type OrderPlanChange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OrderId        string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status         string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	SettlementTime int64  `protobuf:"varint,3,opt,name=settlement_time,json=settlementTime,proto3" json:"settlement_time,omitempty"`
+	FromProductId string `protobuf:"bytes,1,opt,name=from_product_id,json=fromProductId,proto3" json:"from_product_id,omitempty"`
+	UnusedValue   int64  `protobuf:"varint,2,opt,name=unused_value,json=unusedValue,proto3" json:"unused_value,omitempty"`
+	Credit        int64  `protobuf:"varint,3,opt,name=credit,proto3" json:"credit,omitempty"`
 }
 
-func (x *OrderChangeStatus) Reset() {
-	*x = OrderChangeStatus{}
+func (x *OrderPlanChange) Reset() {
+	*x = OrderPlanChange{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -458,13 +602,13 @@ func (x *OrderChangeStatus) Reset() {
 	}
 }
 
-func (x *OrderChangeStatus) String() string {
+func (x *OrderPlanChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderChangeStatus) ProtoMessage() {}
+func (*OrderPlanChange) ProtoMessage() {}
 
-func (x *OrderChangeStatus) ProtoReflect() protoreflect.Message {
+func (x *OrderPlanChange) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -476,42 +620,47 @@ func (x *OrderChangeStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderChangeStatus.ProtoReflect.Descriptor instead.
-func (*OrderChangeStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderPlanChange.ProtoReflect.Descriptor instead.
+func (*OrderPlanChange) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *OrderChangeStatus) GetOrderId() string {
+func (x *OrderPlanChange) GetFromProductId() string {
 	if x != nil {
-		return x.OrderId
+		return x.FromProductId
 	}
 	return ""
 }
 
-func (x *OrderChangeStatus) GetStatus() string {
+func (x *OrderPlanChange) GetUnusedValue() int64 {
 	if x != nil {
-		return x.Status
+		return x.UnusedValue
 	}
-	return ""
+	return 0
 }
 
-func (x *OrderChangeStatus) GetSettlementTime() int64 {
+func (x *OrderPlanChange) GetCredit() int64 {
 	if x != nil {
-		return x.SettlementTime
+		return x.Credit
 	}
 	return 0
 }
 
-type OrderFindOneRequest struct {
+type OrderProduct struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId   string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Price       int64  `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	Duration    int64  `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Category    string `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
 }
 
-func (x *OrderFindOneRequest) Reset() {
-	*x = OrderFindOneRequest{}
+func (x *OrderProduct) Reset() {
+	*x = OrderProduct{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -519,13 +668,13 @@ func (x *OrderFindOneRequest) Reset() {
 	}
 }
 
-func (x *OrderFindOneRequest) String() string {
+func (x *OrderProduct) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderFindOneRequest) ProtoMessage() {}
+func (*OrderProduct) ProtoMessage() {}
 
-func (x *OrderFindOneRequest) ProtoReflect() protoreflect.Message {
+func (x *OrderProduct) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -537,34 +686,69 @@ func (x *OrderFindOneRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderFindOneRequest.ProtoReflect.Descriptor instead.
-func (*OrderFindOneRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderProduct.ProtoReflect.Descriptor instead.
+func (*OrderProduct) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *OrderFindOneRequest) GetOrderId() string {
+func (x *OrderProduct) GetProductId() string {
 	if x != nil {
-		return x.OrderId
+		return x.ProductId
 	}
 	return ""
 }
 
-type OrderFindAllRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *OrderProduct) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
 
-	Sort      string `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
-	Page      int64  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PerPage   int64  `protobuf:"varint,3,opt,name=perPage,proto3" json:"perPage,omitempty"`
-	Search    string `protobuf:"bytes,4,opt,name=search,proto3" json:"search,omitempty"`
-	Status    string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	UserId    string `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CountOnly bool   `protobuf:"varint,7,opt,name=count_only,json=countOnly,proto3" json:"count_only,omitempty"`
+func (x *OrderProduct) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
 }
 
-func (x *OrderFindAllRequest) Reset() {
-	*x = OrderFindAllRequest{}
+func (x *OrderProduct) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *OrderProduct) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *OrderProduct) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type OrderBuyer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CustomerId   string `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Name         string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	User         string `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Jurisdiction string `protobuf:"bytes,4,opt,name=jurisdiction,proto3" json:"jurisdiction,omitempty"`
+	Email        string `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Phone        string `protobuf:"bytes,6,opt,name=phone,proto3" json:"phone,omitempty"`
+	Locale       string `protobuf:"bytes,7,opt,name=locale,proto3" json:"locale,omitempty"`
+}
+
+func (x *OrderBuyer) Reset() {
+	*x = OrderBuyer{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -572,13 +756,13 @@ func (x *OrderFindAllRequest) Reset() {
 	}
 }
 
-func (x *OrderFindAllRequest) String() string {
+func (x *OrderBuyer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderFindAllRequest) ProtoMessage() {}
+func (*OrderBuyer) ProtoMessage() {}
 
-func (x *OrderFindAllRequest) ProtoReflect() protoreflect.Message {
+func (x *OrderBuyer) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -590,75 +774,75 @@ func (x *OrderFindAllRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderFindAllRequest.ProtoReflect.Descriptor instead.
-func (*OrderFindAllRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderBuyer.ProtoReflect.Descriptor instead.
+func (*OrderBuyer) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *OrderFindAllRequest) GetSort() string {
+func (x *OrderBuyer) GetCustomerId() string {
 	if x != nil {
-		return x.Sort
+		return x.CustomerId
 	}
 	return ""
 }
 
-func (x *OrderFindAllRequest) GetPage() int64 {
+func (x *OrderBuyer) GetName() string {
 	if x != nil {
-		return x.Page
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderFindAllRequest) GetPerPage() int64 {
+func (x *OrderBuyer) GetUser() string {
 	if x != nil {
-		return x.PerPage
+		return x.User
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderFindAllRequest) GetSearch() string {
+func (x *OrderBuyer) GetJurisdiction() string {
 	if x != nil {
-		return x.Search
+		return x.Jurisdiction
 	}
 	return ""
 }
 
-func (x *OrderFindAllRequest) GetStatus() string {
+func (x *OrderBuyer) GetEmail() string {
 	if x != nil {
-		return x.Status
+		return x.Email
 	}
 	return ""
 }
 
-func (x *OrderFindAllRequest) GetUserId() string {
+func (x *OrderBuyer) GetPhone() string {
 	if x != nil {
-		return x.UserId
+		return x.Phone
 	}
 	return ""
 }
 
-func (x *OrderFindAllRequest) GetCountOnly() bool {
+func (x *OrderBuyer) GetLocale() string {
 	if x != nil {
-		return x.CountOnly
+		return x.Locale
 	}
-	return false
+	return ""
 }
 
-type OrderFindAllPayload struct {
+type OrderPayment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Orders     []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	Rows       int64    `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
-	Pages      int64    `protobuf:"varint,3,opt,name=pages,proto3" json:"pages,omitempty"`
-	PerPage    int64    `protobuf:"varint,4,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
-	ActivePage int64    `protobuf:"varint,5,opt,name=active_page,json=activePage,proto3" json:"active_page,omitempty"`
-	Total      int64    `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	PaymentType string `protobuf:"bytes,1,opt,name=payment_type,json=paymentType,proto3" json:"payment_type,omitempty"`
+	OrderId     string `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Bank        string `protobuf:"bytes,3,opt,name=bank,proto3" json:"bank,omitempty"`
+	VaNumber    string `protobuf:"bytes,4,opt,name=va_number,json=vaNumber,proto3" json:"va_number,omitempty"`
+	GrossAmount int64  `protobuf:"varint,5,opt,name=gross_amount,json=grossAmount,proto3" json:"gross_amount,omitempty"`
+	QrString    string `protobuf:"bytes,6,opt,name=qr_string,json=qrString,proto3" json:"qr_string,omitempty"`
 }
 
-func (x *OrderFindAllPayload) Reset() {
-	*x = OrderFindAllPayload{}
+func (x *OrderPayment) Reset() {
+	*x = OrderPayment{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -666,13 +850,13 @@ func (x *OrderFindAllPayload) Reset() {
 	}
 }
 
-func (x *OrderFindAllPayload) String() string {
+func (x *OrderPayment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderFindAllPayload) ProtoMessage() {}
+func (*OrderPayment) ProtoMessage() {}
 
-func (x *OrderFindAllPayload) ProtoReflect() protoreflect.Message {
+func (x *OrderPayment) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -684,64 +868,72 @@ func (x *OrderFindAllPayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderFindAllPayload.ProtoReflect.Descriptor instead.
-func (*OrderFindAllPayload) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderPayment.ProtoReflect.Descriptor instead.
+func (*OrderPayment) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *OrderFindAllPayload) GetOrders() []*Order {
+func (x *OrderPayment) GetPaymentType() string {
 	if x != nil {
-		return x.Orders
+		return x.PaymentType
 	}
-	return nil
+	return ""
 }
 
-func (x *OrderFindAllPayload) GetRows() int64 {
+func (x *OrderPayment) GetOrderId() string {
 	if x != nil {
-		return x.Rows
+		return x.OrderId
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderFindAllPayload) GetPages() int64 {
+func (x *OrderPayment) GetBank() string {
 	if x != nil {
-		return x.Pages
+		return x.Bank
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderFindAllPayload) GetPerPage() int64 {
+func (x *OrderPayment) GetVaNumber() string {
 	if x != nil {
-		return x.PerPage
+		return x.VaNumber
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderFindAllPayload) GetActivePage() int64 {
+func (x *OrderPayment) GetGrossAmount() int64 {
 	if x != nil {
-		return x.ActivePage
+		return x.GrossAmount
 	}
 	return 0
 }
 
-func (x *OrderFindAllPayload) GetTotal() int64 {
+func (x *OrderPayment) GetQrString() string {
 	if x != nil {
-		return x.Total
+		return x.QrString
 	}
-	return 0
+	return ""
 }
 
-type OrderFindAllResponse struct {
+type OrderCreateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IsEmpty bool                 `protobuf:"varint,1,opt,name=is_empty,json=isEmpty,proto3" json:"is_empty,omitempty"`
-	Payload *OrderFindAllPayload `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Buyer      *OrderBuyer   `protobuf:"bytes,1,opt,name=buyer,proto3" json:"buyer,omitempty"`
+	Product    *OrderProduct `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	Payment    *OrderPayment `protobuf:"bytes,3,opt,name=payment,proto3" json:"payment,omitempty"`
+	TrxTime    int64         `protobuf:"varint,4,opt,name=trx_time,json=trxTime,proto3" json:"trx_time,omitempty"`
+	PayExp     int64         `protobuf:"varint,5,opt,name=pay_exp,json=payExp,proto3" json:"pay_exp,omitempty"`
+	Items      []*OrderItem  `protobuf:"bytes,6,rep,name=items,proto3" json:"items,omitempty"`
+	CouponCode string        `protobuf:"bytes,7,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`
+	Currency   string        `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	QuoteToken string        `protobuf:"bytes,9,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"`
+	MerchantId string        `protobuf:"bytes,10,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
 }
 
-func (x *OrderFindAllResponse) Reset() {
-	*x = OrderFindAllResponse{}
+func (x *OrderCreateRequest) Reset() {
+	*x = OrderCreateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -749,13 +941,13 @@ func (x *OrderFindAllResponse) Reset() {
 	}
 }
 
-func (x *OrderFindAllResponse) String() string {
+func (x *OrderCreateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderFindAllResponse) ProtoMessage() {}
+func (*OrderCreateRequest) ProtoMessage() {}
 
-func (x *OrderFindAllResponse) ProtoReflect() protoreflect.Message {
+func (x *OrderCreateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -767,35 +959,93 @@ func (x *OrderFindAllResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderFindAllResponse.ProtoReflect.Descriptor instead.
-func (*OrderFindAllResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderCreateRequest.ProtoReflect.Descriptor instead.
+func (*OrderCreateRequest) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *OrderFindAllResponse) GetIsEmpty() bool {
+func (x *OrderCreateRequest) GetBuyer() *OrderBuyer {
 	if x != nil {
-		return x.IsEmpty
+		return x.Buyer
 	}
-	return false
+	return nil
 }
 
-func (x *OrderFindAllResponse) GetPayload() *OrderFindAllPayload {
+func (x *OrderCreateRequest) GetProduct() *OrderProduct {
 	if x != nil {
-		return x.Payload
+		return x.Product
 	}
 	return nil
 }
 
-type OrderSumIncomeRequest struct {
+func (x *OrderCreateRequest) GetPayment() *OrderPayment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+func (x *OrderCreateRequest) GetTrxTime() int64 {
+	if x != nil {
+		return x.TrxTime
+	}
+	return 0
+}
+
+func (x *OrderCreateRequest) GetPayExp() int64 {
+	if x != nil {
+		return x.PayExp
+	}
+	return 0
+}
+
+func (x *OrderCreateRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderCreateRequest) GetCouponCode() string {
+	if x != nil {
+		return x.CouponCode
+	}
+	return ""
+}
+
+func (x *OrderCreateRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OrderCreateRequest) GetQuoteToken() string {
+	if x != nil {
+		return x.QuoteToken
+	}
+	return ""
+}
+
+func (x *OrderCreateRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+type OrderQuote struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Token     string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt int64  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Payload   *Order `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
 }
 
-func (x *OrderSumIncomeRequest) Reset() {
-	*x = OrderSumIncomeRequest{}
+func (x *OrderQuote) Reset() {
+	*x = OrderQuote{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -803,13 +1053,13 @@ func (x *OrderSumIncomeRequest) Reset() {
 	}
 }
 
-func (x *OrderSumIncomeRequest) String() string {
+func (x *OrderQuote) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderSumIncomeRequest) ProtoMessage() {}
+func (*OrderQuote) ProtoMessage() {}
 
-func (x *OrderSumIncomeRequest) ProtoReflect() protoreflect.Message {
+func (x *OrderQuote) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -821,28 +1071,49 @@ func (x *OrderSumIncomeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderSumIncomeRequest.ProtoReflect.Descriptor instead.
-func (*OrderSumIncomeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderQuote.ProtoReflect.Descriptor instead.
+func (*OrderQuote) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *OrderSumIncomeRequest) GetStatus() string {
+func (x *OrderQuote) GetToken() string {
 	if x != nil {
-		return x.Status
+		return x.Token
 	}
 	return ""
 }
 
-type OrderSumPayload struct {
+func (x *OrderQuote) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *OrderQuote) GetPayload() *Order {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type OrderChangeStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Total int64 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	OrderId         string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status          string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	SettlementTime  int64  `protobuf:"varint,3,opt,name=settlement_time,json=settlementTime,proto3" json:"settlement_time,omitempty"`
+	Reason          string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpectedVersion int64  `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	GrossAmount     int64  `protobuf:"varint,6,opt,name=gross_amount,json=grossAmount,proto3" json:"gross_amount,omitempty"`
+	TransactionId   string `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Fee             int64  `protobuf:"varint,8,opt,name=fee,proto3" json:"fee,omitempty"`
 }
 
-func (x *OrderSumPayload) Reset() {
-	*x = OrderSumPayload{}
+func (x *OrderChangeStatus) Reset() {
+	*x = OrderChangeStatus{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -850,13 +1121,13 @@ func (x *OrderSumPayload) Reset() {
 	}
 }
 
-func (x *OrderSumPayload) String() string {
+func (x *OrderChangeStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderSumPayload) ProtoMessage() {}
+func (*OrderChangeStatus) ProtoMessage() {}
 
-func (x *OrderSumPayload) ProtoReflect() protoreflect.Message {
+func (x *OrderChangeStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -868,29 +1139,77 @@ func (x *OrderSumPayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderSumPayload.ProtoReflect.Descriptor instead.
-func (*OrderSumPayload) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderChangeStatus.ProtoReflect.Descriptor instead.
+func (*OrderChangeStatus) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *OrderSumPayload) GetTotal() int64 {
+func (x *OrderChangeStatus) GetOrderId() string {
 	if x != nil {
-		return x.Total
+		return x.OrderId
 	}
-	return 0
+	return ""
 }
 
-type OrderSumResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+func (x *OrderChangeStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderChangeStatus) GetSettlementTime() int64 {
+	if x != nil {
+		return x.SettlementTime
+	}
+	return 0
+}
+
+func (x *OrderChangeStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderChangeStatus) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *OrderChangeStatus) GetGrossAmount() int64 {
+	if x != nil {
+		return x.GrossAmount
+	}
+	return 0
+}
+
+func (x *OrderChangeStatus) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *OrderChangeStatus) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+type OrderFindOneRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IsEmpty bool             `protobuf:"varint,1,opt,name=is_empty,json=isEmpty,proto3" json:"is_empty,omitempty"`
-	Payload *OrderSumPayload `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 }
 
-func (x *OrderSumResponse) Reset() {
-	*x = OrderSumResponse{}
+func (x *OrderFindOneRequest) Reset() {
+	*x = OrderFindOneRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_order_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -898,13 +1217,13 @@ func (x *OrderSumResponse) Reset() {
 	}
 }
 
-func (x *OrderSumResponse) String() string {
+func (x *OrderFindOneRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderSumResponse) ProtoMessage() {}
+func (*OrderFindOneRequest) ProtoMessage() {}
 
-func (x *OrderSumResponse) ProtoReflect() protoreflect.Message {
+func (x *OrderFindOneRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_order_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -916,106 +1235,1375 @@ func (x *OrderSumResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderSumResponse.ProtoReflect.Descriptor instead.
-func (*OrderSumResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use OrderFindOneRequest.ProtoReflect.Descriptor instead.
+func (*OrderFindOneRequest) Descriptor() ([]byte, []int) {
 	return file_pb_order_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *OrderSumResponse) GetIsEmpty() bool {
+func (x *OrderFindOneRequest) GetOrderId() string {
 	if x != nil {
-		return x.IsEmpty
+		return x.OrderId
+	}
+	return ""
+}
+
+type OrderFindAllRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sort      string `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	Page      int64  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage   int64  `protobuf:"varint,3,opt,name=perPage,proto3" json:"perPage,omitempty"`
+	Search    string `protobuf:"bytes,4,opt,name=search,proto3" json:"search,omitempty"`
+	Status    string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	UserId    string `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CountOnly bool   `protobuf:"varint,7,opt,name=count_only,json=countOnly,proto3" json:"count_only,omitempty"`
+	ProductId string `protobuf:"bytes,8,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+}
+
+func (x *OrderFindAllRequest) Reset() {
+	*x = OrderFindAllRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderFindAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFindAllRequest) ProtoMessage() {}
+
+func (x *OrderFindAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFindAllRequest.ProtoReflect.Descriptor instead.
+func (*OrderFindAllRequest) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *OrderFindAllRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *OrderFindAllRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *OrderFindAllRequest) GetPerPage() int64 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *OrderFindAllRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *OrderFindAllRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderFindAllRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrderFindAllRequest) GetCountOnly() bool {
+	if x != nil {
+		return x.CountOnly
 	}
 	return false
 }
 
-func (x *OrderSumResponse) GetPayload() *OrderSumPayload {
+func (x *OrderFindAllRequest) GetProductId() string {
 	if x != nil {
-		return x.Payload
+		return x.ProductId
 	}
-	return nil
+	return ""
 }
 
-type OrderFindOneResponse struct {
+type OrderFindAllPayload struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IsEmpty bool   `protobuf:"varint,1,opt,name=is_empty,json=isEmpty,proto3" json:"is_empty,omitempty"`
-	Payload *Order `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Orders     []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Rows       int64    `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
+	Pages      int64    `protobuf:"varint,3,opt,name=pages,proto3" json:"pages,omitempty"`
+	PerPage    int64    `protobuf:"varint,4,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	ActivePage int64    `protobuf:"varint,5,opt,name=active_page,json=activePage,proto3" json:"active_page,omitempty"`
+	Total      int64    `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
 }
 
-func (x *OrderFindOneResponse) Reset() {
-	*x = OrderFindOneResponse{}
+func (x *OrderFindAllPayload) Reset() {
+	*x = OrderFindAllPayload{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pb_order_proto_msgTypes[13]
+		mi := &file_pb_order_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OrderFindOneResponse) String() string {
+func (x *OrderFindAllPayload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderFindOneResponse) ProtoMessage() {}
-
-func (x *OrderFindOneResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_order_proto_msgTypes[13]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (*OrderFindAllPayload) ProtoMessage() {}
+
+func (x *OrderFindAllPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFindAllPayload.ProtoReflect.Descriptor instead.
+func (*OrderFindAllPayload) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OrderFindAllPayload) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *OrderFindAllPayload) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *OrderFindAllPayload) GetPages() int64 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *OrderFindAllPayload) GetPerPage() int64 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *OrderFindAllPayload) GetActivePage() int64 {
+	if x != nil {
+		return x.ActivePage
+	}
+	return 0
+}
+
+func (x *OrderFindAllPayload) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type OrderFindAllResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsEmpty bool                 `protobuf:"varint,1,opt,name=is_empty,json=isEmpty,proto3" json:"is_empty,omitempty"`
+	Payload *OrderFindAllPayload `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *OrderFindAllResponse) Reset() {
+	*x = OrderFindAllResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderFindAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFindAllResponse) ProtoMessage() {}
+
+func (x *OrderFindAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFindAllResponse.ProtoReflect.Descriptor instead.
+func (*OrderFindAllResponse) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OrderFindAllResponse) GetIsEmpty() bool {
+	if x != nil {
+		return x.IsEmpty
+	}
+	return false
+}
+
+func (x *OrderFindAllResponse) GetPayload() *OrderFindAllPayload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type OrderSumIncomeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	From     int64  `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To       int64  `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	UserId   string `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *OrderSumIncomeRequest) Reset() {
+	*x = OrderSumIncomeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderSumIncomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderSumIncomeRequest) ProtoMessage() {}
+
+func (x *OrderSumIncomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderSumIncomeRequest.ProtoReflect.Descriptor instead.
+func (*OrderSumIncomeRequest) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OrderSumIncomeRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderSumIncomeRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OrderSumIncomeRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *OrderSumIncomeRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *OrderSumIncomeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type OrderSumPayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total  int64    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Totals []*Money `protobuf:"bytes,2,rep,name=totals,proto3" json:"totals,omitempty"`
+}
+
+func (x *OrderSumPayload) Reset() {
+	*x = OrderSumPayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderSumPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderSumPayload) ProtoMessage() {}
+
+func (x *OrderSumPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderSumPayload.ProtoReflect.Descriptor instead.
+func (*OrderSumPayload) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *OrderSumPayload) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *OrderSumPayload) GetTotals() []*Money {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+type OrderSumResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsEmpty bool             `protobuf:"varint,1,opt,name=is_empty,json=isEmpty,proto3" json:"is_empty,omitempty"`
+	Payload *OrderSumPayload `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *OrderSumResponse) Reset() {
+	*x = OrderSumResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderSumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderSumResponse) ProtoMessage() {}
+
+func (x *OrderSumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderSumResponse.ProtoReflect.Descriptor instead.
+func (*OrderSumResponse) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OrderSumResponse) GetIsEmpty() bool {
+	if x != nil {
+		return x.IsEmpty
+	}
+	return false
+}
+
+func (x *OrderSumResponse) GetPayload() *OrderSumPayload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type OrderFindOneResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsEmpty bool   `protobuf:"varint,1,opt,name=is_empty,json=isEmpty,proto3" json:"is_empty,omitempty"`
+	Payload *Order `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *OrderFindOneResponse) Reset() {
+	*x = OrderFindOneResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderFindOneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFindOneResponse) ProtoMessage() {}
+
+func (x *OrderFindOneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFindOneResponse.ProtoReflect.Descriptor instead.
+func (*OrderFindOneResponse) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OrderFindOneResponse) GetIsEmpty() bool {
+	if x != nil {
+		return x.IsEmpty
+	}
+	return false
+}
+
+func (x *OrderFindOneResponse) GetPayload() *Order {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type OrderCancelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId         string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId          string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpectedVersion int64  `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+}
+
+func (x *OrderCancelRequest) Reset() {
+	*x = OrderCancelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderCancelRequest) ProtoMessage() {}
+
+func (x *OrderCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderCancelRequest.ProtoReflect.Descriptor instead.
+func (*OrderCancelRequest) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *OrderCancelRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderCancelRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrderCancelRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type OrderExpireRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *OrderExpireRequest) Reset() {
+	*x = OrderExpireRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderExpireRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderExpireRequest) ProtoMessage() {}
+
+func (x *OrderExpireRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderExpireRequest.ProtoReflect.Descriptor instead.
+func (*OrderExpireRequest) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{21}
+}
+
+type OrderExpireResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Affected int64 `protobuf:"varint,1,opt,name=affected,proto3" json:"affected,omitempty"`
+}
+
+func (x *OrderExpireResponse) Reset() {
+	*x = OrderExpireResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderExpireResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderExpireResponse) ProtoMessage() {}
+
+func (x *OrderExpireResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderExpireResponse.ProtoReflect.Descriptor instead.
+func (*OrderExpireResponse) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *OrderExpireResponse) GetAffected() int64 {
+	if x != nil {
+		return x.Affected
+	}
+	return 0
+}
+
+type OrderTaxReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From   int64  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To     int64  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Period string `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+}
+
+func (x *OrderTaxReportRequest) Reset() {
+	*x = OrderTaxReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderTaxReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderTaxReportRequest) ProtoMessage() {}
+
+func (x *OrderTaxReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderTaxReportRequest.ProtoReflect.Descriptor instead.
+func (*OrderTaxReportRequest) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *OrderTaxReportRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *OrderTaxReportRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *OrderTaxReportRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+type OrderTaxReportRow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Period   string `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Rate     int64  `protobuf:"varint,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	Taxable  int64  `protobuf:"varint,4,opt,name=taxable,proto3" json:"taxable,omitempty"`
+	Amount   int64  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Orders   int64  `protobuf:"varint,6,opt,name=orders,proto3" json:"orders,omitempty"`
+	Currency string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	Refunds  bool   `protobuf:"varint,8,opt,name=refunds,proto3" json:"refunds,omitempty"`
+}
+
+func (x *OrderTaxReportRow) Reset() {
+	*x = OrderTaxReportRow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderTaxReportRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderTaxReportRow) ProtoMessage() {}
+
+func (x *OrderTaxReportRow) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderTaxReportRow.ProtoReflect.Descriptor instead.
+func (*OrderTaxReportRow) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *OrderTaxReportRow) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *OrderTaxReportRow) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrderTaxReportRow) GetRate() int64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *OrderTaxReportRow) GetTaxable() int64 {
+	if x != nil {
+		return x.Taxable
+	}
+	return 0
+}
+
+func (x *OrderTaxReportRow) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *OrderTaxReportRow) GetOrders() int64 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *OrderTaxReportRow) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OrderTaxReportRow) GetRefunds() bool {
+	if x != nil {
+		return x.Refunds
+	}
+	return false
+}
+
+type OrderTaxReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rows []*OrderTaxReportRow `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *OrderTaxReportResponse) Reset() {
+	*x = OrderTaxReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderTaxReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderTaxReportResponse) ProtoMessage() {}
+
+func (x *OrderTaxReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderTaxReportResponse.ProtoReflect.Descriptor instead.
+func (*OrderTaxReportResponse) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *OrderTaxReportResponse) GetRows() []*OrderTaxReportRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type OrderRefundRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId         string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Amount          int64  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason          string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor           string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reference       string `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	ExpectedVersion int64  `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+}
+
+func (x *OrderRefundRequest) Reset() {
+	*x = OrderRefundRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderRefundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderRefundRequest) ProtoMessage() {}
+
+func (x *OrderRefundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderRefundRequest.ProtoReflect.Descriptor instead.
+func (*OrderRefundRequest) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *OrderRefundRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderRefundRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *OrderRefundRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderRefundRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *OrderRefundRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *OrderRefundRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type OrderRefundResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Refund  *OrderRefund `protobuf:"bytes,1,opt,name=refund,proto3" json:"refund,omitempty"`
+	Payload *Order       `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *OrderRefundResponse) Reset() {
+	*x = OrderRefundResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderRefundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderRefundResponse) ProtoMessage() {}
+
+func (x *OrderRefundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderRefundResponse.ProtoReflect.Descriptor instead.
+func (*OrderRefundResponse) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *OrderRefundResponse) GetRefund() *OrderRefund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+func (x *OrderRefundResponse) GetPayload() *Order {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type OrderRenewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *OrderRenewRequest) Reset() {
+	*x = OrderRenewRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderRenewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderRenewRequest) ProtoMessage() {}
+
+func (x *OrderRenewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderRenewRequest.ProtoReflect.Descriptor instead.
+func (*OrderRenewRequest) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{28}
+}
+
+type OrderRenewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Created int64           `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Events  []*RenewalEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Failed  int64           `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+}
+
+func (x *OrderRenewResponse) Reset() {
+	*x = OrderRenewResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderRenewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderRenewResponse) ProtoMessage() {}
+
+func (x *OrderRenewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderRenewResponse.ProtoReflect.Descriptor instead.
+func (*OrderRenewResponse) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *OrderRenewResponse) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *OrderRenewResponse) GetEvents() []*RenewalEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *OrderRenewResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type PlanChangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CustomerId    string        `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	FromProductId string        `protobuf:"bytes,2,opt,name=from_product_id,json=fromProductId,proto3" json:"from_product_id,omitempty"`
+	ToProduct     *OrderProduct `protobuf:"bytes,3,opt,name=to_product,json=toProduct,proto3" json:"to_product,omitempty"`
+	Payment       *OrderPayment `protobuf:"bytes,4,opt,name=payment,proto3" json:"payment,omitempty"`
+	PayExp        int64         `protobuf:"varint,5,opt,name=pay_exp,json=payExp,proto3" json:"pay_exp,omitempty"`
+	QuoteToken    string        `protobuf:"bytes,6,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"`
+}
+
+func (x *PlanChangeRequest) Reset() {
+	*x = PlanChangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanChangeRequest) ProtoMessage() {}
+
+func (x *PlanChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanChangeRequest.ProtoReflect.Descriptor instead.
+func (*PlanChangeRequest) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PlanChangeRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *PlanChangeRequest) GetFromProductId() string {
+	if x != nil {
+		return x.FromProductId
+	}
+	return ""
+}
+
+func (x *PlanChangeRequest) GetToProduct() *OrderProduct {
+	if x != nil {
+		return x.ToProduct
+	}
+	return nil
+}
+
+func (x *PlanChangeRequest) GetPayment() *OrderPayment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+func (x *PlanChangeRequest) GetPayExp() int64 {
+	if x != nil {
+		return x.PayExp
+	}
+	return 0
+}
+
+func (x *PlanChangeRequest) GetQuoteToken() string {
+	if x != nil {
+		return x.QuoteToken
+	}
+	return ""
+}
+
+type PlanChangeQuote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CustomerId       string        `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	FromProductId    string        `protobuf:"bytes,2,opt,name=from_product_id,json=fromProductId,proto3" json:"from_product_id,omitempty"`
+	ToProduct        *OrderProduct `protobuf:"bytes,3,opt,name=to_product,json=toProduct,proto3" json:"to_product,omitempty"`
+	Currency         string        `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	RemainingSeconds int64         `protobuf:"varint,5,opt,name=remaining_seconds,json=remainingSeconds,proto3" json:"remaining_seconds,omitempty"`
+	UnusedValue      int64         `protobuf:"varint,6,opt,name=unused_value,json=unusedValue,proto3" json:"unused_value,omitempty"`
+	Price            int64         `protobuf:"varint,7,opt,name=price,proto3" json:"price,omitempty"`
+	Discount         int64         `protobuf:"varint,8,opt,name=discount,proto3" json:"discount,omitempty"`
+	TaxTotal         int64         `protobuf:"varint,9,opt,name=tax_total,json=taxTotal,proto3" json:"tax_total,omitempty"`
+	Total            int64         `protobuf:"varint,10,opt,name=total,proto3" json:"total,omitempty"`
+	Credit           int64         `protobuf:"varint,11,opt,name=credit,proto3" json:"credit,omitempty"`
+	QuotedAt         int64         `protobuf:"varint,12,opt,name=quoted_at,json=quotedAt,proto3" json:"quoted_at,omitempty"`
+	Token            string        `protobuf:"bytes,13,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt        int64         `protobuf:"varint,14,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *PlanChangeQuote) Reset() {
+	*x = PlanChangeQuote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_order_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanChangeQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanChangeQuote) ProtoMessage() {}
+
+func (x *PlanChangeQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanChangeQuote.ProtoReflect.Descriptor instead.
+func (*PlanChangeQuote) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PlanChangeQuote) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *PlanChangeQuote) GetFromProductId() string {
+	if x != nil {
+		return x.FromProductId
+	}
+	return ""
+}
+
+func (x *PlanChangeQuote) GetToProduct() *OrderProduct {
+	if x != nil {
+		return x.ToProduct
+	}
+	return nil
+}
+
+func (x *PlanChangeQuote) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PlanChangeQuote) GetRemainingSeconds() int64 {
+	if x != nil {
+		return x.RemainingSeconds
+	}
+	return 0
+}
+
+func (x *PlanChangeQuote) GetUnusedValue() int64 {
+	if x != nil {
+		return x.UnusedValue
+	}
+	return 0
+}
+
+func (x *PlanChangeQuote) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PlanChangeQuote) GetDiscount() int64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *PlanChangeQuote) GetTaxTotal() int64 {
+	if x != nil {
+		return x.TaxTotal
+	}
+	return 0
+}
+
+func (x *PlanChangeQuote) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PlanChangeQuote) GetCredit() int64 {
+	if x != nil {
+		return x.Credit
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use OrderFindOneResponse.ProtoReflect.Descriptor instead.
-func (*OrderFindOneResponse) Descriptor() ([]byte, []int) {
-	return file_pb_order_proto_rawDescGZIP(), []int{13}
+func (x *PlanChangeQuote) GetQuotedAt() int64 {
+	if x != nil {
+		return x.QuotedAt
+	}
+	return 0
 }
 
-func (x *OrderFindOneResponse) GetIsEmpty() bool {
+func (x *PlanChangeQuote) GetToken() string {
 	if x != nil {
-		return x.IsEmpty
+		return x.Token
 	}
-	return false
+	return ""
 }
 
-func (x *OrderFindOneResponse) GetPayload() *Order {
+func (x *PlanChangeQuote) GetExpiresAt() int64 {
 	if x != nil {
-		return x.Payload
+		return x.ExpiresAt
 	}
-	return nil
+	return 0
 }
 
-type OrderCancelRequest struct {
+type PlanChangeResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	UserId  string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Quote   *PlanChangeQuote `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	Payload *Order           `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
 }
 
-func (x *OrderCancelRequest) Reset() {
-	*x = OrderCancelRequest{}
+func (x *PlanChangeResponse) Reset() {
+	*x = PlanChangeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pb_order_proto_msgTypes[14]
+		mi := &file_pb_order_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OrderCancelRequest) String() string {
+func (x *PlanChangeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderCancelRequest) ProtoMessage() {}
+func (*PlanChangeResponse) ProtoMessage() {}
 
-func (x *OrderCancelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_order_proto_msgTypes[14]
+func (x *PlanChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_order_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1026,23 +2614,23 @@ func (x *OrderCancelRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderCancelRequest.ProtoReflect.Descriptor instead.
-func (*OrderCancelRequest) Descriptor() ([]byte, []int) {
-	return file_pb_order_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use PlanChangeResponse.ProtoReflect.Descriptor instead.
+func (*PlanChangeResponse) Descriptor() ([]byte, []int) {
+	return file_pb_order_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *OrderCancelRequest) GetOrderId() string {
+func (x *PlanChangeResponse) GetQuote() *PlanChangeQuote {
 	if x != nil {
-		return x.OrderId
+		return x.Quote
 	}
-	return ""
+	return nil
 }
 
-func (x *OrderCancelRequest) GetUserId() string {
+func (x *PlanChangeResponse) GetPayload() *Order {
 	if x != nil {
-		return x.UserId
+		return x.Payload
 	}
-	return ""
+	return nil
 }
 
 var File_pb_order_proto protoreflect.FileDescriptor
@@ -1050,7 +2638,19 @@ var File_pb_order_proto protoreflect.FileDescriptor
 var file_pb_order_proto_rawDesc = []byte{
 	0x0a, 0x0e, 0x70, 0x62, 0x2f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x1a, 0x11, 0x70, 0x62, 0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0xca, 0x02, 0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x19, 0x0a,
+	0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x70, 0x62, 0x2f, 0x63, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0e, 0x70, 0x62, 0x2f, 0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x70, 0x62, 0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x70, 0x62, 0x2f, 0x77,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x70, 0x62,
+	0x2f, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0d,
+	0x70, 0x62, 0x2f, 0x71, 0x72, 0x69, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x70,
+	0x62, 0x2f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x70, 0x62, 0x2f, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x70, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x70, 0x62, 0x2f, 0x72, 0x65,
+	0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x0f, 0x70, 0x62, 0x2f, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xd9, 0x07, 0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x19, 0x0a,
 	0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x05, 0x62, 0x75, 0x79, 0x65,
 	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42,
@@ -1071,108 +2671,345 @@ var file_pb_order_proto_rawDesc = []byte{
 	0x74, 0x72, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
 	0x74, 0x72, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x5f, 0x65,
 	0x78, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x61, 0x79, 0x45, 0x78, 0x70,
-	0x22, 0x95, 0x01, 0x0a, 0x0c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63,
-	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x55, 0x0a, 0x0a, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x42, 0x75, 0x79, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x75, 0x73,
-	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75,
-	0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x22,
-	0xa0, 0x01, 0x0a, 0x0c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
-	0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12,
-	0x0a, 0x04, 0x62, 0x61, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x61,
-	0x6e, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x61, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76, 0x61, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
-	0x21, 0x0a, 0x0c, 0x67, 0x72, 0x6f, 0x73, 0x73, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x67, 0x72, 0x6f, 0x73, 0x73, 0x41, 0x6d, 0x6f, 0x75,
-	0x6e, 0x74, 0x22, 0xbd, 0x01, 0x0a, 0x12, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x62, 0x75, 0x79,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x42, 0x75, 0x79, 0x65, 0x72, 0x52, 0x05, 0x62, 0x75, 0x79, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x07,
-	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x07, 0x70, 0x72,
-	0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x27, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x61,
-	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19,
-	0x0a, 0x08, 0x74, 0x72, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x07, 0x74, 0x72, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x61, 0x79,
-	0x5f, 0x65, 0x78, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x61, 0x79, 0x45,
-	0x78, 0x70, 0x22, 0x6f, 0x0a, 0x11, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x20, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2c, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x09, 0x64, 0x69, 0x73, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x75, 0x62, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x12, 0x1f, 0x0a, 0x05, 0x74, 0x61, 0x78, 0x65, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x09, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x61, 0x78, 0x52, 0x05, 0x74, 0x61, 0x78,
+	0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73,
+	0x69, 0x76, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x74, 0x61, 0x78, 0x49, 0x6e,
+	0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x78, 0x5f, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x13, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x61, 0x78, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x12, 0x26, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52,
+	0x07, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x66, 0x75,
+	0x6e, 0x64, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12,
+	0x31, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x17,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x6e,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x6e, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18,
+	0x19, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x41,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x12, 0x29, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x73, 0x18, 0x1a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70,
+	0x74, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x61, 0x69, 0x64, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x70, 0x61, 0x69, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x33, 0x0a, 0x0b, 0x6f, 0x76,
+	0x65, 0x72, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x4f, 0x76, 0x65, 0x72, 0x70, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x0b, 0x6f, 0x76, 0x65, 0x72, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x22,
+	0xaa, 0x01, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x27, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x07, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x6e, 0x69, 0x74, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x69, 0x6e, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x5d, 0x0a, 0x0d,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x64, 0x0a, 0x08, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x54, 0x61, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x61, 0x78, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x74, 0x61, 0x78, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0xad, 0x01, 0x0a, 0x0b, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x22, 0x74, 0x0a, 0x0f, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x6e, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66,
+	0x72, 0x6f, 0x6d, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c,
+	0x75, 0x6e, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x75, 0x6e, 0x75, 0x73, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x22, 0xb1, 0x01, 0x0a, 0x0c, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x22, 0xbd, 0x01, 0x0a, 0x0a,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x75, 0x79, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x6a, 0x75, 0x72, 0x69, 0x73, 0x64, 0x69, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6a, 0x75, 0x72, 0x69, 0x73,
+	0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68,
+	0x6f, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x22, 0xbd, 0x01, 0x0a, 0x0c,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61,
+	0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x61, 0x6e, 0x6b, 0x12, 0x1b,
+	0x0a, 0x09, 0x76, 0x61, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x76, 0x61, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x67,
+	0x72, 0x6f, 0x73, 0x73, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x67, 0x72, 0x6f, 0x73, 0x73, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x71, 0x72, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x71, 0x72, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x22, 0xde, 0x02, 0x0a, 0x12,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x62, 0x75, 0x79, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x75, 0x79, 0x65, 0x72, 0x52, 0x05,
+	0x62, 0x75, 0x79, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x27,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07,
+	0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x72, 0x78, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x72, 0x78, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x5f, 0x65, 0x78, 0x70, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x61, 0x79, 0x45, 0x78, 0x70, 0x12, 0x20, 0x0a, 0x05, 0x69,
+	0x74, 0x65, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x63, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75,
+	0x6f, 0x74, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
+	0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x63, 0x0a, 0x0a,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12,
+	0x20, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x06, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x22, 0x8e, 0x02, 0x0a, 0x11, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67,
 	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72,
 	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65,
 	0x74, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20,
 	0x01, 0x28, 0x03, 0x52, 0x0e, 0x73, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x54,
-	0x69, 0x6d, 0x65, 0x22, 0x30, 0x0a, 0x13, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x46, 0x69, 0x6e, 0x64,
-	0x4f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72,
-	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72,
-	0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0xbf, 0x01, 0x0a, 0x13, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x46,
-	0x69, 0x6e, 0x64, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0xb1, 0x01, 0x0a, 0x13, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x46, 0x69, 0x6e, 0x64, 0x41, 0x6c, 0x6c, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12,
-	0x1e, 0x0a, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x06, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12,
-	0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x72,
-	0x6f, 0x77, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x65, 0x72,
-	0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x65, 0x72,
-	0x50, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70,
-	0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76,
-	0x65, 0x50, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x61, 0x0a, 0x14, 0x4f,
-	0x72, 0x64, 0x65, 0x72, 0x46, 0x69, 0x6e, 0x64, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x72, 0x6f, 0x73, 0x73, 0x5f,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x67, 0x72,
+	0x6f, 0x73, 0x73, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x66,
+	0x65, 0x65, 0x22, 0x30, 0x0a, 0x13, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x46, 0x69, 0x6e, 0x64, 0x4f,
+	0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0xde, 0x01, 0x0a, 0x13, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x46, 0x69,
+	0x6e, 0x64, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x65, 0x72, 0x50, 0x61, 0x67, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x49, 0x64, 0x22, 0xb1, 0x01, 0x0a, 0x13, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x46,
+	0x69, 0x6e, 0x64, 0x41, 0x6c, 0x6c, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1e, 0x0a,
+	0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x72, 0x6f, 0x77,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x65, 0x72, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x65, 0x72, 0x50, 0x61,
+	0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x61, 0x67,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x50,
+	0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x61, 0x0a, 0x14, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x46, 0x69, 0x6e, 0x64, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x07,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x46, 0x69, 0x6e, 0x64, 0x41, 0x6c, 0x6c, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x88, 0x01, 0x0a,
+	0x15, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e,
+	0x0a, 0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x47, 0x0a, 0x0f, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x53, 0x75, 0x6d, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x1e, 0x0a, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x06, 0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73,
+	0x22, 0x59, 0x0a, 0x10, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x2a, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x50, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x53, 0x0a, 0x14, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x46, 0x69, 0x6e, 0x64, 0x4f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
 	0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2e,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x20,
 	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x46, 0x69, 0x6e, 0x64, 0x41, 0x6c, 0x6c, 0x50, 0x61,
-	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x2f,
-	0x0a, 0x15, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x49, 0x6e, 0x63, 0x6f, 0x6d, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
-	0x27, 0x0a, 0x0f, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x50, 0x61, 0x79, 0x6c, 0x6f,
-	0x61, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x59, 0x0a, 0x10, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x53, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08,
-	0x69, 0x73, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
-	0x69, 0x73, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f,
-	0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
-	0x53, 0x75, 0x6d, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c,
-	0x6f, 0x61, 0x64, 0x22, 0x53, 0x0a, 0x14, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x46, 0x69, 0x6e, 0x64,
-	0x4f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69,
-	0x73, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69,
-	0x73, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x20, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
-	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x48, 0x0a, 0x12, 0x4f, 0x72, 0x64, 0x65,
-	0x72, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19,
-	0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
-	0x49, 0x64, 0x32, 0xd4, 0x02, 0x0a, 0x0c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x13, 0x2e,
-	0x4f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x06, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0c,
+	0x06, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x22, 0x73, 0x0a, 0x12, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x14, 0x0a, 0x12, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x45, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x13, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x66, 0x66, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x66, 0x66, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0x53,
+	0x0a, 0x15, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x61, 0x78, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x22, 0xd3, 0x01, 0x0a, 0x11, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x61, 0x78,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x61, 0x78,
+	0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x61, 0x78, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x22, 0x40, 0x0a, 0x16, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x54, 0x61, 0x78, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x61, 0x78, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x22, 0xbe, 0x01, 0x0a, 0x12,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5d, 0x0a, 0x13,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x52, 0x06, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x6d, 0x0a, 0x12, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x25, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x22,
+	0xed, 0x01, 0x0a, 0x11, 0x50, 0x6c, 0x61, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x66, 0x72, 0x6f, 0x6d, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x2c,
+	0x0a, 0x0a, 0x74, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x52, 0x09, 0x74, 0x6f, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x27, 0x0a, 0x07,
+	0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x70, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x5f, 0x65, 0x78, 0x70,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x61, 0x79, 0x45, 0x78, 0x70, 0x12, 0x1f,
+	0x0a, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
+	0xc3, 0x03, 0x0a, 0x0f, 0x50, 0x6c, 0x61, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66,
+	0x72, 0x6f, 0x6d, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x0a,
+	0x74, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52,
+	0x09, 0x74, 0x6f, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e,
+	0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x10, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x6e, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x75, 0x6e, 0x75, 0x73, 0x65,
+	0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x78, 0x5f,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x61, 0x78,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x72, 0x65, 0x64, 0x69, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x63, 0x72, 0x65,
+	0x64, 0x69, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x5f, 0x61, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x5e, 0x0a, 0x12, 0x50, 0x6c, 0x61, 0x6e, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x71,
+	0x75, 0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x50, 0x6c, 0x61,
+	0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x71, 0x75,
+	0x6f, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x32, 0xc1, 0x0e, 0x0a, 0x0c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x12, 0x13, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x06, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x00, 0x12,
+	0x2b, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x13, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0c,
 	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x2e, 0x4f,
 	0x72, 0x64, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
 	0x1a, 0x12, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
@@ -1190,6 +3027,98 @@ var file_pb_order_proto_rawDesc = []byte{
 	0x73, 0x65, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x13,
 	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x06, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x12, 0x13, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x45, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x2d, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e,
+	0x12, 0x07, 0x2e, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x1a, 0x12, 0x2e, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x3d, 0x0a, 0x0a, 0x46, 0x69, 0x6e, 0x64, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x12, 0x15, 0x2e,
+	0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x46, 0x69, 0x6e, 0x64, 0x4f, 0x6e, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x43, 0x6f, 0x75, 0x70, 0x6f, 0x6e, 0x46, 0x69, 0x6e,
+	0x64, 0x4f, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e,
+	0x0a, 0x09, 0x54, 0x61, 0x78, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x2e, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x54, 0x61, 0x78, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x61, 0x78, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x35,
+	0x0a, 0x06, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x12, 0x13, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x10, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x45, 0x6e,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x47, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x05, 0x52, 0x65, 0x6e,
+	0x65, 0x77, 0x12, 0x12, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65,
+	0x6e, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a,
+	0x0c, 0x53, 0x65, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x12, 0x1c, 0x2e,
+	0x45, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x52,
+	0x65, 0x6e, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x39, 0x0a, 0x0f, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x12, 0x2e, 0x50, 0x6c, 0x61,
+	0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x14, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x14, 0x2e, 0x57,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x15, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x57,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x15, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x73, 0x12, 0x13, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x54, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x15, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x57,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x12, 0x15,
+	0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x10, 0x44, 0x69, 0x73,
+	0x70, 0x61, 0x74, 0x63, 0x68, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x12, 0x17, 0x2e,
+	0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x44, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x29, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x12, 0x0f, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x08, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x22, 0x00, 0x12, 0x2f, 0x0a,
+	0x0c, 0x47, 0x65, 0x74, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x51, 0x52, 0x12, 0x11, 0x2e,
+	0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x51, 0x52, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0a, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x51, 0x52, 0x22, 0x00, 0x12, 0x2e,
+	0x0a, 0x0c, 0x52, 0x65, 0x74, 0x72, 0x79, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14,
+	0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x06, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x00, 0x12, 0x37,
+	0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x12, 0x11, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4c, 0x65,
+	0x64, 0x67, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x15, 0x2e, 0x4c,
+	0x65, 0x64, 0x67, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a,
+	0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x12, 0x13, 0x2e, 0x4c,
+	0x65, 0x64, 0x67, 0x65, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70,
 	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
@@ -1206,54 +3135,177 @@ func file_pb_order_proto_rawDescGZIP() []byte {
 	return file_pb_order_proto_rawDescData
 }
 
-var file_pb_order_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_pb_order_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_pb_order_proto_goTypes = []interface{}{
-	(*Order)(nil),                 // 0: Order
-	(*OrderProduct)(nil),          // 1: OrderProduct
-	(*OrderBuyer)(nil),            // 2: OrderBuyer
-	(*OrderPayment)(nil),          // 3: OrderPayment
-	(*OrderCreateRequest)(nil),    // 4: OrderCreateRequest
-	(*OrderChangeStatus)(nil),     // 5: OrderChangeStatus
-	(*OrderFindOneRequest)(nil),   // 6: OrderFindOneRequest
-	(*OrderFindAllRequest)(nil),   // 7: OrderFindAllRequest
-	(*OrderFindAllPayload)(nil),   // 8: OrderFindAllPayload
-	(*OrderFindAllResponse)(nil),  // 9: OrderFindAllResponse
-	(*OrderSumIncomeRequest)(nil), // 10: OrderSumIncomeRequest
-	(*OrderSumPayload)(nil),       // 11: OrderSumPayload
-	(*OrderSumResponse)(nil),      // 12: OrderSumResponse
-	(*OrderFindOneResponse)(nil),  // 13: OrderFindOneResponse
-	(*OrderCancelRequest)(nil),    // 14: OrderCancelRequest
-	(*Empty)(nil),                 // 15: Empty
-	(*OperationResponse)(nil),     // 16: OperationResponse
+	(*Order)(nil),                       // 0: Order
+	(*OrderItem)(nil),                   // 1: OrderItem
+	(*OrderDiscount)(nil),               // 2: OrderDiscount
+	(*OrderTax)(nil),                    // 3: OrderTax
+	(*OrderRefund)(nil),                 // 4: OrderRefund
+	(*OrderPlanChange)(nil),             // 5: OrderPlanChange
+	(*OrderProduct)(nil),                // 6: OrderProduct
+	(*OrderBuyer)(nil),                  // 7: OrderBuyer
+	(*OrderPayment)(nil),                // 8: OrderPayment
+	(*OrderCreateRequest)(nil),          // 9: OrderCreateRequest
+	(*OrderQuote)(nil),                  // 10: OrderQuote
+	(*OrderChangeStatus)(nil),           // 11: OrderChangeStatus
+	(*OrderFindOneRequest)(nil),         // 12: OrderFindOneRequest
+	(*OrderFindAllRequest)(nil),         // 13: OrderFindAllRequest
+	(*OrderFindAllPayload)(nil),         // 14: OrderFindAllPayload
+	(*OrderFindAllResponse)(nil),        // 15: OrderFindAllResponse
+	(*OrderSumIncomeRequest)(nil),       // 16: OrderSumIncomeRequest
+	(*OrderSumPayload)(nil),             // 17: OrderSumPayload
+	(*OrderSumResponse)(nil),            // 18: OrderSumResponse
+	(*OrderFindOneResponse)(nil),        // 19: OrderFindOneResponse
+	(*OrderCancelRequest)(nil),          // 20: OrderCancelRequest
+	(*OrderExpireRequest)(nil),          // 21: OrderExpireRequest
+	(*OrderExpireResponse)(nil),         // 22: OrderExpireResponse
+	(*OrderTaxReportRequest)(nil),       // 23: OrderTaxReportRequest
+	(*OrderTaxReportRow)(nil),           // 24: OrderTaxReportRow
+	(*OrderTaxReportResponse)(nil),      // 25: OrderTaxReportResponse
+	(*OrderRefundRequest)(nil),          // 26: OrderRefundRequest
+	(*OrderRefundResponse)(nil),         // 27: OrderRefundResponse
+	(*OrderRenewRequest)(nil),           // 28: OrderRenewRequest
+	(*OrderRenewResponse)(nil),          // 29: OrderRenewResponse
+	(*PlanChangeRequest)(nil),           // 30: PlanChangeRequest
+	(*PlanChangeQuote)(nil),             // 31: PlanChangeQuote
+	(*PlanChangeResponse)(nil),          // 32: PlanChangeResponse
+	(*PaymentAttempt)(nil),              // 33: PaymentAttempt
+	(*OrderReceipt)(nil),                // 34: OrderReceipt
+	(*OrderOverpayment)(nil),            // 35: OrderOverpayment
+	(*Money)(nil),                       // 36: Money
+	(*RenewalEvent)(nil),                // 37: RenewalEvent
+	(*Coupon)(nil),                      // 38: Coupon
+	(*CouponFindOneRequest)(nil),        // 39: CouponFindOneRequest
+	(*EntitlementCheckRequest)(nil),     // 40: EntitlementCheckRequest
+	(*EntitlementListRequest)(nil),      // 41: EntitlementListRequest
+	(*EntitlementAutoRenewRequest)(nil), // 42: EntitlementAutoRenewRequest
+	(*WebhookSubscription)(nil),         // 43: WebhookSubscription
+	(*WebhookUpdateRequest)(nil),        // 44: WebhookUpdateRequest
+	(*WebhookDeleteRequest)(nil),        // 45: WebhookDeleteRequest
+	(*WebhookListRequest)(nil),          // 46: WebhookListRequest
+	(*WebhookDeliveryListRequest)(nil),  // 47: WebhookDeliveryListRequest
+	(*WebhookReplayRequest)(nil),        // 48: WebhookReplayRequest
+	(*WebhookDispatchRequest)(nil),      // 49: WebhookDispatchRequest
+	(*InvoiceRequest)(nil),              // 50: InvoiceRequest
+	(*PaymentQRRequest)(nil),            // 51: PaymentQRRequest
+	(*RetryPaymentRequest)(nil),         // 52: RetryPaymentRequest
+	(*ReconcileRequest)(nil),            // 53: ReconcileRequest
+	(*LedgerBalanceRequest)(nil),        // 54: LedgerBalanceRequest
+	(*LedgerCheckRequest)(nil),          // 55: LedgerCheckRequest
+	(*OperationResponse)(nil),           // 56: OperationResponse
+	(*CouponFindOneResponse)(nil),       // 57: CouponFindOneResponse
+	(*EntitlementCheckResponse)(nil),    // 58: EntitlementCheckResponse
+	(*EntitlementListResponse)(nil),     // 59: EntitlementListResponse
+	(*WebhookListResponse)(nil),         // 60: WebhookListResponse
+	(*WebhookDeliveryListResponse)(nil), // 61: WebhookDeliveryListResponse
+	(*WebhookDelivery)(nil),             // 62: WebhookDelivery
+	(*WebhookDispatchResponse)(nil),     // 63: WebhookDispatchResponse
+	(*Invoice)(nil),                     // 64: Invoice
+	(*PaymentQR)(nil),                   // 65: PaymentQR
+	(*ReconciliationReport)(nil),        // 66: ReconciliationReport
+	(*LedgerBalanceResponse)(nil),       // 67: LedgerBalanceResponse
+	(*LedgerCheckResponse)(nil),         // 68: LedgerCheckResponse
 }
 var file_pb_order_proto_depIdxs = []int32{
-	2,  // 0: Order.buyer:type_name -> OrderBuyer
-	1,  // 1: Order.product:type_name -> OrderProduct
-	3,  // 2: Order.payment:type_name -> OrderPayment
-	2,  // 3: OrderCreateRequest.buyer:type_name -> OrderBuyer
-	1,  // 4: OrderCreateRequest.product:type_name -> OrderProduct
-	3,  // 5: OrderCreateRequest.payment:type_name -> OrderPayment
-	0,  // 6: OrderFindAllPayload.orders:type_name -> Order
-	8,  // 7: OrderFindAllResponse.payload:type_name -> OrderFindAllPayload
-	11, // 8: OrderSumResponse.payload:type_name -> OrderSumPayload
-	0,  // 9: OrderFindOneResponse.payload:type_name -> Order
-	4,  // 10: OrderService.Create:input_type -> OrderCreateRequest
-	5,  // 11: OrderService.ChangeStatus:input_type -> OrderChangeStatus
-	6,  // 12: OrderService.FindOne:input_type -> OrderFindOneRequest
-	7,  // 13: OrderService.FindAll:input_type -> OrderFindAllRequest
-	10, // 14: OrderService.SumIncome:input_type -> OrderSumIncomeRequest
-	14, // 15: OrderService.Cancel:input_type -> OrderCancelRequest
-	15, // 16: OrderService.Create:output_type -> Empty
-	16, // 17: OrderService.ChangeStatus:output_type -> OperationResponse
-	13, // 18: OrderService.FindOne:output_type -> OrderFindOneResponse
-	9,  // 19: OrderService.FindAll:output_type -> OrderFindAllResponse
-	12, // 20: OrderService.SumIncome:output_type -> OrderSumResponse
-	16, // 21: OrderService.Cancel:output_type -> OperationResponse
-	16, // [16:22] is the sub-list for method output_type
-	10, // [10:16] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	7,  // 0: Order.buyer:type_name -> OrderBuyer
+	6,  // 1: Order.product:type_name -> OrderProduct
+	8,  // 2: Order.payment:type_name -> OrderPayment
+	1,  // 3: Order.items:type_name -> OrderItem
+	2,  // 4: Order.discounts:type_name -> OrderDiscount
+	3,  // 5: Order.taxes:type_name -> OrderTax
+	4,  // 6: Order.refunds:type_name -> OrderRefund
+	5,  // 7: Order.plan_change:type_name -> OrderPlanChange
+	33, // 8: Order.attempts:type_name -> PaymentAttempt
+	34, // 9: Order.receipts:type_name -> OrderReceipt
+	35, // 10: Order.overpayment:type_name -> OrderOverpayment
+	6,  // 11: OrderItem.product:type_name -> OrderProduct
+	7,  // 12: OrderCreateRequest.buyer:type_name -> OrderBuyer
+	6,  // 13: OrderCreateRequest.product:type_name -> OrderProduct
+	8,  // 14: OrderCreateRequest.payment:type_name -> OrderPayment
+	1,  // 15: OrderCreateRequest.items:type_name -> OrderItem
+	0,  // 16: OrderQuote.payload:type_name -> Order
+	0,  // 17: OrderFindAllPayload.orders:type_name -> Order
+	14, // 18: OrderFindAllResponse.payload:type_name -> OrderFindAllPayload
+	36, // 19: OrderSumPayload.totals:type_name -> Money
+	17, // 20: OrderSumResponse.payload:type_name -> OrderSumPayload
+	0,  // 21: OrderFindOneResponse.payload:type_name -> Order
+	24, // 22: OrderTaxReportResponse.rows:type_name -> OrderTaxReportRow
+	4,  // 23: OrderRefundResponse.refund:type_name -> OrderRefund
+	0,  // 24: OrderRefundResponse.payload:type_name -> Order
+	37, // 25: OrderRenewResponse.events:type_name -> RenewalEvent
+	6,  // 26: PlanChangeRequest.to_product:type_name -> OrderProduct
+	8,  // 27: PlanChangeRequest.payment:type_name -> OrderPayment
+	6,  // 28: PlanChangeQuote.to_product:type_name -> OrderProduct
+	31, // 29: PlanChangeResponse.quote:type_name -> PlanChangeQuote
+	0,  // 30: PlanChangeResponse.payload:type_name -> Order
+	9,  // 31: OrderService.Create:input_type -> OrderCreateRequest
+	9,  // 32: OrderService.Quote:input_type -> OrderCreateRequest
+	11, // 33: OrderService.ChangeStatus:input_type -> OrderChangeStatus
+	12, // 34: OrderService.FindOne:input_type -> OrderFindOneRequest
+	13, // 35: OrderService.FindAll:input_type -> OrderFindAllRequest
+	16, // 36: OrderService.SumIncome:input_type -> OrderSumIncomeRequest
+	20, // 37: OrderService.Cancel:input_type -> OrderCancelRequest
+	21, // 38: OrderService.Expire:input_type -> OrderExpireRequest
+	38, // 39: OrderService.CreateCoupon:input_type -> Coupon
+	39, // 40: OrderService.FindCoupon:input_type -> CouponFindOneRequest
+	23, // 41: OrderService.TaxReport:input_type -> OrderTaxReportRequest
+	26, // 42: OrderService.Refund:input_type -> OrderRefundRequest
+	40, // 43: OrderService.CheckEntitlement:input_type -> EntitlementCheckRequest
+	41, // 44: OrderService.ListEntitlements:input_type -> EntitlementListRequest
+	28, // 45: OrderService.Renew:input_type -> OrderRenewRequest
+	42, // 46: OrderService.SetAutoRenew:input_type -> EntitlementAutoRenewRequest
+	30, // 47: OrderService.QuotePlanChange:input_type -> PlanChangeRequest
+	30, // 48: OrderService.ChangePlan:input_type -> PlanChangeRequest
+	43, // 49: OrderService.CreateWebhook:input_type -> WebhookSubscription
+	44, // 50: OrderService.UpdateWebhook:input_type -> WebhookUpdateRequest
+	45, // 51: OrderService.DeleteWebhook:input_type -> WebhookDeleteRequest
+	46, // 52: OrderService.ListWebhooks:input_type -> WebhookListRequest
+	47, // 53: OrderService.ListWebhookDeliveries:input_type -> WebhookDeliveryListRequest
+	48, // 54: OrderService.ReplayWebhookDelivery:input_type -> WebhookReplayRequest
+	49, // 55: OrderService.DispatchWebhooks:input_type -> WebhookDispatchRequest
+	50, // 56: OrderService.GetInvoice:input_type -> InvoiceRequest
+	51, // 57: OrderService.GetPaymentQR:input_type -> PaymentQRRequest
+	52, // 58: OrderService.RetryPayment:input_type -> RetryPaymentRequest
+	53, // 59: OrderService.Reconcile:input_type -> ReconcileRequest
+	54, // 60: OrderService.GetLedgerBalances:input_type -> LedgerBalanceRequest
+	55, // 61: OrderService.CheckLedger:input_type -> LedgerCheckRequest
+	0,  // 62: OrderService.Create:output_type -> Order
+	10, // 63: OrderService.Quote:output_type -> OrderQuote
+	56, // 64: OrderService.ChangeStatus:output_type -> OperationResponse
+	19, // 65: OrderService.FindOne:output_type -> OrderFindOneResponse
+	15, // 66: OrderService.FindAll:output_type -> OrderFindAllResponse
+	18, // 67: OrderService.SumIncome:output_type -> OrderSumResponse
+	56, // 68: OrderService.Cancel:output_type -> OperationResponse
+	22, // 69: OrderService.Expire:output_type -> OrderExpireResponse
+	56, // 70: OrderService.CreateCoupon:output_type -> OperationResponse
+	57, // 71: OrderService.FindCoupon:output_type -> CouponFindOneResponse
+	25, // 72: OrderService.TaxReport:output_type -> OrderTaxReportResponse
+	27, // 73: OrderService.Refund:output_type -> OrderRefundResponse
+	58, // 74: OrderService.CheckEntitlement:output_type -> EntitlementCheckResponse
+	59, // 75: OrderService.ListEntitlements:output_type -> EntitlementListResponse
+	29, // 76: OrderService.Renew:output_type -> OrderRenewResponse
+	56, // 77: OrderService.SetAutoRenew:output_type -> OperationResponse
+	31, // 78: OrderService.QuotePlanChange:output_type -> PlanChangeQuote
+	32, // 79: OrderService.ChangePlan:output_type -> PlanChangeResponse
+	43, // 80: OrderService.CreateWebhook:output_type -> WebhookSubscription
+	43, // 81: OrderService.UpdateWebhook:output_type -> WebhookSubscription
+	56, // 82: OrderService.DeleteWebhook:output_type -> OperationResponse
+	60, // 83: OrderService.ListWebhooks:output_type -> WebhookListResponse
+	61, // 84: OrderService.ListWebhookDeliveries:output_type -> WebhookDeliveryListResponse
+	62, // 85: OrderService.ReplayWebhookDelivery:output_type -> WebhookDelivery
+	63, // 86: OrderService.DispatchWebhooks:output_type -> WebhookDispatchResponse
+	64, // 87: OrderService.GetInvoice:output_type -> Invoice
+	65, // 88: OrderService.GetPaymentQR:output_type -> PaymentQR
+	0,  // 89: OrderService.RetryPayment:output_type -> Order
+	66, // 90: OrderService.Reconcile:output_type -> ReconciliationReport
+	67, // 91: OrderService.GetLedgerBalances:output_type -> LedgerBalanceResponse
+	68, // 92: OrderService.CheckLedger:output_type -> LedgerCheckResponse
+	62, // [62:93] is the sub-list for method output_type
+	31, // [31:62] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_pb_order_proto_init() }
@@ -1262,6 +3314,16 @@ func file_pb_order_proto_init() {
 		return
 	}
 	file_pb_response_proto_init()
+	file_pb_coupon_proto_init()
+	file_pb_money_proto_init()
+	file_pb_entitlement_proto_init()
+	file_pb_webhook_proto_init()
+	file_pb_invoice_proto_init()
+	file_pb_qris_proto_init()
+	file_pb_payment_attempt_proto_init()
+	file_pb_receipt_proto_init()
+	file_pb_reconciliation_proto_init()
+	file_pb_ledger_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_pb_order_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Order); i {
@@ -1276,7 +3338,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderProduct); i {
+			switch v := v.(*OrderItem); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1288,7 +3350,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderBuyer); i {
+			switch v := v.(*OrderDiscount); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1300,7 +3362,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderPayment); i {
+			switch v := v.(*OrderTax); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1312,7 +3374,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderCreateRequest); i {
+			switch v := v.(*OrderRefund); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1324,7 +3386,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderChangeStatus); i {
+			switch v := v.(*OrderPlanChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1336,7 +3398,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderFindOneRequest); i {
+			switch v := v.(*OrderProduct); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1348,7 +3410,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderFindAllRequest); i {
+			switch v := v.(*OrderBuyer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1360,7 +3422,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderFindAllPayload); i {
+			switch v := v.(*OrderPayment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1372,7 +3434,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderFindAllResponse); i {
+			switch v := v.(*OrderCreateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1384,7 +3446,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderSumIncomeRequest); i {
+			switch v := v.(*OrderQuote); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1396,7 +3458,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderSumPayload); i {
+			switch v := v.(*OrderChangeStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1408,7 +3470,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderSumResponse); i {
+			switch v := v.(*OrderFindOneRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1420,7 +3482,7 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OrderFindOneResponse); i {
+			switch v := v.(*OrderFindAllRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1432,6 +3494,78 @@ func file_pb_order_proto_init() {
 			}
 		}
 		file_pb_order_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderFindAllPayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderFindAllResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderSumIncomeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderSumPayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderSumResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderFindOneResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OrderCancelRequest); i {
 			case 0:
 				return &v.state
@@ -1443,6 +3577,150 @@ func file_pb_order_proto_init() {
 				return nil
 			}
 		}
+		file_pb_order_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderExpireRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderExpireResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderTaxReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderTaxReportRow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderTaxReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderRefundRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderRefundResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderRenewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OrderRenewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanChangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanChangeQuote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_order_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanChangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1450,7 +3728,7 @@ func file_pb_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pb_order_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,12 +22,37 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OrderServiceClient interface {
-	Create(ctx context.Context, in *OrderCreateRequest, opts ...grpc.CallOption) (*Empty, error)
+	Create(ctx context.Context, in *OrderCreateRequest, opts ...grpc.CallOption) (*Order, error)
+	Quote(ctx context.Context, in *OrderCreateRequest, opts ...grpc.CallOption) (*OrderQuote, error)
 	ChangeStatus(ctx context.Context, in *OrderChangeStatus, opts ...grpc.CallOption) (*OperationResponse, error)
 	FindOne(ctx context.Context, in *OrderFindOneRequest, opts ...grpc.CallOption) (*OrderFindOneResponse, error)
 	FindAll(ctx context.Context, in *OrderFindAllRequest, opts ...grpc.CallOption) (*OrderFindAllResponse, error)
 	SumIncome(ctx context.Context, in *OrderSumIncomeRequest, opts ...grpc.CallOption) (*OrderSumResponse, error)
 	Cancel(ctx context.Context, in *OrderCancelRequest, opts ...grpc.CallOption) (*OperationResponse, error)
+	Expire(ctx context.Context, in *OrderExpireRequest, opts ...grpc.CallOption) (*OrderExpireResponse, error)
+	CreateCoupon(ctx context.Context, in *Coupon, opts ...grpc.CallOption) (*OperationResponse, error)
+	FindCoupon(ctx context.Context, in *CouponFindOneRequest, opts ...grpc.CallOption) (*CouponFindOneResponse, error)
+	TaxReport(ctx context.Context, in *OrderTaxReportRequest, opts ...grpc.CallOption) (*OrderTaxReportResponse, error)
+	Refund(ctx context.Context, in *OrderRefundRequest, opts ...grpc.CallOption) (*OrderRefundResponse, error)
+	CheckEntitlement(ctx context.Context, in *EntitlementCheckRequest, opts ...grpc.CallOption) (*EntitlementCheckResponse, error)
+	ListEntitlements(ctx context.Context, in *EntitlementListRequest, opts ...grpc.CallOption) (*EntitlementListResponse, error)
+	Renew(ctx context.Context, in *OrderRenewRequest, opts ...grpc.CallOption) (*OrderRenewResponse, error)
+	SetAutoRenew(ctx context.Context, in *EntitlementAutoRenewRequest, opts ...grpc.CallOption) (*OperationResponse, error)
+	QuotePlanChange(ctx context.Context, in *PlanChangeRequest, opts ...grpc.CallOption) (*PlanChangeQuote, error)
+	ChangePlan(ctx context.Context, in *PlanChangeRequest, opts ...grpc.CallOption) (*PlanChangeResponse, error)
+	CreateWebhook(ctx context.Context, in *WebhookSubscription, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, in *WebhookUpdateRequest, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, in *WebhookDeleteRequest, opts ...grpc.CallOption) (*OperationResponse, error)
+	ListWebhooks(ctx context.Context, in *WebhookListRequest, opts ...grpc.CallOption) (*WebhookListResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *WebhookDeliveryListRequest, opts ...grpc.CallOption) (*WebhookDeliveryListResponse, error)
+	ReplayWebhookDelivery(ctx context.Context, in *WebhookReplayRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
+	DispatchWebhooks(ctx context.Context, in *WebhookDispatchRequest, opts ...grpc.CallOption) (*WebhookDispatchResponse, error)
+	GetInvoice(ctx context.Context, in *InvoiceRequest, opts ...grpc.CallOption) (*Invoice, error)
+	GetPaymentQR(ctx context.Context, in *PaymentQRRequest, opts ...grpc.CallOption) (*PaymentQR, error)
+	RetryPayment(ctx context.Context, in *RetryPaymentRequest, opts ...grpc.CallOption) (*Order, error)
+	Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconciliationReport, error)
+	GetLedgerBalances(ctx context.Context, in *LedgerBalanceRequest, opts ...grpc.CallOption) (*LedgerBalanceResponse, error)
+	CheckLedger(ctx context.Context, in *LedgerCheckRequest, opts ...grpc.CallOption) (*LedgerCheckResponse, error)
 }
 
 type orderServiceClient struct {
@@ -38,8 +63,8 @@ func NewOrderServiceClient(cc grpc.ClientConnInterface) OrderServiceClient {
 	return &orderServiceClient{cc}
 }
 
-func (c *orderServiceClient) Create(ctx context.Context, in *OrderCreateRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
+func (c *orderServiceClient) Create(ctx context.Context, in *OrderCreateRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
 	err := c.cc.Invoke(ctx, "/OrderService/Create", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -47,6 +72,15 @@ func (c *orderServiceClient) Create(ctx context.Context, in *OrderCreateRequest,
 	return out, nil
 }
 
+func (c *orderServiceClient) Quote(ctx context.Context, in *OrderCreateRequest, opts ...grpc.CallOption) (*OrderQuote, error) {
+	out := new(OrderQuote)
+	err := c.cc.Invoke(ctx, "/OrderService/Quote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orderServiceClient) ChangeStatus(ctx context.Context, in *OrderChangeStatus, opts ...grpc.CallOption) (*OperationResponse, error) {
 	out := new(OperationResponse)
 	err := c.cc.Invoke(ctx, "/OrderService/ChangeStatus", in, out, opts...)
@@ -92,16 +126,257 @@ func (c *orderServiceClient) Cancel(ctx context.Context, in *OrderCancelRequest,
 	return out, nil
 }
 
+func (c *orderServiceClient) Expire(ctx context.Context, in *OrderExpireRequest, opts ...grpc.CallOption) (*OrderExpireResponse, error) {
+	out := new(OrderExpireResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/Expire", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreateCoupon(ctx context.Context, in *Coupon, opts ...grpc.CallOption) (*OperationResponse, error) {
+	out := new(OperationResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/CreateCoupon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) FindCoupon(ctx context.Context, in *CouponFindOneRequest, opts ...grpc.CallOption) (*CouponFindOneResponse, error) {
+	out := new(CouponFindOneResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/FindCoupon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) TaxReport(ctx context.Context, in *OrderTaxReportRequest, opts ...grpc.CallOption) (*OrderTaxReportResponse, error) {
+	out := new(OrderTaxReportResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/TaxReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) Refund(ctx context.Context, in *OrderRefundRequest, opts ...grpc.CallOption) (*OrderRefundResponse, error) {
+	out := new(OrderRefundResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/Refund", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CheckEntitlement(ctx context.Context, in *EntitlementCheckRequest, opts ...grpc.CallOption) (*EntitlementCheckResponse, error) {
+	out := new(EntitlementCheckResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/CheckEntitlement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListEntitlements(ctx context.Context, in *EntitlementListRequest, opts ...grpc.CallOption) (*EntitlementListResponse, error) {
+	out := new(EntitlementListResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/ListEntitlements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) Renew(ctx context.Context, in *OrderRenewRequest, opts ...grpc.CallOption) (*OrderRenewResponse, error) {
+	out := new(OrderRenewResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/Renew", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) SetAutoRenew(ctx context.Context, in *EntitlementAutoRenewRequest, opts ...grpc.CallOption) (*OperationResponse, error) {
+	out := new(OperationResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/SetAutoRenew", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) QuotePlanChange(ctx context.Context, in *PlanChangeRequest, opts ...grpc.CallOption) (*PlanChangeQuote, error) {
+	out := new(PlanChangeQuote)
+	err := c.cc.Invoke(ctx, "/OrderService/QuotePlanChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ChangePlan(ctx context.Context, in *PlanChangeRequest, opts ...grpc.CallOption) (*PlanChangeResponse, error) {
+	out := new(PlanChangeResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/ChangePlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CreateWebhook(ctx context.Context, in *WebhookSubscription, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, "/OrderService/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) UpdateWebhook(ctx context.Context, in *WebhookUpdateRequest, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, "/OrderService/UpdateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) DeleteWebhook(ctx context.Context, in *WebhookDeleteRequest, opts ...grpc.CallOption) (*OperationResponse, error) {
+	out := new(OperationResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListWebhooks(ctx context.Context, in *WebhookListRequest, opts ...grpc.CallOption) (*WebhookListResponse, error) {
+	out := new(WebhookListResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ListWebhookDeliveries(ctx context.Context, in *WebhookDeliveryListRequest, opts ...grpc.CallOption) (*WebhookDeliveryListResponse, error) {
+	out := new(WebhookDeliveryListResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/ListWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ReplayWebhookDelivery(ctx context.Context, in *WebhookReplayRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, "/OrderService/ReplayWebhookDelivery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) DispatchWebhooks(ctx context.Context, in *WebhookDispatchRequest, opts ...grpc.CallOption) (*WebhookDispatchResponse, error) {
+	out := new(WebhookDispatchResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/DispatchWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetInvoice(ctx context.Context, in *InvoiceRequest, opts ...grpc.CallOption) (*Invoice, error) {
+	out := new(Invoice)
+	err := c.cc.Invoke(ctx, "/OrderService/GetInvoice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetPaymentQR(ctx context.Context, in *PaymentQRRequest, opts ...grpc.CallOption) (*PaymentQR, error) {
+	out := new(PaymentQR)
+	err := c.cc.Invoke(ctx, "/OrderService/GetPaymentQR", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RetryPayment(ctx context.Context, in *RetryPaymentRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/OrderService/RetryPayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) Reconcile(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconciliationReport, error) {
+	out := new(ReconciliationReport)
+	err := c.cc.Invoke(ctx, "/OrderService/Reconcile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetLedgerBalances(ctx context.Context, in *LedgerBalanceRequest, opts ...grpc.CallOption) (*LedgerBalanceResponse, error) {
+	out := new(LedgerBalanceResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/GetLedgerBalances", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) CheckLedger(ctx context.Context, in *LedgerCheckRequest, opts ...grpc.CallOption) (*LedgerCheckResponse, error) {
+	out := new(LedgerCheckResponse)
+	err := c.cc.Invoke(ctx, "/OrderService/CheckLedger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility
 type OrderServiceServer interface {
-	Create(context.Context, *OrderCreateRequest) (*Empty, error)
+	Create(context.Context, *OrderCreateRequest) (*Order, error)
+	Quote(context.Context, *OrderCreateRequest) (*OrderQuote, error)
 	ChangeStatus(context.Context, *OrderChangeStatus) (*OperationResponse, error)
 	FindOne(context.Context, *OrderFindOneRequest) (*OrderFindOneResponse, error)
 	FindAll(context.Context, *OrderFindAllRequest) (*OrderFindAllResponse, error)
 	SumIncome(context.Context, *OrderSumIncomeRequest) (*OrderSumResponse, error)
 	Cancel(context.Context, *OrderCancelRequest) (*OperationResponse, error)
+	Expire(context.Context, *OrderExpireRequest) (*OrderExpireResponse, error)
+	CreateCoupon(context.Context, *Coupon) (*OperationResponse, error)
+	FindCoupon(context.Context, *CouponFindOneRequest) (*CouponFindOneResponse, error)
+	TaxReport(context.Context, *OrderTaxReportRequest) (*OrderTaxReportResponse, error)
+	Refund(context.Context, *OrderRefundRequest) (*OrderRefundResponse, error)
+	CheckEntitlement(context.Context, *EntitlementCheckRequest) (*EntitlementCheckResponse, error)
+	ListEntitlements(context.Context, *EntitlementListRequest) (*EntitlementListResponse, error)
+	Renew(context.Context, *OrderRenewRequest) (*OrderRenewResponse, error)
+	SetAutoRenew(context.Context, *EntitlementAutoRenewRequest) (*OperationResponse, error)
+	QuotePlanChange(context.Context, *PlanChangeRequest) (*PlanChangeQuote, error)
+	ChangePlan(context.Context, *PlanChangeRequest) (*PlanChangeResponse, error)
+	CreateWebhook(context.Context, *WebhookSubscription) (*WebhookSubscription, error)
+	UpdateWebhook(context.Context, *WebhookUpdateRequest) (*WebhookSubscription, error)
+	DeleteWebhook(context.Context, *WebhookDeleteRequest) (*OperationResponse, error)
+	ListWebhooks(context.Context, *WebhookListRequest) (*WebhookListResponse, error)
+	ListWebhookDeliveries(context.Context, *WebhookDeliveryListRequest) (*WebhookDeliveryListResponse, error)
+	ReplayWebhookDelivery(context.Context, *WebhookReplayRequest) (*WebhookDelivery, error)
+	DispatchWebhooks(context.Context, *WebhookDispatchRequest) (*WebhookDispatchResponse, error)
+	GetInvoice(context.Context, *InvoiceRequest) (*Invoice, error)
+	GetPaymentQR(context.Context, *PaymentQRRequest) (*PaymentQR, error)
+	RetryPayment(context.Context, *RetryPaymentRequest) (*Order, error)
+	Reconcile(context.Context, *ReconcileRequest) (*ReconciliationReport, error)
+	GetLedgerBalances(context.Context, *LedgerBalanceRequest) (*LedgerBalanceResponse, error)
+	CheckLedger(context.Context, *LedgerCheckRequest) (*LedgerCheckResponse, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -109,9 +384,12 @@ type OrderServiceServer interface {
 type UnimplementedOrderServiceServer struct {
 }
 
-func (UnimplementedOrderServiceServer) Create(context.Context, *OrderCreateRequest) (*Empty, error) {
+func (UnimplementedOrderServiceServer) Create(context.Context, *OrderCreateRequest) (*Order, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
+func (UnimplementedOrderServiceServer) Quote(context.Context, *OrderCreateRequest) (*OrderQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Quote not implemented")
+}
 func (UnimplementedOrderServiceServer) ChangeStatus(context.Context, *OrderChangeStatus) (*OperationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangeStatus not implemented")
 }
@@ -127,6 +405,78 @@ func (UnimplementedOrderServiceServer) SumIncome(context.Context, *OrderSumIncom
 func (UnimplementedOrderServiceServer) Cancel(context.Context, *OrderCancelRequest) (*OperationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
 }
+func (UnimplementedOrderServiceServer) Expire(context.Context, *OrderExpireRequest) (*OrderExpireResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Expire not implemented")
+}
+func (UnimplementedOrderServiceServer) CreateCoupon(context.Context, *Coupon) (*OperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCoupon not implemented")
+}
+func (UnimplementedOrderServiceServer) FindCoupon(context.Context, *CouponFindOneRequest) (*CouponFindOneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindCoupon not implemented")
+}
+func (UnimplementedOrderServiceServer) TaxReport(context.Context, *OrderTaxReportRequest) (*OrderTaxReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TaxReport not implemented")
+}
+func (UnimplementedOrderServiceServer) Refund(context.Context, *OrderRefundRequest) (*OrderRefundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refund not implemented")
+}
+func (UnimplementedOrderServiceServer) CheckEntitlement(context.Context, *EntitlementCheckRequest) (*EntitlementCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckEntitlement not implemented")
+}
+func (UnimplementedOrderServiceServer) ListEntitlements(context.Context, *EntitlementListRequest) (*EntitlementListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEntitlements not implemented")
+}
+func (UnimplementedOrderServiceServer) Renew(context.Context, *OrderRenewRequest) (*OrderRenewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Renew not implemented")
+}
+func (UnimplementedOrderServiceServer) SetAutoRenew(context.Context, *EntitlementAutoRenewRequest) (*OperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAutoRenew not implemented")
+}
+func (UnimplementedOrderServiceServer) QuotePlanChange(context.Context, *PlanChangeRequest) (*PlanChangeQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuotePlanChange not implemented")
+}
+func (UnimplementedOrderServiceServer) ChangePlan(context.Context, *PlanChangeRequest) (*PlanChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePlan not implemented")
+}
+func (UnimplementedOrderServiceServer) CreateWebhook(context.Context, *WebhookSubscription) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedOrderServiceServer) UpdateWebhook(context.Context, *WebhookUpdateRequest) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (UnimplementedOrderServiceServer) DeleteWebhook(context.Context, *WebhookDeleteRequest) (*OperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedOrderServiceServer) ListWebhooks(context.Context, *WebhookListRequest) (*WebhookListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedOrderServiceServer) ListWebhookDeliveries(context.Context, *WebhookDeliveryListRequest) (*WebhookDeliveryListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedOrderServiceServer) ReplayWebhookDelivery(context.Context, *WebhookReplayRequest) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWebhookDelivery not implemented")
+}
+func (UnimplementedOrderServiceServer) DispatchWebhooks(context.Context, *WebhookDispatchRequest) (*WebhookDispatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DispatchWebhooks not implemented")
+}
+func (UnimplementedOrderServiceServer) GetInvoice(context.Context, *InvoiceRequest) (*Invoice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvoice not implemented")
+}
+func (UnimplementedOrderServiceServer) GetPaymentQR(context.Context, *PaymentQRRequest) (*PaymentQR, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentQR not implemented")
+}
+func (UnimplementedOrderServiceServer) RetryPayment(context.Context, *RetryPaymentRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryPayment not implemented")
+}
+func (UnimplementedOrderServiceServer) Reconcile(context.Context, *ReconcileRequest) (*ReconciliationReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconcile not implemented")
+}
+func (UnimplementedOrderServiceServer) GetLedgerBalances(context.Context, *LedgerBalanceRequest) (*LedgerBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLedgerBalances not implemented")
+}
+func (UnimplementedOrderServiceServer) CheckLedger(context.Context, *LedgerCheckRequest) (*LedgerCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckLedger not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 
 // UnsafeOrderServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -158,6 +508,24 @@ func _OrderService_Create_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_Quote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Quote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/Quote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Quote(ctx, req.(*OrderCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrderService_ChangeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(OrderChangeStatus)
 	if err := dec(in); err != nil {
@@ -248,36 +616,568 @@ func _OrderService_Cancel_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
-// OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var OrderService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "OrderService",
-	HandlerType: (*OrderServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Create",
-			Handler:    _OrderService_Create_Handler,
-		},
-		{
-			MethodName: "ChangeStatus",
-			Handler:    _OrderService_ChangeStatus_Handler,
-		},
-		{
-			MethodName: "FindOne",
-			Handler:    _OrderService_FindOne_Handler,
-		},
-		{
-			MethodName: "FindAll",
-			Handler:    _OrderService_FindAll_Handler,
-		},
-		{
-			MethodName: "SumIncome",
-			Handler:    _OrderService_SumIncome_Handler,
-		},
-		{
-			MethodName: "Cancel",
-			Handler:    _OrderService_Cancel_Handler,
+func _OrderService_Expire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderExpireRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Expire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/Expire",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Expire(ctx, req.(*OrderExpireRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CreateCoupon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Coupon)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreateCoupon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/CreateCoupon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateCoupon(ctx, req.(*Coupon))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_FindCoupon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CouponFindOneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).FindCoupon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/FindCoupon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).FindCoupon(ctx, req.(*CouponFindOneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_TaxReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderTaxReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).TaxReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/TaxReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).TaxReport(ctx, req.(*OrderTaxReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_Refund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderRefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Refund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/Refund",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Refund(ctx, req.(*OrderRefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CheckEntitlement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntitlementCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CheckEntitlement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/CheckEntitlement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CheckEntitlement(ctx, req.(*EntitlementCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListEntitlements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntitlementListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListEntitlements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/ListEntitlements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListEntitlements(ctx, req.(*EntitlementListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_Renew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderRenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Renew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/Renew",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Renew(ctx, req.(*OrderRenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_SetAutoRenew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntitlementAutoRenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).SetAutoRenew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/SetAutoRenew",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).SetAutoRenew(ctx, req.(*EntitlementAutoRenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_QuotePlanChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).QuotePlanChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/QuotePlanChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).QuotePlanChange(ctx, req.(*PlanChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ChangePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ChangePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/ChangePlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ChangePlan(ctx, req.(*PlanChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookSubscription)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CreateWebhook(ctx, req.(*WebhookSubscription))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/UpdateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).UpdateWebhook(ctx, req.(*WebhookUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/DeleteWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).DeleteWebhook(ctx, req.(*WebhookDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListWebhooks(ctx, req.(*WebhookListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookDeliveryListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/ListWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListWebhookDeliveries(ctx, req.(*WebhookDeliveryListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ReplayWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ReplayWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/ReplayWebhookDelivery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ReplayWebhookDelivery(ctx, req.(*WebhookReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_DispatchWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookDispatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).DispatchWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/DispatchWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).DispatchWebhooks(ctx, req.(*WebhookDispatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/GetInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetInvoice(ctx, req.(*InvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetPaymentQR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentQRRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetPaymentQR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/GetPaymentQR",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetPaymentQR(ctx, req.(*PaymentQRRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RetryPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RetryPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/RetryPayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RetryPayment(ctx, req.(*RetryPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/Reconcile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).Reconcile(ctx, req.(*ReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetLedgerBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LedgerBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetLedgerBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/GetLedgerBalances",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetLedgerBalances(ctx, req.(*LedgerBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_CheckLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LedgerCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CheckLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/OrderService/CheckLedger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CheckLedger(ctx, req.(*LedgerCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "OrderService",
+	HandlerType: (*OrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _OrderService_Create_Handler,
+		},
+		{
+			MethodName: "Quote",
+			Handler:    _OrderService_Quote_Handler,
+		},
+		{
+			MethodName: "ChangeStatus",
+			Handler:    _OrderService_ChangeStatus_Handler,
+		},
+		{
+			MethodName: "FindOne",
+			Handler:    _OrderService_FindOne_Handler,
+		},
+		{
+			MethodName: "FindAll",
+			Handler:    _OrderService_FindAll_Handler,
+		},
+		{
+			MethodName: "SumIncome",
+			Handler:    _OrderService_SumIncome_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _OrderService_Cancel_Handler,
+		},
+		{
+			MethodName: "Expire",
+			Handler:    _OrderService_Expire_Handler,
+		},
+		{
+			MethodName: "CreateCoupon",
+			Handler:    _OrderService_CreateCoupon_Handler,
+		},
+		{
+			MethodName: "FindCoupon",
+			Handler:    _OrderService_FindCoupon_Handler,
+		},
+		{
+			MethodName: "TaxReport",
+			Handler:    _OrderService_TaxReport_Handler,
+		},
+		{
+			MethodName: "Refund",
+			Handler:    _OrderService_Refund_Handler,
+		},
+		{
+			MethodName: "CheckEntitlement",
+			Handler:    _OrderService_CheckEntitlement_Handler,
+		},
+		{
+			MethodName: "ListEntitlements",
+			Handler:    _OrderService_ListEntitlements_Handler,
+		},
+		{
+			MethodName: "Renew",
+			Handler:    _OrderService_Renew_Handler,
+		},
+		{
+			MethodName: "SetAutoRenew",
+			Handler:    _OrderService_SetAutoRenew_Handler,
+		},
+		{
+			MethodName: "QuotePlanChange",
+			Handler:    _OrderService_QuotePlanChange_Handler,
+		},
+		{
+			MethodName: "ChangePlan",
+			Handler:    _OrderService_ChangePlan_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _OrderService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _OrderService_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _OrderService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _OrderService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _OrderService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ReplayWebhookDelivery",
+			Handler:    _OrderService_ReplayWebhookDelivery_Handler,
+		},
+		{
+			MethodName: "DispatchWebhooks",
+			Handler:    _OrderService_DispatchWebhooks_Handler,
+		},
+		{
+			MethodName: "GetInvoice",
+			Handler:    _OrderService_GetInvoice_Handler,
+		},
+		{
+			MethodName: "GetPaymentQR",
+			Handler:    _OrderService_GetPaymentQR_Handler,
+		},
+		{
+			MethodName: "RetryPayment",
+			Handler:    _OrderService_RetryPayment_Handler,
+		},
+		{
+			MethodName: "Reconcile",
+			Handler:    _OrderService_Reconcile_Handler,
+		},
+		{
+			MethodName: "GetLedgerBalances",
+			Handler:    _OrderService_GetLedgerBalances_Handler,
+		},
+		{
+			MethodName: "CheckLedger",
+			Handler:    _OrderService_CheckLedger_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
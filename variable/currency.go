@@ -0,0 +1,18 @@
+package variable
+
+var (
+	// DefaultCurrency is the currency of orders stored before orders had one.
+	DefaultCurrency = "IDR"
+
+	// CurrencyExponents holds the number of minor unit digits of every
+	// supported currency. Rupiah amounts are whole rupiah, as charged by the
+	// payment gateway.
+	CurrencyExponents = map[string]int{
+		"IDR": 0,
+		"SGD": 2,
+		"MYR": 2,
+		"USD": 2,
+		"EUR": 2,
+		"JPY": 0,
+	}
+)
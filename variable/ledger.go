@@ -0,0 +1,32 @@
+package variable
+
+// Ledger accounts, named after their type: assets and expenses grow with
+// debits, liabilities and revenue with credits.
+var (
+	// AccountGatewayClearing is the money the gateways collected for us and
+	// have yet to pay out.
+	AccountGatewayClearing = "assets:gateway_clearing"
+	// AccountCustomerDeposits holds what customers paid towards orders that
+	// are not settled yet.
+	AccountCustomerDeposits = "liabilities:customer_deposits"
+	// AccountCustomerCredit and AccountRefundsPayable are what is owed to
+	// customers, as credit to spend or as money to pay back.
+	AccountCustomerCredit = "liabilities:customer_credit"
+	AccountRefundsPayable = "liabilities:refunds_payable"
+	AccountTaxPayable     = "liabilities:tax_payable"
+	AccountSales          = "revenue:sales"
+	// AccountRefunds and AccountCreditsGranted reduce revenue, by the
+	// refunds issued and the credits granted to customers.
+	AccountRefunds        = "revenue:refunds"
+	AccountCreditsGranted = "revenue:credits_granted"
+	AccountGatewayFees    = "expenses:gateway_fees"
+)
+
+// Journal kinds, the money movements that post to the ledger.
+var (
+	JournalReceipt    = "receipt"
+	JournalSettlement = "settlement"
+	JournalRefund     = "refund"
+	JournalFee        = "fee"
+	JournalCredit     = "credit"
+)
@@ -5,4 +5,170 @@ var (
 	PaymentStatusSettlement = "settlement"
 	PayementStatusCancel    = "cancel"
 	PayementStatusExpire    = "expire"
+	// PaymentStatusPartiallyRefunded and PaymentStatusRefunded are settled
+	// orders that had part or all of their payment refunded.
+	PaymentStatusPartiallyRefunded = "partially_refunded"
+	PaymentStatusRefunded          = "refunded"
+	// PaymentStatusPartiallyPaid orders received part of their total.
+	PaymentStatusPartiallyPaid = "partially_paid"
+)
+
+// PaymentTypeQRIS orders are paid by scanning the QRIS code of the order,
+// instead of transferring to a virtual account.
+var PaymentTypeQRIS = "qris"
+
+// Payment attempt statuses. An order has one active attempt at most; the
+// attempt it was paid by is paid, and the others are superseded.
+var (
+	PaymentAttemptActive     = "active"
+	PaymentAttemptSuperseded = "superseded"
+	PaymentAttemptPaid       = "paid"
+)
+
+// UnpaidStatuses are the statuses of orders waiting for their payment, or
+// the rest of it. They hold on to their virtual account number.
+var UnpaidStatuses = []string{PaymentStatusPending, PaymentStatusPartiallyPaid}
+
+// What the surplus of an overpaid order becomes: a credit on the account of
+// the customer, or money to pay back.
+var (
+	OverpaymentCredit    = "credit"
+	OverpaymentRefundDue = "refund_due"
+)
+
+// SettledStatuses are the statuses of orders that were paid.
+var SettledStatuses = []string{PaymentStatusSettlement, PaymentStatusPartiallyRefunded, PaymentStatusRefunded}
+
+var (
+	CouponTypePercentage = "percentage"
+	CouponTypeFixed      = "fixed"
+)
+
+// PlanChangeDiscountCode marks the discount of the unused value of the plan
+// an order moves the customer from.
+var PlanChangeDiscountCode = "PLAN_CHANGE"
+
+var (
+	TaxRoundingHalfUp = "half_up"
+	TaxRoundingDown   = "down"
+	TaxRoundingUp     = "up"
+)
+
+// Reconciliation outcomes, what a settlement report says of an order
+// compared to what the order says of itself.
+var (
+	ReconcileMatched          = "matched"
+	ReconcileAmountMismatch   = "amount_mismatch"
+	ReconcileStatusMismatch   = "status_mismatch"
+	ReconcileMissingInOurs    = "missing_in_ours"
+	ReconcileMissingInGateway = "missing_in_gateway"
+)
+
+// ReconcileActionSettled marks the orders a reconciliation settled.
+var ReconcileActionSettled = "settled"
+
+var (
+	ReportPeriodDay   = "day"
+	ReportPeriodMonth = "month"
+	ReportPeriodYear  = "year"
+)
+
+// Renewal stages, from the creation of a renewal order to its outcome.
+var (
+	RenewalStageCreated     = "created"
+	RenewalStageReminder    = "reminder"
+	RenewalStageFinalNotice = "final_notice"
+	RenewalStageLapsed      = "lapsed"
+	RenewalStagePaid        = "paid"
+	RenewalStageCancelled   = "cancelled"
+)
+
+// DunningStages are the stages of renewals still waiting for payment.
+var DunningStages = []string{RenewalStageCreated, RenewalStageReminder, RenewalStageFinalNotice}
+
+// Order event types written to the outbox.
+var (
+	OrderEventCreated       = "order.created"
+	OrderEventSettled       = "order.settled"
+	OrderEventCancelled     = "order.cancelled"
+	OrderEventExpired       = "order.expired"
+	OrderEventRefunded      = "order.refunded"
+	OrderEventStatusChanged = "order.status_changed"
+	// OrderEventPaymentRetried records a new payment attempt on an order.
+	OrderEventPaymentRetried = "order.payment_retried"
+	// OrderEventPartiallyPaid records that an order received part of its
+	// total, and OrderEventPaymentReceived money received for an order
+	// without changing its status, e.g. an overpayment.
+	OrderEventPartiallyPaid   = "order.partially_paid"
+	OrderEventPaymentReceived = "order.payment_received"
+	// OrderEventRenewalReminder, OrderEventRenewalFinalNotice and
+	// OrderEventRenewalLapsed record that the unpaid renewal order moved to
+	// the dunning stage they are named after.
+	OrderEventRenewalReminder    = "order.renewal_reminder"
+	OrderEventRenewalFinalNotice = "order.renewal_final_notice"
+	OrderEventRenewalLapsed      = "order.renewal_lapsed"
+)
+
+// OrderEventTypes are the event types webhooks can subscribe to.
+var OrderEventTypes = []string{
+	OrderEventCreated,
+	OrderEventSettled,
+	OrderEventCancelled,
+	OrderEventExpired,
+	OrderEventRefunded,
+	OrderEventStatusChanged,
+	OrderEventPaymentRetried,
+	OrderEventPartiallyPaid,
+	OrderEventPaymentReceived,
+	OrderEventRenewalReminder,
+	OrderEventRenewalFinalNotice,
+	OrderEventRenewalLapsed,
+}
+
+// Webhook delivery statuses.
+var (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Notification templates, each sent to the buyer on the order event it is
+// named after.
+var (
+	NotificationOrderCreated       = "order_created"
+	NotificationVAIssued           = "va_issued"
+	NotificationPaymentSettled     = "payment_settled"
+	NotificationOrderExpired       = "order_expired"
+	NotificationRenewalReminder    = "renewal_reminder"
+	NotificationRenewalFinalNotice = "renewal_final_notice"
+	NotificationRenewalLapsed      = "renewal_lapsed"
+)
+
+// Notification channels, in the order a notification is sent through them.
+var (
+	NotificationChannelEmail = "email"
+	NotificationChannelSMS   = "sms"
+)
+
+// NotificationChannels lists every notification channel.
+var NotificationChannels = []string{NotificationChannelEmail, NotificationChannelSMS}
+
+// Notification statuses.
+var (
+	NotificationSending = "sending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+)
+
+// Invoice formats.
+var (
+	InvoiceFormatPDF  = "pdf"
+	InvoiceFormatHTML = "html"
+)
+
+// Check digit algorithms of virtual account schemes.
+var (
+	VACheckDigitNone  = "none"
+	VACheckDigitLuhn  = "luhn"
+	VACheckDigitMod11 = "mod11"
 )
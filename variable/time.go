@@ -3,9 +3,3 @@ package variable
 var (
 	TimeLayout = "2006-01-02 15:04:05"
 )
-
-var (
-	UnixNano  = "nano"
-	UnixMili  = "mili"
-	UnixMicro = "micro"
-)